@@ -0,0 +1,97 @@
+package app
+
+import (
+	"errors"
+	"github.com/spf13/viper"
+	"simple-order-api/cmd/exports"
+	"simple-order-api/cmd/models"
+	"strings"
+)
+
+// loadServerConfig reads config.yaml from the working directory when present and
+// lets every key be overridden by an ORDER_API_ prefixed environment variable,
+// e.g. ORDER_API_TRACING_EXPORTER=otlp.
+func loadServerConfig() (models.ServerConfig, error) {
+	config := viper.New()
+	config.SetConfigName("config")
+	config.SetConfigType("yaml")
+	config.AddConfigPath(".")
+	config.SetEnvPrefix("ORDER_API")
+	config.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.AutomaticEnv()
+	setConfigDefaults(config)
+
+	serverConfig := models.ServerConfig{}
+	if err := config.ReadInConfig(); err != nil {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return serverConfig, err
+		}
+	}
+
+	err := config.Unmarshal(&serverConfig)
+	return serverConfig, err
+}
+
+func setConfigDefaults(config *viper.Viper) {
+	config.SetDefault("port", ":8080")
+	config.SetDefault("host", "localhost:8080")
+	config.SetDefault("grpc.enabled", true)
+	config.SetDefault("grpc.port", ":9090")
+	config.SetDefault("tracing.enabled", false)
+	config.SetDefault("tracing.serviceName", "simple-order-api")
+	config.SetDefault("tracing.exporter", "stdout")
+	config.SetDefault("tracing.otlpEndpoint", "localhost:4318")
+	config.SetDefault("tracing.otlpInsecure", true)
+	config.SetDefault("tracing.sampleRatio", 1.0)
+	config.SetDefault("auth.enabled", false)
+	config.SetDefault("auth.hmacSecret", "")
+	config.SetDefault("auth.rsaPublicKeyFile", "")
+	config.SetDefault("auth.jwksFile", "")
+	config.SetDefault("auth.issuer", "")
+	config.SetDefault("auth.audience", "")
+	config.SetDefault("auth.publicPaths", []string{"/swagger/*", "/health", "/geo/*"})
+	config.SetDefault("auth.adminApiKey", "")
+	config.SetDefault("cors.allowedOrigins", []string{"*"})
+	config.SetDefault("cors.allowedMethods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	config.SetDefault("cors.allowedHeaders", []string{
+		"Content-Type", "Content-Length", "Accept", "Accept-Encoding", "Authorization", "X-API-Key", "traceparent",
+	})
+	config.SetDefault("cors.exposedHeaders", []string{
+		"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "traceparent",
+	})
+	config.SetDefault("cors.allowCredentials", false)
+	config.SetDefault("cors.maxAge", "10m")
+	config.SetDefault("rateLimit.enabled", false)
+	config.SetDefault("rateLimit.default.limit", 300)
+	config.SetDefault("rateLimit.default.period", "1m")
+	config.SetDefault("rateLimit.routes", []map[string]interface{}{
+		{"method": "POST", "path": "/orders", "limit": 30, "period": "1m"},
+	})
+	config.SetDefault("rateLimit.ipLimitFactor", 10)
+	config.SetDefault("rateLimit.trustedProxies", []string{})
+	config.SetDefault("outbox.pollInterval", "1s")
+	config.SetDefault("outbox.batchSize", 100)
+	config.SetDefault("outbox.maxAttempts", 5)
+	config.SetDefault("outbox.initialBackoff", "1s")
+	config.SetDefault("outbox.maxBackoff", "1m")
+	config.SetDefault("outbox.retention", "1h")
+	config.SetDefault("webhooks.timeout", "10s")
+	config.SetDefault("webhooks.maxAttempts", 6)
+	config.SetDefault("webhooks.initialBackoff", "5s")
+	config.SetDefault("webhooks.maxBackoff", "10m")
+	config.SetDefault("webhooks.allowInternalHosts", false)
+	config.SetDefault("eventStream.replayBufferSize", 1000)
+	config.SetDefault("eventStream.clientBufferSize", 64)
+	config.SetDefault("eventStream.heartbeatInterval", "15s")
+	config.SetDefault("webSocket.pingInterval", "30s")
+	config.SetDefault("webSocket.pongWait", "60s")
+	config.SetDefault("webSocket.writeWait", "10s")
+	config.SetDefault("webSocket.maxSubscriptions", 20)
+	config.SetDefault("graphql.playground", false)
+	config.SetDefault("export.columns", exports.ColumnKeys())
+	config.SetDefault("export.locale", "en-US")
+	config.SetDefault("import.maxFileSize", 32<<20)
+	config.SetDefault("import.syncRowLimit", 100)
+	config.SetDefault("import.maxReportedErrors", 1000)
+}
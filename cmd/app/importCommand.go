@@ -0,0 +1,173 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/imports"
+	"simple-order-api/cmd/models/response"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// importCommand uploads an order file to the import endpoint of a running server, since
+// the orders live in the server's store, and follows the import job until it is done.
+type importCommand struct {
+	serverUrl    string
+	token        string
+	apiKey       string
+	pollInterval time.Duration
+	httpClient   *http.Client
+	output       io.Writer
+}
+
+// RunImportCommand runs "import [flags] <file>" and returns the exit code: 0 when every
+// row was imported, 1 when rows failed or the import was cancelled and 2 when the file
+// could not be imported at all.
+func RunImportCommand(args []string, output io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(output)
+	command := &importCommand{httpClient: &http.Client{Timeout: time.Minute}, output: output}
+	flags.StringVar(&command.serverUrl, "url", "http://localhost:8080", "base url of the order api")
+	flags.StringVar(&command.token, "token", os.Getenv("ORDER_API_TOKEN"), "bearer token, defaults to $ORDER_API_TOKEN")
+	flags.StringVar(&command.apiKey, "apiKey", os.Getenv("ORDER_API_KEY"), "api key, defaults to $ORDER_API_KEY")
+	flags.DurationVar(&command.pollInterval, "pollInterval", time.Second, "interval of the progress polls of background imports")
+	format := flags.String("format", "", "csv or ndjson, taken from the file extension when missing")
+	dryRun := flags.Bool("dryRun", false, "only validate the rows")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(output, "Usage: import [flags] <file>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		if err == nil {
+			flags.Usage()
+		}
+		return 2
+	}
+
+	fileName := flags.Arg(0)
+	if len(*format) == 0 {
+		*format = importFileFormat(fileName)
+	}
+	if !imports.IsValidFormat(*format) {
+		_, _ = fmt.Fprintf(output, "Import format %q is not supported, use csv or ndjson\n", *format)
+		return 2
+	}
+
+	importJob, err := command.importFile(fileName, *format, *dryRun)
+	if err != nil {
+		_, _ = fmt.Fprintf(output, "Orders could not be imported: %s\n", err)
+		return 2
+	}
+
+	command.printReport(*importJob)
+	if importJob.Status != string(enum.CompletedImportStatus) || importJob.FailedRows > 0 {
+		return 1
+	}
+	return 0
+}
+
+func (command *importCommand) importFile(fileName string, format string, dryRun bool) (*response.ImportJob, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	query := url.Values{"format": {format}, "dryRun": {strconv.FormatBool(dryRun)}}
+	req, err := http.NewRequest(http.MethodPost, command.serverUrl+"/orders/imports?"+query.Encode(), file)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", importContentType(format))
+
+	importJob := &response.ImportJob{}
+	if err = command.send(req, importJob); err != nil {
+		return nil, err
+	}
+
+	for importJob.Status == string(enum.RunningImportStatus) {
+		_, _ = fmt.Fprintf(command.output, "Imported %d of %d rows...\n", importJob.ProcessedRows, importJob.TotalRows)
+		time.Sleep(command.pollInterval)
+
+		req, err = http.NewRequest(http.MethodGet, command.serverUrl+"/orders/imports/"+url.PathEscape(importJob.Id), nil)
+		if err != nil {
+			return nil, err
+		}
+		if err = command.send(req, importJob); err != nil {
+			return nil, err
+		}
+	}
+
+	return importJob, nil
+}
+
+// send authenticates the request and decodes the job, or the api error as an error.
+func (command *importCommand) send(req *http.Request, importJob *response.ImportJob) error {
+	if len(command.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+command.token)
+	}
+	if len(command.apiKey) > 0 {
+		req.Header.Set(constants.ApiKeyHeader, command.apiKey)
+	}
+
+	resp, err := command.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		errorResponse := response.ErrorResponse{}
+		if err = json.NewDecoder(resp.Body).Decode(&errorResponse); err != nil || len(errorResponse.Message) == 0 {
+			return fmt.Errorf("server responded with %s", resp.Status)
+		}
+		return fmt.Errorf("%s (%d)", errorResponse.Message, errorResponse.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(importJob)
+}
+
+func (command *importCommand) printReport(importJob response.ImportJob) {
+	mode := "imported"
+	if importJob.DryRun {
+		mode = "valid (dry run)"
+	}
+	_, _ = fmt.Fprintf(command.output, "Import %s %s: %d of %d rows processed, %d %s, %d failed\n",
+		importJob.Id, importJob.Status, importJob.ProcessedRows, importJob.TotalRows, importJob.ImportedRows, mode, importJob.FailedRows)
+
+	for _, rowError := range importJob.Errors {
+		_, _ = fmt.Fprintf(command.output, "  row %d", rowError.Row)
+		if len(rowError.OrderNumber) > 0 {
+			_, _ = fmt.Fprintf(command.output, " (order %s)", rowError.OrderNumber)
+		}
+		_, _ = fmt.Fprintf(command.output, ": %s\n", rowError.Error.Message)
+	}
+	if omitted := importJob.FailedRows - len(importJob.Errors); omitted > 0 {
+		_, _ = fmt.Fprintf(command.output, "  ... %d more failed rows\n", omitted)
+	}
+}
+
+func importFileFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ndjson", ".jsonl":
+		return imports.NdjsonFormat
+	default:
+		return imports.CsvFormat
+	}
+}
+
+func importContentType(format string) string {
+	if format == imports.NdjsonFormat {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
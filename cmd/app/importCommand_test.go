@@ -0,0 +1,143 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func writeImportFile(t *testing.T, name string, content string) string {
+	fileName := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+	return fileName
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestRunImportCommand_UploadsFileAndReturnsZeroWhenEveryRowIsImported(t *testing.T) {
+	//Given
+	var uploadedRequest *http.Request
+	var uploadedContent []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploadedRequest = r
+		uploadedContent, _ = io.ReadAll(r.Body)
+		writeJson(w, http.StatusCreated, response.ImportJob{
+			Id: "job-1", Status: string(enum.CompletedImportStatus), DryRun: true, TotalRows: 1, ProcessedRows: 1, ImportedRows: 1,
+		})
+	}))
+	defer server.Close()
+	fileName := writeImportFile(t, "orders.ndjson", `{"orderNumber": "1"}`+"\n")
+	output := &bytes.Buffer{}
+
+	//When
+	exitCode := RunImportCommand([]string{"-url", server.URL, "-apiKey", "key", "-dryRun", fileName}, output)
+
+	//Then
+	assert.Equal(t, 0, exitCode)
+	require.NotNil(t, uploadedRequest)
+	assert.Equal(t, http.MethodPost, uploadedRequest.Method)
+	assert.Equal(t, "/orders/imports", uploadedRequest.URL.Path)
+	assert.Equal(t, "ndjson", uploadedRequest.URL.Query().Get("format"))
+	assert.Equal(t, "true", uploadedRequest.URL.Query().Get("dryRun"))
+	assert.Equal(t, "application/x-ndjson", uploadedRequest.Header.Get("Content-Type"))
+	assert.Equal(t, "key", uploadedRequest.Header.Get(constants.ApiKeyHeader))
+	assert.Equal(t, `{"orderNumber": "1"}`+"\n", string(uploadedContent))
+	assert.Contains(t, output.String(), "Import job-1 completed: 1 of 1 rows processed, 1 valid (dry run), 0 failed")
+}
+
+func TestRunImportCommand_PollsBackgroundJobAndReportsFailedRows(t *testing.T) {
+	//Given
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			writeJson(w, http.StatusAccepted, response.ImportJob{Id: "job-1", Status: string(enum.RunningImportStatus), TotalRows: 3})
+			return
+		}
+
+		polls++
+		assert.Equal(t, "/orders/imports/job-1", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		writeJson(w, http.StatusOK, response.ImportJob{
+			Id: "job-1", Status: string(enum.CompletedImportStatus), TotalRows: 3, ProcessedRows: 3, ImportedRows: 1, FailedRows: 2,
+			Errors: []response.ImportRowError{{
+				Row:         3,
+				OrderNumber: "2",
+				Error:       response.NewErrorBuilder().SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).Build(),
+			}},
+		})
+	}))
+	defer server.Close()
+	fileName := writeImportFile(t, "orders.csv", "orderNumber\n1\n2\n3\n")
+	output := &bytes.Buffer{}
+
+	//When
+	exitCode := RunImportCommand([]string{"-url", server.URL, "-token", "token", "-pollInterval", "1ms", fileName}, output)
+
+	//Then
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, 1, polls)
+	assert.Contains(t, output.String(), "Imported 0 of 3 rows...")
+	assert.Contains(t, output.String(), "1 imported, 2 failed")
+	assert.Contains(t, output.String(), "  row 3 (order 2): "+constants.SameOrderFoundByUniqueId)
+	assert.Contains(t, output.String(), "  ... 1 more failed rows")
+}
+
+func TestRunImportCommand_WhenServerRefusesFile_ReturnsTwoWithItsError(t *testing.T) {
+	//Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusBadRequest, response.NewErrorBuilder().SetError(http.StatusBadRequest, constants.ImportFileIsNotValid).Build())
+	}))
+	defer server.Close()
+	fileName := writeImportFile(t, "orders.csv", "password\nsecret\n")
+	output := &bytes.Buffer{}
+
+	//When
+	exitCode := RunImportCommand([]string{"-url", server.URL, fileName}, output)
+
+	//Then
+	assert.Equal(t, 2, exitCode)
+	assert.Contains(t, output.String(), "Orders could not be imported: "+constants.ImportFileIsNotValid+" (400)")
+}
+
+func TestRunImportCommand_WhenArgumentsAreNotValid_ReturnsTwo(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing file", []string{}},
+		{"unknown flag", []string{"-verbose", "orders.csv"}},
+		{"unsupported format", []string{"-format", "xml", "orders.xml"}},
+		{"missing file on disk", []string{"-url", "http://127.0.0.1:0", filepath.Join(os.TempDir(), "missing-orders.csv")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//When
+			exitCode := RunImportCommand(test.args, &bytes.Buffer{})
+
+			//Then
+			assert.Equal(t, 2, exitCode)
+		})
+	}
+}
+
+func TestImportFileFormat_TakesFormatFromExtension(t *testing.T) {
+	assert.Equal(t, "ndjson", importFileFormat("orders.NDJSON"))
+	assert.Equal(t, "ndjson", importFileFormat("orders.jsonl"))
+	assert.Equal(t, "csv", importFileFormat("orders.csv"))
+	assert.Equal(t, "csv", importFileFormat("orders"))
+}
@@ -1,54 +1,201 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
 	controllers2 "simple-order-api/cmd/controllers"
 	"simple-order-api/cmd/docs"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/graphqlserver"
+	"simple-order-api/cmd/grpcserver"
+	"simple-order-api/cmd/middlewares"
 	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/policies"
 	"simple-order-api/cmd/repositories"
 	"simple-order-api/cmd/services"
+	"syscall"
+	"time"
 )
 
 func StartServer() {
-	serverConfig := models.ServerConfig{
-		Port: ":8080",
-		Host: "localhost:8080",
+	serverConfig, err := loadServerConfig()
+	if err != nil {
+		fmt.Println("An error has occured while loading server config!")
+		panic(err)
 	}
+
+	shutdownTracing, err := initTracing(serverConfig.Tracing)
+	if err != nil {
+		fmt.Println("An error has occured while initializing tracing!")
+		panic(err)
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	docs.SwaggerInfo.Host = serverConfig.Host
-	engine := setHttpServerConfigs()
-	orderRepository := repositories.NewOrderRepository()
-	orderService := services.NewOrderService(orderRepository)
+	apiKeyRepository := repositories.NewApiKeyRepository()
+	apiKeyService := services.NewApiKeyService(apiKeyRepository)
+	if len(serverConfig.Auth.AdminApiKey) > 0 {
+		errorResp := apiKeyService.RegisterApiKey(context.Background(), "admin", serverConfig.Auth.AdminApiKey, []string{policies.ApiKeysScope})
+		if errorResp != nil {
+			fmt.Println("An error has occured while registering the admin api key!")
+			panic(errorResp)
+		}
+	}
+	authenticator, err := middlewares.NewAuthenticator(serverConfig.Auth, apiKeyService)
+	if err != nil {
+		fmt.Println("An error has occured while configuring authentication!")
+		panic(err)
+	}
+	rateLimitStore := middlewares.NewMemoryRateLimitStore()
+	engine, err := setHttpServerConfigs(serverConfig, authenticator, rateLimitStore)
+	if err != nil {
+		fmt.Println("An error has occured while configuring web server!")
+		panic(err)
+	}
+
+	database := repositories.NewInMemoryDatabase()
+	orderRepository := repositories.NewOrderRepository(database)
+	auditRepository := repositories.NewAuditRepository()
+	customerRepository := repositories.NewCustomerRepository()
+	// Deferred in reverse: the relay stops first, then the bus drains into the subscribers.
+	webhookService := services.NewWebhookService(repositories.NewWebhookRepository(), serverConfig.Webhooks)
+	defer webhookService.Close()
+	eventBus := events.NewEventBus()
+	defer eventBus.Close()
+	eventBus.SubscribeAsync(webhookService.HandleEvent)
+	eventStream := events.NewEventStream(serverConfig.EventStream.ReplayBufferSize, serverConfig.EventStream.ClientBufferSize)
+	eventBus.Subscribe(eventStream.HandleEvent)
+	outboxRelay := services.NewOutboxRelay(repositories.NewOutboxRepository(database), eventBus, serverConfig.Outbox)
+	outboxRelay.Start()
+	defer outboxRelay.Stop()
+	geoService := services.NewGeoService(repositories.NewGeoRepository())
+	productRepository := repositories.NewProductRepository()
+	inventoryRepository := repositories.NewInventoryRepository()
+	promotionRepository := repositories.NewPromotionRepository()
+	orderService := services.NewOrderService(orderRepository, auditRepository, customerRepository, geoService, productRepository, inventoryRepository, promotionRepository)
 	orderController := controllers2.NewOrderController(orderService)
+	productController := controllers2.NewProductController(services.NewProductService(productRepository))
+	inventoryController := controllers2.NewInventoryController(services.NewInventoryService(inventoryRepository, productRepository))
+	promotionController := controllers2.NewPromotionController(services.NewPromotionService(promotionRepository))
+	customerService := services.NewCustomerService(customerRepository, orderRepository)
+	customerController := controllers2.NewCustomerController(customerService)
+	geoController := controllers2.NewGeoController(geoService)
 	swaggerController := controllers2.NewSwaggerController()
+	healthController := controllers2.NewHealthController()
+	apiKeyController := controllers2.NewApiKeyController(apiKeyService)
+	webhookController := controllers2.NewWebhookController(webhookService)
+	orderExportController := controllers2.NewOrderExportController(orderService, serverConfig.Export)
+	importService := services.NewImportService(orderService, repositories.NewImportJobRepository(), serverConfig.Import)
+	defer importService.Close()
+	orderImportController := controllers2.NewOrderImportController(importService, serverConfig.Import)
+	orderEventController := controllers2.NewOrderEventController(eventStream, serverConfig.EventStream.HeartbeatInterval)
+	orderTrackingController := controllers2.NewOrderTrackingController(orderService, eventStream, serverConfig.WebSocket, serverConfig.Cors.AllowedOrigins)
+	orderSchema, err := graphqlserver.NewSchema(orderService)
+	if err != nil {
+		fmt.Println("An error has occured while building graphql schema!")
+		panic(err)
+	}
+	graphqlController := controllers2.NewGraphqlController(orderSchema, serverConfig.Graphql.Playground)
 	swaggerController.Register(engine)
+	healthController.Register(engine)
 	orderController.Register(engine)
+	productController.Register(engine)
+	inventoryController.Register(engine)
+	promotionController.Register(engine)
+	customerController.Register(engine)
+	geoController.Register(engine)
+	orderExportController.Register(engine)
+	orderImportController.Register(engine)
+	apiKeyController.Register(engine)
+	webhookController.Register(engine)
+	orderEventController.Register(engine)
+	orderTrackingController.Register(engine)
+	graphqlController.Register(engine)
+	grpcServer := grpcserver.NewGrpcServer(orderService, authenticator, serverConfig.RateLimit, rateLimitStore)
 
 	fmt.Println("Order web server begins to start!")
 
-	if err := engine.Run(serverConfig.Port); err != nil {
-		fmt.Println("An error has occured while starting web server!")
-		panic(true)
+	server := &http.Server{Addr: serverConfig.Port, Handler: engine}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Println("An error has occured while starting web server!")
+			panic(true)
+		}
+	}()
+
+	if serverConfig.Grpc.Enabled {
+		listener, err := net.Listen("tcp", serverConfig.Grpc.Port)
+		if err != nil {
+			fmt.Println("An error has occured while starting grpc server!")
+			panic(err)
+		}
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				fmt.Println("An error has occured while serving grpc!")
+				panic(err)
+			}
+		}()
 	}
+
+	waitForShutdown(server, grpcServer)
 }
 
-func setHttpServerConfigs() *gin.Engine {
+// waitForShutdown blocks until the process is interrupted and then drains
+// in-flight requests so that deferred cleanups, like flushing spans, can run.
+func waitForShutdown(server *http.Server, grpcServer *grpc.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	fmt.Println("Order web server is shutting down!")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+}
+
+func setHttpServerConfigs(serverConfig models.ServerConfig, authenticator middlewares.Authenticator, rateLimitStore middlewares.RateLimitStore) (*gin.Engine, error) {
 	engine := gin.New()
+	if err := engine.SetTrustedProxies(serverConfig.RateLimit.TrustedProxies); err != nil {
+		return nil, err
+	}
 	engine.Use(gin.Recovery())
-	engine.Use(corsMiddleware())
-	return engine
-}
+	engine.Use(otelgin.Middleware(serverConfig.Tracing.ServiceName))
+	engine.Use(traceResponseMiddleware())
+	engine.Use(middlewares.RequestIdMiddleware())
 
-func corsMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-		c.Next()
+	corsMiddleware, err := middlewares.NewCorsMiddleware(serverConfig.Cors)
+	if err != nil {
+		return nil, err
 	}
+	engine.Use(corsMiddleware)
+
+	if serverConfig.RateLimit.Enabled {
+		engine.Use(middlewares.NewRateLimitMiddleware(serverConfig.RateLimit, rateLimitStore))
+	}
+
+	engine.Use(middlewares.NewAuthMiddleware(serverConfig.Auth, authenticator))
+
+	if serverConfig.RateLimit.Enabled {
+		engine.Use(middlewares.NewClientRateLimitMiddleware(serverConfig.RateLimit, rateLimitStore))
+	}
+
+	return engine, nil
 }
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"simple-order-api/cmd/models"
+)
+
+const (
+	stdoutExporter = "stdout"
+	otlpExporter   = "otlp"
+)
+
+// initTracing installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans on shutdown.
+func initTracing(config models.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !config.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newSpanExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(config.ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return tracerProvider.Shutdown, nil
+}
+
+func newSpanExporter(config models.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case stdoutExporter:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case otlpExporter:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.OtlpEndpoint)}
+		if config.OtlpInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+}
+
+// traceResponseMiddleware writes the traceparent of the server span back to the
+// caller so a request can be looked up in the tracing backend.
+func traceResponseMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		otel.GetTextMapPropagator().Inject(c.Request.Context(), propagation.HeaderCarrier(c.Writer.Header()))
+		c.Next()
+	}
+}
@@ -16,4 +16,119 @@ const (
 	CreateOrderRequestIsNotValid             = "create.order.request.is.not.valid"
 	UpdateOrderRequestIsNotValid             = "update.order.request.is.not.valid"
 	OrderChangeNotPermittedBecauseOfStatus   = "order.change.not.permitted.because.of.status"
+	RequestTimedOut                          = "request.timed.out"
+	RequestCancelled                         = "request.cancelled"
+	Principal                                = "principal"
+	AuthorizationTokenIsMissing              = "authorization.token.is.missing"
+	AuthorizationTokenIsNotValid             = "authorization.token.is.not.valid"
+	PermissionDenied                         = "permission.denied"
+	OrderAccessDenied                        = "order.access.denied"
+	StatusIsNotValid                         = "status.is.not.valid"
+	TransitionOrderRequestIsNotValid         = "transition.order.request.is.not.valid"
+	OrderStatusTransitionIsNotValid          = "order.status.transition.is.not.valid"
+	OrderStatusHasChanged                    = "order.status.has.changed"
+	UnexpectedErrorOccurred                  = "unexpected.error.occurred"
+	ApiKeyHeader                             = "X-API-Key"
+	ApiKeyId                                 = "apiKeyId"
+	ApiKeyIsNotValid                         = "api.key.is.not.valid"
+	ApiKeyNotFound                           = "api.key.not.found"
+	ApiKeyIdIsNotValid                       = "api.key.id.is.not.valid"
+	ApiKeyNameIsNotValid                     = "api.key.name.is.not.valid"
+	ApiKeyScopesAreNotValid                  = "api.key.scopes.are.not.valid"
+	CreateApiKeyRequestIsNotValid            = "create.api.key.request.is.not.valid"
+	RateLimitExceeded                        = "rate.limit.exceeded"
+	RequestIdHeader                          = "X-Request-ID"
+	RequestId                                = "requestId"
+	EventType                                = "eventType"
+	OutboxMessageId                          = "outboxMessageId"
+	PrunedOutboxMessages                     = "prunedOutboxMessages"
+	PublishError                             = "publishError"
+	OutboxMessageIsDeadLettered              = "outbox.message.is.dead.lettered"
+	WebhookId                                = "webhookId"
+	DeliveryId                               = "deliveryId"
+	WebhookNotFound                          = "webhook.not.found"
+	WebhookIdIsNotValid                      = "webhook.id.is.not.valid"
+	WebhookUrlIsNotValid                     = "webhook.url.is.not.valid"
+	WebhookEventTypesAreNotValid             = "webhook.event.types.are.not.valid"
+	WebhookSecretIsNotValid                  = "webhook.secret.is.not.valid"
+	CreateWebhookRequestIsNotValid           = "create.webhook.request.is.not.valid"
+	WebhookDeliveryNotFound                  = "webhook.delivery.not.found"
+	WebhookDeliveryIdIsNotValid              = "webhook.delivery.id.is.not.valid"
+	WebhookSignatureHeader                   = "X-Webhook-Signature"
+	WebhookTimestampHeader                   = "X-Webhook-Timestamp"
+	WebhookEventHeader                       = "X-Webhook-Event"
+	WebhookDeliveryHeader                    = "X-Webhook-Delivery"
+	LastEventIdHeader                        = "Last-Event-ID"
+	AccessTokenQuery                         = "access_token"
+	SubscriptionRequestIsNotValid            = "subscription.request.is.not.valid"
+	SubscriptionLimitExceeded                = "subscription.limit.exceeded"
+	PagingIsNotValid                         = "paging.is.not.valid"
+	GraphqlRequestIsNotValid                 = "graphql.request.is.not.valid"
+	OrderFilterIsNotValid                    = "order.filter.is.not.valid"
+	ExportFormatIsNotValid                   = "export.format.is.not.valid"
+	ExportColumnsAreNotValid                 = "export.columns.are.not.valid"
+	LocaleIsNotValid                         = "locale.is.not.valid"
+	ImportJobId                              = "importJobId"
+	ImportJobIdIsNotValid                    = "import.job.id.is.not.valid"
+	ImportJobNotFound                        = "import.job.not.found"
+	ImportFormatIsNotValid                   = "import.format.is.not.valid"
+	ImportFileIsNotValid                     = "import.file.is.not.valid"
+	ImportFileIsTooLarge                     = "import.file.is.too.large"
+	ImportRowIsNotValid                      = "import.row.is.not.valid"
+	CustomerId                               = "customerId"
+	CustomerIdIsNotValid                     = "customer.id.is.not.valid"
+	CustomerNotFound                         = "customer.not.found"
+	CustomerAccessDenied                     = "customer.access.denied"
+	CustomerRequestIsNotValid                = "customer.request.is.not.valid"
+	CustomerReferenceIsNotValid              = "customer.reference.is.not.valid"
+	EmailIsNotValid                          = "email.is.not.valid"
+	PhoneIsNotValid                          = "phone.is.not.valid"
+	SameCustomerFoundByUniqueId              = "same.customer.found.by.unique.id"
+	SameCustomerFoundByEmail                 = "same.customer.found.by.email"
+	PostalCodeIsNotValid                     = "postal.code.is.not.valid"
+	CountryCodeIsNotValid                    = "country.code.is.not.valid"
+	CountryCodeIsRequired                    = "country.code.is.required"
+	CustomerWithOrdersCanNotBeDeleted        = "customer.with.orders.can.not.be.deleted"
+	CityId                                   = "cityId"
+	CityIdIsNotValid                         = "city.id.is.not.valid"
+	CityNotFound                             = "city.not.found"
+	DistrictDoesNotBelongToCity              = "district.does.not.belong.to.city"
+	Sku                                      = "sku"
+	SkuIsNotValid                            = "sku.is.not.valid"
+	ProductNameIsNotValid                    = "product.name.is.not.valid"
+	ProductDescriptionIsNotValid             = "product.description.is.not.valid"
+	ProductPricesAreNotValid                 = "product.prices.are.not.valid"
+	ProductRequestIsNotValid                 = "product.request.is.not.valid"
+	ProductNotFound                          = "product.not.found"
+	SameProductFoundBySku                    = "same.product.found.by.sku"
+	ProductIsNotActive                       = "product.is.not.active"
+	ProductPriceIsNotAvailable               = "product.price.is.not.available"
+	OrderItemsAreNotValid                    = "order.items.are.not.valid"
+	OrderItemsAreRequired                    = "order.items.are.required"
+	WarehouseId                              = "warehouseId"
+	WarehouseIdIsNotValid                    = "warehouse.id.is.not.valid"
+	StockLevelIsNotValid                     = "stock.level.is.not.valid"
+	StockLevelRequestIsNotValid              = "stock.level.request.is.not.valid"
+	StockLevelIsBelowReservations            = "stock.level.is.below.reservations"
+	StockIsInsufficient                      = "stock.is.insufficient"
+	ReservationNotFound                      = "reservation.not.found"
+	PromotionCode                            = "promotionCode"
+	PromotionCodeIsNotValid                  = "promotion.code.is.not.valid"
+	PromotionNameIsNotValid                  = "promotion.name.is.not.valid"
+	PromotionTypeIsNotValid                  = "promotion.type.is.not.valid"
+	PromotionValueIsNotValid                 = "promotion.value.is.not.valid"
+	PromotionCurrencyCodeIsNotValid          = "promotion.currency.code.is.not.valid"
+	PromotionMinBasketAmountIsNotValid       = "promotion.min.basket.amount.is.not.valid"
+	PromotionValidityIsNotValid              = "promotion.validity.is.not.valid"
+	PromotionUsageLimitIsNotValid            = "promotion.usage.limit.is.not.valid"
+	PromotionRequestIsNotValid               = "promotion.request.is.not.valid"
+	PromotionNotFound                        = "promotion.not.found"
+	SamePromotionFoundByCode                 = "same.promotion.found.by.code"
+	PromotionUsageLimitIsReached             = "promotion.usage.limit.is.reached"
+	CouponCodeIsNotValid                     = "coupon.code.is.not.valid"
+	CouponNotFound                           = "coupon.not.found"
+	CouponIsNotActive                        = "coupon.is.not.active"
+	CouponIsNotApplicableToCurrency          = "coupon.is.not.applicable.to.currency"
+	CouponMinimumBasketIsNotReached          = "coupon.minimum.basket.is.not.reached"
+	CouponRequiresCustomer                   = "coupon.requires.customer"
 )
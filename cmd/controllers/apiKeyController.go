@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"strings"
+	"time"
+)
+
+const apiKeyTimeout = 5 * time.Second
+
+type ApiKeyController struct {
+	apiKeyService services.ApiKeyService
+}
+
+func NewApiKeyController(
+	apiKeyService services.ApiKeyService,
+) Controller {
+	return &ApiKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// @Tags ApiKeyController
+// @Description Get Api Keys
+// @Produce json
+// @Success 200 {object} []response.ApiKey
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /admin/api-keys [get]
+// @Security BearerAuth
+func (controller *ApiKeyController) GetApiKeys() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, apiKeyTimeout)
+		defer cancel()
+
+		apiKeys, errorResp := controller.apiKeyService.GetApiKeys(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, apiKeys)
+	}
+}
+
+// @Tags ApiKeyController
+// @Description Create Api Key. The returned key is shown only once.
+// @Produce json
+// @Success 201 {object} response.CreatedApiKey
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /admin/api-keys [post]
+// @Security BearerAuth
+// @Param request body request.CreateApiKeyRequest true "Create Api Key Request"
+func (controller *ApiKeyController) CreateApiKey() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		var createApiKeyRequest *request.CreateApiKeyRequest
+		_ = mapstructure.Decode(getRequestBody(createApiKeyRequest, context), &createApiKeyRequest)
+
+		if createApiKeyRequest == nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.CreateApiKeyRequestIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if len(strings.TrimSpace(createApiKeyRequest.Name)) == 0 {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ApiKeyNameIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if !areValidScopes(createApiKeyRequest.Scopes) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ApiKeyScopesAreNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, apiKeyTimeout)
+		defer cancel()
+
+		apiKey, createErr := controller.apiKeyService.CreateApiKey(ctx, *createApiKeyRequest)
+		if createErr != nil {
+			context.JSON(createErr.StatusCode, createErr)
+			return
+		}
+
+		context.JSON(http.StatusCreated, apiKey)
+	}
+}
+
+// @Tags ApiKeyController
+// @Description Rotate Api Key. The previous key stops working immediately.
+// @Produce json
+// @Success 200 {object} response.CreatedApiKey
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /admin/api-keys/{apiKeyId}/rotate [post]
+// @Security BearerAuth
+// @Param apiKeyId path string true "apiKeyId"
+func (controller *ApiKeyController) RotateApiKey() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		apiKeyId, apiKeyIdErr := getStringParam(context, constants.ApiKeyId)
+		if !helpers.IsValidString(apiKeyId, apiKeyIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ApiKeyIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, apiKeyTimeout)
+		defer cancel()
+
+		apiKey, rotateErr := controller.apiKeyService.RotateApiKey(ctx, apiKeyId)
+		if rotateErr != nil {
+			context.JSON(rotateErr.StatusCode, rotateErr)
+			return
+		}
+
+		context.JSON(http.StatusOK, apiKey)
+	}
+}
+
+// @Tags ApiKeyController
+// @Description Revoke Api Key
+// @Produce json
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /admin/api-keys/{apiKeyId} [delete]
+// @Security BearerAuth
+// @Param apiKeyId path string true "apiKeyId"
+func (controller *ApiKeyController) RevokeApiKey() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		apiKeyId, apiKeyIdErr := getStringParam(context, constants.ApiKeyId)
+		if !helpers.IsValidString(apiKeyId, apiKeyIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ApiKeyIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, apiKeyTimeout)
+		defer cancel()
+
+		revokeErr := controller.apiKeyService.RevokeApiKey(ctx, apiKeyId)
+		if revokeErr != nil {
+			context.JSON(revokeErr.StatusCode, revokeErr)
+			return
+		}
+
+		context.JSON(http.StatusNoContent, "")
+	}
+}
+
+func (controller *ApiKeyController) Register(engine *gin.Engine) {
+	admin := engine.Group("/admin/api-keys", middlewares.RequireAuthentication(), middlewares.RequirePermission(policies.ManageApiKeys))
+	admin.GET("", controller.GetApiKeys())
+	admin.POST("", controller.CreateApiKey())
+	admin.POST("/:apiKeyId/rotate", controller.RotateApiKey())
+	admin.DELETE("/:apiKeyId", controller.RevokeApiKey())
+}
+
+func areValidScopes(scopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !policies.IsValidScope(scope) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"testing"
+)
+
+// newApiKeyAdminEngine authenticates every request as the configured admin api key.
+func newApiKeyAdminEngine() *gin.Engine {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		principal := &models.Principal{Type: models.ApiKeyPrincipal, Subject: "admin", Scopes: []string{policies.ApiKeysScope}}
+		c.Request = c.Request.WithContext(helpers.WithPrincipal(c.Request.Context(), principal))
+	})
+	return engine
+}
+
+func TestCreateApiKey(t *testing.T) {
+	//Given
+	engine := newApiKeyAdminEngine()
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	createdApiKey := response.CreatedApiKey{
+		ApiKey: response.ApiKey{Id: "key-1", Name: "marketplace", Scopes: []string{"orders:read"}},
+		Key:    "soa_secret",
+	}
+	mockApiKeyService.On("CreateApiKey", mock.Anything, mock.Anything).Return(&createdApiKey, nil)
+	controller := NewApiKeyController(mockApiKeyService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"name": "marketplace", "scopes": ["orders:read"]}`
+	req, _ := http.NewRequest("POST", "/admin/api-keys", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	resp := response.CreatedApiKey{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "soa_secret", resp.Key)
+	mockApiKeyService.AssertCalled(t, "CreateApiKey", mock.Anything, request.CreateApiKeyRequest{
+		Name:   "marketplace",
+		Scopes: []string{"orders:read"},
+	})
+}
+
+func TestCreateApiKey_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{"missing name", `{"scopes": ["orders:read"]}`, constants.ApiKeyNameIsNotValid},
+		{"missing scopes", `{"name": "marketplace"}`, constants.ApiKeyScopesAreNotValid},
+		{"unknown scope", `{"name": "marketplace", "scopes": ["orders:delete"]}`, constants.ApiKeyScopesAreNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := newApiKeyAdminEngine()
+			mockApiKeyService := &mocks.MockApiKeyService{}
+			controller := NewApiKeyController(mockApiKeyService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/admin/api-keys", bytes.NewBuffer([]byte(test.body)))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockApiKeyService.AssertNumberOfCalls(t, "CreateApiKey", 0)
+		})
+	}
+}
+
+func TestCreateApiKey_WhenCallerIsAnonymous_ReturnsUnauthorized(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	controller := NewApiKeyController(mockApiKeyService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"name": "marketplace", "scopes": ["orders:read"]}`
+	req, _ := http.NewRequest("POST", "/admin/api-keys", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, mockApiKeyService.Calls)
+}
+
+func TestRotateApiKey(t *testing.T) {
+	//Given
+	engine := newApiKeyAdminEngine()
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	rotatedApiKey := response.CreatedApiKey{ApiKey: response.ApiKey{Id: "key-1"}, Key: "soa_new"}
+	mockApiKeyService.On("RotateApiKey", mock.Anything, "key-1").Return(&rotatedApiKey, nil)
+	controller := NewApiKeyController(mockApiKeyService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("POST", "/admin/api-keys/key-1/rotate", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockApiKeyService.AssertNumberOfCalls(t, "RotateApiKey", 1)
+}
+
+func TestRevokeApiKey_WhenServiceReturnsError_ReturnsError(t *testing.T) {
+	//Given
+	engine := newApiKeyAdminEngine()
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.ApiKeyNotFound).
+		Build()
+	mockApiKeyService.On("RevokeApiKey", mock.Anything, "key-1").Return(&serviceErr)
+	controller := NewApiKeyController(mockApiKeyService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("DELETE", "/admin/api-keys/key-1", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, serviceErr, errResponse)
+}
@@ -2,8 +2,16 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"github.com/gin-gonic/gin"
 	"io/ioutil"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Controller interface {
@@ -15,6 +23,12 @@ func getStringParam(context *gin.Context, paramName string) (string, error) {
 	return valueParam, nil
 }
 
+// requestContext derives a context from the incoming request so that a client
+// disconnect or the route timeout cancels the work done on behalf of it.
+func requestContext(ginContext *gin.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ginContext.Request.Context(), timeout)
+}
+
 func getRequestBody(request interface{}, context *gin.Context) interface{} {
 	if context.Request.Body == nil {
 		return nil
@@ -35,3 +49,47 @@ func getRequestBody(request interface{}, context *gin.Context) interface{} {
 	context.Request.Body = ioutil.NopCloser(bytes.NewBuffer(byteBody))
 	return request
 }
+
+// parseOrderFilter reads the order filters shared by the list and export endpoints
+// from the query string.
+func parseOrderFilter(context *gin.Context) (request.OrderFilter, *response.ErrorResponse) {
+	statusIds, ok := parseStatusIds(context.Query("status"))
+	if !ok {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.StatusIsNotValid).
+			Build()
+		return request.OrderFilter{}, &errorResponse
+	}
+
+	minTotalAmount, minOk := parseAmount(context.Query("minTotalAmount"))
+	maxTotalAmount, maxOk := parseAmount(context.Query("maxTotalAmount"))
+	if !minOk || !maxOk {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.OrderFilterIsNotValid).
+			Build()
+		return request.OrderFilter{}, &errorResponse
+	}
+
+	return request.OrderFilter{
+		StatusIds:      statusIds,
+		CustomerId:     strings.TrimSpace(context.Query("customerId")),
+		City:           strings.TrimSpace(context.Query("city")),
+		District:       strings.TrimSpace(context.Query("district")),
+		CurrencyCode:   strings.TrimSpace(context.Query("currencyCode")),
+		MinTotalAmount: minTotalAmount,
+		MaxTotalAmount: maxTotalAmount,
+	}, nil
+}
+
+func parseAmount(value string) (*float32, bool) {
+	if value = strings.TrimSpace(value); len(value) == 0 {
+		return nil, true
+	}
+
+	amount, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return nil, false
+	}
+	totalAmount := float32(amount)
+	return &totalAmount, true
+}
@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const customerTimeout = 5 * time.Second
+
+type CustomerController struct {
+	customerService services.CustomerService
+}
+
+func NewCustomerController(
+	customerService services.CustomerService,
+) Controller {
+	return &CustomerController{
+		customerService: customerService,
+	}
+}
+
+// @Tags CustomerController
+// @Description Get Customers. Customers see only their own record.
+// @Produce json
+// @Success 200 {object} []response.Customer
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (controller *CustomerController) GetCustomers() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		customers, errorResp := controller.customerService.GetCustomers(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, customers)
+	}
+}
+
+// @Tags CustomerController
+// @Description Get Customer By Id
+// @Produce json
+// @Success 200 {object} response.Customer
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers/{customerId} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param customerId path string true "customerId"
+func (controller *CustomerController) GetCustomer() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		customerId, ok := getCustomerId(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		customer, errorResp := controller.customerService.GetCustomer(ctx, customerId)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, customer)
+	}
+}
+
+// @Tags CustomerController
+// @Description Create Customer. The record of a customer principal gets the subject of its token as id, others get a generated one.
+// @Accept json
+// @Produce json
+// @Success 201 {object} response.Customer
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param request body request.CustomerRequest true "Customer Request"
+func (controller *CustomerController) CreateCustomer() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		customerRequest, ok := getCustomerRequest(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		customer, createErr := controller.customerService.CreateCustomer(ctx, *customerRequest)
+		if createErr != nil {
+			context.JSON(createErr.StatusCode, createErr)
+			return
+		}
+
+		context.JSON(http.StatusCreated, customer)
+	}
+}
+
+// @Tags CustomerController
+// @Description Update Customer. The saved addresses are replaced by those of the request.
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Customer
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers/{customerId} [put]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param customerId path string true "customerId"
+// @Param request body request.CustomerRequest true "Customer Request"
+func (controller *CustomerController) UpdateCustomer() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		customerId, ok := getCustomerId(context)
+		if !ok {
+			return
+		}
+
+		customerRequest, ok := getCustomerRequest(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		customer, updateErr := controller.customerService.UpdateCustomer(ctx, customerId, *customerRequest)
+		if updateErr != nil {
+			context.JSON(updateErr.StatusCode, updateErr)
+			return
+		}
+
+		context.JSON(http.StatusOK, customer)
+	}
+}
+
+// @Tags CustomerController
+// @Description Delete Customer. Customers with orders can not be deleted.
+// @Produce json
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers/{customerId} [delete]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param customerId path string true "customerId"
+func (controller *CustomerController) DeleteCustomer() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		customerId, ok := getCustomerId(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		deleteErr := controller.customerService.DeleteCustomer(ctx, customerId)
+		if deleteErr != nil {
+			context.JSON(deleteErr.StatusCode, deleteErr)
+			return
+		}
+
+		context.JSON(http.StatusNoContent, "")
+	}
+}
+
+// @Tags CustomerController
+// @Description Get Orders Of Customer, filtered like Get Orders
+// @Produce json
+// @Success 200 {object} []response.Order
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /customers/{customerId}/orders [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param customerId path string true "customerId"
+// @Param status query string false "comma separated status ids"
+// @Param city query string false "city"
+// @Param district query string false "district"
+// @Param currencyCode query string false "currencyCode"
+// @Param minTotalAmount query number false "minimum total amount"
+// @Param maxTotalAmount query number false "maximum total amount"
+func (controller *CustomerController) GetCustomerOrders() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		customerId, ok := getCustomerId(context)
+		if !ok {
+			return
+		}
+
+		filter, errorResponse := parseOrderFilter(context)
+		if errorResponse != nil {
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, customerTimeout)
+		defer cancel()
+
+		orders, errorResp := controller.customerService.GetCustomerOrders(ctx, customerId, filter)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, orders)
+	}
+}
+
+func (controller *CustomerController) Register(engine *gin.Engine) {
+	customers := engine.Group("/customers")
+	customers.GET("", middlewares.RequirePermission(policies.ReadCustomers), controller.GetCustomers())
+	customers.GET("/:customerId", middlewares.RequirePermission(policies.ReadCustomers), controller.GetCustomer())
+	customers.POST("", middlewares.RequirePermission(policies.WriteCustomers), controller.CreateCustomer())
+	customers.PUT("/:customerId", middlewares.RequirePermission(policies.WriteCustomers), controller.UpdateCustomer())
+	customers.DELETE("/:customerId", middlewares.RequirePermission(policies.DeleteCustomers), controller.DeleteCustomer())
+	customers.GET("/:customerId/orders", middlewares.RequirePermission(policies.ReadOrders), controller.GetCustomerOrders())
+}
+
+func getCustomerId(context *gin.Context) (string, bool) {
+	customerId, customerIdErr := getStringParam(context, constants.CustomerId)
+	if !helpers.IsValidString(customerId, customerIdErr) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.CustomerIdIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return "", false
+	}
+
+	return customerId, true
+}
+
+func getCustomerRequest(context *gin.Context) (*request.CustomerRequest, bool) {
+	customerRequest := &request.CustomerRequest{}
+	if !bindRequestBody(context, customerRequest) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.CustomerRequestIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return nil, false
+	}
+
+	if errorResponse := customerRequest.Validate(); errorResponse != nil {
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return nil, false
+	}
+
+	return customerRequest, true
+}
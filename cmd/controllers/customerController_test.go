@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestCreateCustomer(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockCustomerService := &mocks.MockCustomerService{}
+	customer := response.Customer{Id: "customer-9", FirstName: "Ayşe", LastName: "Yılmaz", Email: "ayse@example.com"}
+	mockCustomerService.On("CreateCustomer", mock.Anything, mock.Anything).Return(&customer, nil)
+	controller := NewCustomerController(mockCustomerService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"firstName": "Ayşe", "lastName": "Yılmaz", "email": "ayse@example.com",
+		"addresses": [{"label": "home", "address": "Lorem ipsum", "city": "Ankara", "district": "Çankaya"}]}`
+	req, _ := http.NewRequest("POST", "/customers", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	resp := response.Customer{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, customer, resp)
+	mockCustomerService.AssertCalled(t, "CreateCustomer", mock.Anything, request.CustomerRequest{
+		FirstName: "Ayşe",
+		LastName:  "Yılmaz",
+		Email:     "ayse@example.com",
+		Addresses: []request.CustomerAddress{{Label: "home", Address: "Lorem ipsum", City: "Ankara", District: "Çankaya"}},
+	})
+}
+
+func TestCreateCustomer_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{"malformed body", `{"firstName": `, constants.CustomerRequestIsNotValid},
+		{"missing first name", `{"lastName": "Yılmaz", "email": "ayse@example.com"}`, constants.FirstNameIsNotValid},
+		{"missing email", `{"firstName": "Ayşe", "lastName": "Yılmaz"}`, constants.EmailIsNotValid},
+		{"email with display name", `{"firstName": "Ayşe", "lastName": "Yılmaz", "email": "Ayşe <ayse@example.com>"}`, constants.EmailIsNotValid},
+		{"phone with letters", `{"firstName": "Ayşe", "lastName": "Yılmaz", "email": "ayse@example.com", "phone": "call me"}`, constants.PhoneIsNotValid},
+		{"address without city", `{"firstName": "Ayşe", "lastName": "Yılmaz", "email": "ayse@example.com",
+			"addresses": [{"address": "Lorem ipsum", "district": "Çankaya"}]}`, constants.CityIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockCustomerService := &mocks.MockCustomerService{}
+			controller := NewCustomerController(mockCustomerService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/customers", bytes.NewBuffer([]byte(test.body)))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockCustomerService.AssertNumberOfCalls(t, "CreateCustomer", 0)
+		})
+	}
+}
+
+func TestGetCustomer_WhenServiceDeniesAccess_ReturnsForbidden(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockCustomerService := &mocks.MockCustomerService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusForbidden, constants.CustomerAccessDenied).
+		Build()
+	mockCustomerService.On("GetCustomer", mock.Anything, "customer-2").Return(nil, &serviceErr)
+	controller := NewCustomerController(mockCustomerService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/customers/customer-2", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.CustomerAccessDenied, errResponse.Message)
+}
+
+func TestUpdateCustomer(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockCustomerService := &mocks.MockCustomerService{}
+	customer := response.Customer{Id: "customer-1", FirstName: "Ahmet", LastName: "Ata", Email: "ahmet@example.com"}
+	mockCustomerService.On("UpdateCustomer", mock.Anything, "customer-1", mock.Anything).Return(&customer, nil)
+	controller := NewCustomerController(mockCustomerService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"firstName": "Ahmet", "lastName": "Ata", "email": "ahmet@example.com"}`
+	req, _ := http.NewRequest("PUT", "/customers/customer-1", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockCustomerService.AssertCalled(t, "UpdateCustomer", mock.Anything, "customer-1", request.CustomerRequest{
+		FirstName: "Ahmet",
+		LastName:  "Ata",
+		Email:     "ahmet@example.com",
+	})
+}
+
+func TestDeleteCustomer_WhenCustomerHasOrders_ReturnsConflict(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockCustomerService := &mocks.MockCustomerService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusConflict, constants.CustomerWithOrdersCanNotBeDeleted).
+		Build()
+	mockCustomerService.On("DeleteCustomer", mock.Anything, "customer-1").Return(&serviceErr)
+	controller := NewCustomerController(mockCustomerService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("DELETE", "/customers/customer-1", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetCustomerOrders_PassesQueryFiltersToService(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockCustomerService := &mocks.MockCustomerService{}
+	orders := []response.Order{{OrderNumber: "1", CustomerId: "customer-1", City: "Ankara"}}
+	mockCustomerService.On("GetCustomerOrders", mock.Anything, "customer-1", mock.MatchedBy(func(filter request.OrderFilter) bool {
+		return filter.City == "Ankara"
+	})).Return(orders, nil)
+	controller := NewCustomerController(mockCustomerService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/customers/customer-1/orders?city=Ankara", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := make([]response.Order, 0)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, orders, resp)
+}
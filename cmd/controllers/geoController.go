@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/services"
+	"strings"
+	"time"
+)
+
+const (
+	geoTimeout = 5 * time.Second
+	// geoCacheControl lets browsers keep the catalog, which only changes with a release.
+	geoCacheControl = "public, max-age=86400"
+)
+
+type GeoController struct {
+	geoService services.GeoService
+}
+
+func NewGeoController(
+	geoService services.GeoService,
+) Controller {
+	return &GeoController{
+		geoService: geoService,
+	}
+}
+
+// @Tags GeoController
+// @Description Get Countries Of The Reference Catalog
+// @Produce json
+// @Success 200 {object} []response.Country
+// @Failure 500 {object} response.ErrorResponse
+// @Router /geo/countries [get]
+func (controller *GeoController) GetCountries() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, geoTimeout)
+		defer cancel()
+
+		countries, errorResp := controller.geoService.GetCountries(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.Header("Cache-Control", geoCacheControl)
+		context.JSON(http.StatusOK, countries)
+	}
+}
+
+// @Tags GeoController
+// @Description Get Cities Of The Reference Catalog. Names are matched regardless of case and diacritics.
+// @Produce json
+// @Success 200 {object} []response.City
+// @Failure 500 {object} response.ErrorResponse
+// @Router /geo/cities [get]
+// @Param countryCode query string false "ISO 3166-1 alpha-2 country code"
+// @Param q query string false "prefix of the city name"
+func (controller *GeoController) GetCities() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, geoTimeout)
+		defer cancel()
+
+		countryCode := strings.TrimSpace(context.Query("countryCode"))
+		cities, errorResp := controller.geoService.GetCities(ctx, countryCode, context.Query("q"))
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.Header("Cache-Control", geoCacheControl)
+		context.JSON(http.StatusOK, cities)
+	}
+}
+
+// @Tags GeoController
+// @Description Get Districts Of A City Of The Reference Catalog
+// @Produce json
+// @Success 200 {object} []response.District
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /geo/cities/{cityId}/districts [get]
+// @Param cityId path string true "cityId"
+func (controller *GeoController) GetDistricts() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		cityId, cityIdErr := getStringParam(context, constants.CityId)
+		if !helpers.IsValidString(cityId, cityIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.CityIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, geoTimeout)
+		defer cancel()
+
+		districts, errorResp := controller.geoService.GetDistricts(ctx, cityId)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.Header("Cache-Control", geoCacheControl)
+		context.JSON(http.StatusOK, districts)
+	}
+}
+
+// Register leaves the catalog without permissions; it holds no customer data and is
+// listed in the public paths by default.
+func (controller *GeoController) Register(engine *gin.Engine) {
+	geo := engine.Group("/geo")
+	geo.GET("/countries", controller.GetCountries())
+	geo.GET("/cities", controller.GetCities())
+	geo.GET("/cities/:cityId/districts", controller.GetDistricts())
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestGetCities_PassesCountryCodeAndQueryToService(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockGeoService := &mocks.MockGeoService{}
+	cities := []response.City{{Id: "tr-istanbul", Name: "İstanbul", CountryCode: "TR"}}
+	mockGeoService.On("GetCities", mock.Anything, "TR", "ist").Return(cities, nil)
+	controller := NewGeoController(mockGeoService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/geo/cities?countryCode=TR&q=ist", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, geoCacheControl, w.Header().Get("Cache-Control"))
+	resp := make([]response.City, 0)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, cities, resp)
+}
+
+func TestGetDistricts(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockGeoService := &mocks.MockGeoService{}
+	districts := []response.District{{Id: "gb-london-westminster", Name: "Westminster", CityId: "gb-london"}}
+	mockGeoService.On("GetDistricts", mock.Anything, "gb-london").Return(districts, nil)
+	controller := NewGeoController(mockGeoService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/geo/cities/gb-london/districts", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := make([]response.District, 0)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, districts, resp)
+}
+
+func TestGetDistricts_WhenCityDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockGeoService := &mocks.MockGeoService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.CityNotFound).
+		Build()
+	mockGeoService.On("GetDistricts", mock.Anything, "xx-nowhere").Return(nil, &serviceErr)
+	controller := NewGeoController(mockGeoService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/geo/cities/xx-nowhere/districts", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.CityNotFound, errResponse.Message)
+}
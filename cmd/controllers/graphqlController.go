@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/mitchellh/mapstructure"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"time"
+)
+
+const graphqlTimeout = 10 * time.Second
+
+type GraphqlController struct {
+	schema     graphql.Schema
+	playground bool
+}
+
+// NewGraphqlController serves the order schema at /graphql and, when playground is
+// set, the GraphiQL page on GET requests.
+func NewGraphqlController(schema graphql.Schema, playground bool) Controller {
+	return &GraphqlController{
+		schema:     schema,
+		playground: playground,
+	}
+}
+
+// @Tags GraphqlController
+// @Description Execute a GraphQL query or mutation. Errors are returned in the "errors" list with the REST error code and status code as extensions.
+// @Accept json
+// @Produce json
+// @Success 200 {object} object
+// @Failure 400 {object} response.ErrorResponse
+// @Router /graphql [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param request body request.GraphqlRequest true "GraphQL Request"
+func (controller *GraphqlController) Execute() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		var graphqlRequest *request.GraphqlRequest
+		_ = mapstructure.Decode(getRequestBody(graphqlRequest, context), &graphqlRequest)
+
+		if graphqlRequest == nil || len(strings.TrimSpace(graphqlRequest.Query)) == 0 {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.GraphqlRequestIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, graphqlTimeout)
+		defer cancel()
+
+		result := graphql.Do(graphql.Params{
+			Schema:         controller.schema,
+			RequestString:  graphqlRequest.Query,
+			OperationName:  graphqlRequest.OperationName,
+			VariableValues: graphqlRequest.Variables,
+			Context:        ctx,
+		})
+		context.JSON(http.StatusOK, result)
+	}
+}
+
+func (controller *GraphqlController) Playground() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		context.Data(http.StatusOK, "text/html; charset=utf-8", []byte(graphiqlPage))
+	}
+}
+
+func (controller *GraphqlController) Register(engine *gin.Engine) {
+	engine.POST("/graphql", controller.Execute())
+	if controller.playground {
+		engine.GET("/graphql", controller.Playground())
+	}
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Simple Order Api GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@2.4.0/graphiql.min.css" />
+</head>
+<body style="margin: 0;">
+  <div id="graphiql" style="height: 100vh;"></div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@2.4.0/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: window.location.pathname });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(
+      React.createElement(GraphiQL, { fetcher: fetcher, headerEditorEnabled: true })
+    );
+  </script>
+</body>
+</html>`
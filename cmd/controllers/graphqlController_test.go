@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/graphqlserver"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+func newGraphqlEngine(t *testing.T, orderService *mocks.MockOrderService, playground bool) *gin.Engine {
+	schema, err := graphqlserver.NewSchema(orderService)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	NewGraphqlController(schema, playground).Register(engine)
+	return engine
+}
+
+func TestExecuteGraphql(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{OrderNumber: "1", FirstName: "Ahmet"}, nil)
+	engine := newGraphqlEngine(t, mockOrderService, false)
+	w := httptest.NewRecorder()
+	body := `{"query": "query($orderNumber: String!) { order(orderNumber: $orderNumber) { firstName } }", "variables": {"orderNumber": "1"}}`
+
+	//When
+	req, _ := http.NewRequest("POST", "/graphql", strings.NewReader(body))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data": {"order": {"firstName": "Ahmet"}}}`, w.Body.String())
+}
+
+func TestExecuteGraphql_WhenQueryIsMissing_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := newGraphqlEngine(t, &mocks.MockOrderService{}, false)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("POST", "/graphql", strings.NewReader(`{"variables": {}}`))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errorResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.Equal(t, constants.GraphqlRequestIsNotValid, errorResponse.Message)
+}
+
+func TestGraphqlPlayground_IsServedOnlyWhenEnabled(t *testing.T) {
+	//Given
+	enabledEngine := newGraphqlEngine(t, &mocks.MockOrderService{}, true)
+	disabledEngine := newGraphqlEngine(t, &mocks.MockOrderService{}, false)
+	enabledResp := httptest.NewRecorder()
+	disabledResp := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/graphql", nil)
+	enabledEngine.ServeHTTP(enabledResp, req)
+	disabledEngine.ServeHTTP(disabledResp, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, enabledResp.Code)
+	assert.Contains(t, enabledResp.Body.String(), "GraphiQL")
+	assert.Equal(t, http.StatusNotFound, disabledResp.Code)
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type HealthController struct {
+}
+
+func NewHealthController() Controller {
+	return &HealthController{}
+}
+
+// @Tags HealthController
+// @Description Report that the server is up, for load balancers and orchestrators
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health [get]
+func (controller *HealthController) GetHealth() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		context.JSON(http.StatusOK, gin.H{"status": "up"})
+	}
+}
+
+// Register leaves the health check without permissions; it is listed in the public paths
+// by default.
+func (controller *HealthController) Register(engine *gin.Engine) {
+	engine.GET("/health", controller.GetHealth())
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const inventoryTimeout = 5 * time.Second
+
+type InventoryController struct {
+	inventoryService services.InventoryService
+}
+
+func NewInventoryController(
+	inventoryService services.InventoryService,
+) Controller {
+	return &InventoryController{
+		inventoryService: inventoryService,
+	}
+}
+
+// @Tags InventoryController
+// @Description Get Stock Levels per product and warehouse
+// @Produce json
+// @Success 200 {object} []response.StockLevel
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /inventory [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param sku query string false "sku"
+func (controller *InventoryController) GetStockLevels() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, inventoryTimeout)
+		defer cancel()
+
+		stockLevels, errorResp := controller.inventoryService.GetStockLevels(ctx, context.Query(constants.Sku))
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, stockLevels)
+	}
+}
+
+// @Tags InventoryController
+// @Description Set Stock Level of a product in a warehouse. It can not fall below the reserved units.
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.StockLevel
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /inventory/{sku}/warehouses/{warehouseId} [put]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param sku path string true "sku"
+// @Param warehouseId path string true "warehouseId"
+// @Param request body request.StockLevelRequest true "Stock Level Request"
+func (controller *InventoryController) SetStockLevel() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		sku, ok := getSku(context)
+		if !ok {
+			return
+		}
+
+		warehouseId, warehouseIdErr := getStringParam(context, constants.WarehouseId)
+		if !helpers.IsValidString(warehouseId, warehouseIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WarehouseIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		stockLevelRequest := &request.StockLevelRequest{}
+		if !bindRequestBody(context, stockLevelRequest) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.StockLevelRequestIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if errorResponse := stockLevelRequest.Validate(); errorResponse != nil {
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, inventoryTimeout)
+		defer cancel()
+
+		stockLevel, errorResp := controller.inventoryService.SetStockLevel(ctx, sku, warehouseId, *stockLevelRequest)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, stockLevel)
+	}
+}
+
+// @Tags InventoryController
+// @Description Get Stock Reservation Of Order, kept until the order is transferred or deleted
+// @Produce json
+// @Success 200 {object} []response.ReservationLine
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /inventory/reservations/{orderNumber} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param orderNumber path string true "orderNumber"
+func (controller *InventoryController) GetReservation() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		orderNumber, orderNumberErr := getStringParam(context, constants.OrderNumber)
+		if !helpers.IsValidString(orderNumber, orderNumberErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, inventoryTimeout)
+		defer cancel()
+
+		lines, errorResp := controller.inventoryService.GetReservation(ctx, orderNumber)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, lines)
+	}
+}
+
+func (controller *InventoryController) Register(engine *gin.Engine) {
+	inventory := engine.Group("/inventory")
+	inventory.GET("", middlewares.RequirePermission(policies.ReadInventory), controller.GetStockLevels())
+	inventory.PUT("/:sku/warehouses/:warehouseId", middlewares.RequirePermission(policies.WriteInventory), controller.SetStockLevel())
+	inventory.GET("/reservations/:orderNumber", middlewares.RequirePermission(policies.ReadInventory), controller.GetReservation())
+}
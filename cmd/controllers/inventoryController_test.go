@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestSetStockLevel_PassesSkuWarehouseAndRequestToService(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockInventoryService := &mocks.MockInventoryService{}
+	stockLevel := &response.StockLevel{Sku: "MUG-WHT", WarehouseId: "BER-1", OnHand: 12, Available: 12}
+	mockInventoryService.On("SetStockLevel", mock.Anything, "MUG-WHT", "BER-1", request.StockLevelRequest{OnHand: 12}).Return(stockLevel, nil)
+	controller := NewInventoryController(mockInventoryService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("PUT", "/inventory/MUG-WHT/warehouses/BER-1", bytes.NewBufferString(`{"onHand":12}`))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := response.StockLevel{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, *stockLevel, resp)
+}
+
+func TestSetStockLevel_WhenOnHandIsNegative_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockInventoryService := &mocks.MockInventoryService{}
+	controller := NewInventoryController(mockInventoryService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("PUT", "/inventory/MUG-WHT/warehouses/BER-1", bytes.NewBufferString(`{"onHand":-1}`))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.StockLevelIsNotValid, errResponse.Message)
+	mockInventoryService.AssertNotCalled(t, "SetStockLevel", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetReservation_WhenOrderHasNone_ReturnsNotFound(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockInventoryService := &mocks.MockInventoryService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.ReservationNotFound).
+		Build()
+	mockInventoryService.On("GetReservation", mock.Anything, "100").Return(nil, &serviceErr)
+	controller := NewInventoryController(mockInventoryService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/inventory/reservations/100", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.ReservationNotFound, errResponse.Message)
+}
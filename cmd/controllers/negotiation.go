@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"simple-order-api/cmd/models/response"
+)
+
+// mimeYaml is the registered yaml media type; gin itself knows only application/x-yaml.
+const mimeYaml = "application/yaml"
+
+// mimeNdjson is the media type of order listings streamed with one json order per line.
+const mimeNdjson = "application/x-ndjson"
+
+// offeredFormats are the media types the order endpoints write. JSON comes first so that
+// it is chosen for clients accepting anything.
+var offeredFormats = []string{
+	binding.MIMEJSON,
+	binding.MIMEXML,
+	binding.MIMEXML2,
+	binding.MIMEYAML,
+	mimeYaml,
+	binding.MIMEMSGPACK,
+	binding.MIMEMSGPACK2,
+}
+
+// negotiate writes the data in the format named by the Accept header, falling back to
+// JSON when the client accepts none of the offered formats.
+func negotiate(context *gin.Context, statusCode int, data interface{}) {
+	switch context.NegotiateFormat(offeredFormats...) {
+	case binding.MIMEXML, binding.MIMEXML2:
+		context.XML(statusCode, xmlDocument(data))
+	case binding.MIMEYAML, mimeYaml:
+		context.YAML(statusCode, data)
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		context.Render(statusCode, render.MsgPack{Data: data})
+	default:
+		context.JSON(statusCode, data)
+	}
+}
+
+// acceptsNdjson tells whether the client prefers a streamed listing to the offered
+// formats; clients accepting anything still get a JSON array.
+func acceptsNdjson(context *gin.Context) bool {
+	return context.NegotiateFormat(append(offeredFormats, mimeNdjson)...) == mimeNdjson
+}
+
+// bindRequestBody decodes the body in the format named by the Content-Type header into
+// the request, reading JSON when the header is missing.
+func bindRequestBody(context *gin.Context, request interface{}) bool {
+	if context.Request.Body == nil {
+		return false
+	}
+
+	var bodyBinding binding.Binding
+	switch context.ContentType() {
+	case binding.MIMEXML, binding.MIMEXML2:
+		bodyBinding = binding.XML
+	case binding.MIMEYAML, mimeYaml:
+		bodyBinding = binding.YAML
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		bodyBinding = binding.MsgPack
+	default:
+		bodyBinding = binding.JSON
+	}
+	return context.ShouldBindWith(request, bodyBinding) == nil
+}
+
+// xmlDocument wraps lists in a root element since an xml document can not have several.
+func xmlDocument(data interface{}) interface{} {
+	switch list := data.(type) {
+	case []response.Order:
+		return response.OrderList{Orders: list}
+	case []response.AuditEntry:
+		return response.AuditLog{Entries: list}
+	default:
+		return data
+	}
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+func newNegotiationEngine(orderService *mocks.MockOrderService) *gin.Engine {
+	engine := gin.New()
+	NewOrderController(orderService).Register(engine)
+	return engine
+}
+
+func getNegotiatedOrder() response.Order {
+	return response.Order{OrderNumber: "1", FirstName: "Ahmet", TotalAmount: 10.5, Address: "Atatürk Cd. No: 5", City: "İstanbul", StatusId: 2}
+}
+
+func TestGetOrders_WhenXmlIsAccepted_WritesOrdersDocument(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return([]response.Order{getNegotiatedOrder()}, nil)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Accept", "application/xml")
+	newNegotiationEngine(mockOrderService).ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<orders><order><orderNumber>1</orderNumber>")
+	assert.Contains(t, w.Body.String(), "<address>Atatürk Cd. No: 5</address>")
+	orderList := response.OrderList{}
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &orderList))
+	orderList.Orders[0].XMLName = xml.Name{}
+	assert.Equal(t, []response.Order{getNegotiatedOrder()}, orderList.Orders)
+}
+
+func TestGetOrderByOrderNumber_WhenYamlIsAccepted_WritesSameFieldNames(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	order := getNegotiatedOrder()
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&order, nil)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/1", nil)
+	req.Header.Set("Accept", "application/yaml")
+	newNegotiationEngine(mockOrderService).ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	fields := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &fields))
+	jsonFields := map[string]interface{}{}
+	jsonBody, _ := json.Marshal(order)
+	_ = json.Unmarshal(jsonBody, &jsonFields)
+	for field := range jsonFields {
+		assert.Contains(t, fields, field)
+	}
+	assert.Equal(t, "Atatürk Cd. No: 5", fields["address"])
+}
+
+func TestCreateOrder_WhenBodyIsXml_BindsRequest(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	w := httptest.NewRecorder()
+	body := `<order><orderNumber>1</orderNumber><firstName>Test</firstName><lastName>Sample</lastName><totalAmount>10.2</totalAmount>` +
+		`<address>Kızılay, No: 1</address><city>Ankara</city><district>Çankaya</district><currencyCode>TRY</currencyCode></order>`
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	newNegotiationEngine(mockOrderService).ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, request.CreateOrderRequest{
+		OrderNumber:  "1",
+		FirstName:    "Test",
+		LastName:     "Sample",
+		TotalAmount:  10.2,
+		Address:      "Kızılay, No: 1",
+		City:         "Ankara",
+		District:     "Çankaya",
+		CurrencyCode: "TRY",
+	})
+}
+
+func TestTransitionOrder_WhenBodyIsMessagePack_WritesMessagePackError(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	w := httptest.NewRecorder()
+	body := &bytes.Buffer{}
+	require.NoError(t, codec.NewEncoder(body, &codec.MsgpackHandle{}).Encode(request.TransitionOrderRequest{StatusId: 9}))
+
+	//When
+	req, _ := http.NewRequest("PATCH", "/orders/1/status", body)
+	req.Header.Set("Content-Type", "application/x-msgpack")
+	req.Header.Set("Accept", "application/x-msgpack")
+	newNegotiationEngine(mockOrderService).ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/msgpack; charset=utf-8", w.Header().Get("Content-Type"))
+	errResponse := response.ErrorResponse{}
+	require.NoError(t, codec.NewDecoder(w.Body, &codec.MsgpackHandle{}).Decode(&errResponse))
+	assert.Equal(t, constants.StatusIsNotValid, errResponse.Message)
+}
+
+func TestGetOrders_WhenAcceptedFormatIsNotOffered_WritesJson(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return([]response.Order{getNegotiatedOrder()}, nil)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Accept", "text/html")
+	newNegotiationEngine(mockOrderService).ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"address":"Atatürk Cd. No: 5"`)
+}
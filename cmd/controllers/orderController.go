@@ -1,17 +1,35 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
-	"github.com/mitchellh/mapstructure"
 	"net/http"
 	"simple-order-api/cmd/constants"
 	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
 	"simple-order-api/cmd/services"
-	"strings"
+	"time"
 )
 
+const (
+	getOrdersTimeout    = 10 * time.Second
+	streamOrdersTimeout = 5 * time.Minute
+	getOrderTimeout     = 5 * time.Second
+	createOrderTimeout  = 5 * time.Second
+	updateOrderTimeout  = 5 * time.Second
+	deleteOrderTimeout  = 5 * time.Second
+	transitionTimeout   = 5 * time.Second
+	orderAuditTimeout   = 5 * time.Second
+)
+
+// streamFlushInterval is the number of streamed orders after which they are flushed to
+// the client.
+const streamFlushInterval = 100
+
 type OrderController struct {
 	orderService services.OrderService
 }
@@ -25,31 +43,103 @@ func NewOrderController(
 }
 
 // @Tags OrderController
-// @Description Get Orders
-// @Produce json
+// @Description Get Orders. With Accept application/x-ndjson the orders are streamed one per line as they are read.
+// @Produce json,xml,application/x-yaml,application/x-msgpack,application/x-ndjson
 // @Success 200 {object} []response.Order
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /orders [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param status query string false "comma separated status ids"
+// @Param customerId query string false "customerId"
+// @Param city query string false "city"
+// @Param district query string false "district"
+// @Param currencyCode query string false "currencyCode"
+// @Param minTotalAmount query number false "minimum total amount"
+// @Param maxTotalAmount query number false "maximum total amount"
 func (controller *OrderController) GetOrders() func(context *gin.Context) {
 	return func(context *gin.Context) {
-		orders, errorResp := controller.orderService.GetOrders()
+		filter, errorResponse := parseOrderFilter(context)
+		if errorResponse != nil {
+			negotiate(context, errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if acceptsNdjson(context) {
+			controller.streamOrders(context, filter)
+			return
+		}
+
+		ctx, cancel := requestContext(context, getOrdersTimeout)
+		defer cancel()
+
+		orders, errorResp := controller.orderService.GetOrders(ctx, filter)
 		if errorResp != nil {
-			context.JSON(http.StatusInternalServerError, errorResp)
+			negotiate(context, http.StatusInternalServerError, errorResp)
 			return
 		}
 
-		context.JSON(http.StatusOK, orders)
+		negotiate(context, http.StatusOK, orders)
 	}
 }
 
+// streamOrders writes every order as a json line as soon as it is read, so the listing
+// is never held in memory. It stops once the client disconnects.
+func (controller *OrderController) streamOrders(context *gin.Context, filter request.OrderFilter) {
+	ctx, cancel := requestContext(context, streamOrdersTimeout)
+	defer cancel()
+
+	encoder := json.NewEncoder(context.Writer)
+	streamed := 0
+	errorResp := controller.orderService.StreamOrders(ctx, filter, func(order response.Order) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		if streamed == 0 {
+			context.Header("Content-Type", mimeNdjson)
+			context.Status(http.StatusOK)
+		}
+		if err := encoder.Encode(order); err != nil {
+			return false
+		}
+
+		streamed++
+		if streamed%streamFlushInterval == 0 {
+			context.Writer.Flush()
+		}
+		return true
+	})
+
+	if errorResp != nil && streamed == 0 {
+		context.JSON(errorResp.StatusCode, errorResp)
+		return
+	}
+	if errorResp != nil {
+		// the listing is already being sent; the client sees it end early
+		_ = context.Error(errors.New(errorResp.Message))
+		return
+	}
+
+	if streamed == 0 {
+		context.Header("Content-Type", mimeNdjson)
+		context.Status(http.StatusOK)
+	}
+	context.Writer.Flush()
+}
+
 // @Tags OrderController
 // @Description Get Order By OrderNumber
-// @Produce json
+// @Produce json,xml,application/x-yaml,application/x-msgpack
 // @Success 200 {object} response.Order
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /orders/{orderNumber} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param orderNumber path string true "orderNumber"
 func (controller *OrderController) GetOrderByOrderNumber() func(context *gin.Context) {
 	return func(context *gin.Context) {
@@ -58,13 +148,16 @@ func (controller *OrderController) GetOrderByOrderNumber() func(context *gin.Con
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		order, errorResp := controller.orderService.GetOrder(orderNumber)
+		ctx, cancel := requestContext(context, getOrderTimeout)
+		defer cancel()
+
+		order, errorResp := controller.orderService.GetOrder(ctx, orderNumber)
 		if errorResp != nil {
-			context.JSON(errorResp.StatusCode, errorResp)
+			negotiate(context, errorResp.StatusCode, errorResp)
 			return
 		}
 
@@ -72,118 +165,69 @@ func (controller *OrderController) GetOrderByOrderNumber() func(context *gin.Con
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		context.JSON(http.StatusOK, order)
+		negotiate(context, http.StatusOK, order)
 	}
 }
 
 // @Tags OrderController
 // @Description Create Order
-// @Produce json
+// @Accept json,xml,application/x-yaml,application/x-msgpack
+// @Produce json,xml,application/x-yaml,application/x-msgpack
 // @Success 201
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /orders [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param request body request.CreateOrderRequest true "Create Order Request"
 func (controller *OrderController) CreateOrder() func(context *gin.Context) {
 	return func(context *gin.Context) {
-		var createOrderRequest *request.CreateOrderRequest
-		_ = mapstructure.Decode(getRequestBody(createOrderRequest, context), &createOrderRequest)
-
-		if createOrderRequest == nil {
+		createOrderRequest := &request.CreateOrderRequest{}
+		if !bindRequestBody(context, createOrderRequest) {
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusBadRequest, constants.CreateOrderRequestIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		if len(strings.TrimSpace(createOrderRequest.OrderNumber)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(createOrderRequest.FirstName)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.FirstNameIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+		if errorResponse := createOrderRequest.Validate(); errorResponse != nil {
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(createOrderRequest.LastName)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.LastNameIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
+		ctx, cancel := requestContext(context, createOrderTimeout)
+		defer cancel()
 
-		if createOrderRequest.TotalAmount <= 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.TotalAmountIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		if len(strings.TrimSpace(createOrderRequest.Address)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.AddressIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		if len(strings.TrimSpace(createOrderRequest.City)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.CityIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		if len(strings.TrimSpace(createOrderRequest.District)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.DistrictIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		if len(strings.TrimSpace(createOrderRequest.CurrencyCode)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.CurrencyCodeIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
-			return
-		}
-
-		createErr := controller.orderService.CreateOrder(*createOrderRequest)
+		createErr := controller.orderService.CreateOrder(ctx, *createOrderRequest)
 		if createErr != nil {
-			context.JSON(createErr.StatusCode, createErr)
+			negotiate(context, createErr.StatusCode, createErr)
 			return
 		}
 
-		context.JSON(http.StatusCreated, "")
+		negotiate(context, http.StatusCreated, "")
 	}
 }
 
 // @Tags OrderController
 // @Description Update Order
-// @Produce json
+// @Accept json,xml,application/x-yaml,application/x-msgpack
+// @Produce json,xml,application/x-yaml,application/x-msgpack
 // @Success 204
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /orders/{orderNumber} [put]
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param orderNumber path string true "orderNumber"
 // @Param request body request.UpdateOrderRequest true "Update Order Request"
 func (controller *OrderController) UpdateOrder() func(context *gin.Context) {
@@ -193,121 +237,168 @@ func (controller *OrderController) UpdateOrder() func(context *gin.Context) {
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		var updateOrderRequest *request.UpdateOrderRequest
-		_ = mapstructure.Decode(getRequestBody(updateOrderRequest, context), &updateOrderRequest)
-
-		if updateOrderRequest == nil {
+		updateOrderRequest := &request.UpdateOrderRequest{}
+		if !bindRequestBody(context, updateOrderRequest) {
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusBadRequest, constants.UpdateOrderRequestIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.FirstName)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.FirstNameIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+		if errorResponse := updateOrderRequest.Validate(); errorResponse != nil {
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.LastName)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.LastNameIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+		ctx, cancel := requestContext(context, updateOrderTimeout)
+		defer cancel()
+
+		createErr := controller.orderService.UpdateOrder(ctx, orderNumber, *updateOrderRequest)
+		if createErr != nil {
+			negotiate(context, createErr.StatusCode, createErr)
 			return
 		}
 
-		if updateOrderRequest.TotalAmount <= 0 {
+		negotiate(context, http.StatusNoContent, "")
+	}
+}
+
+// @Tags OrderController
+// @Description Delete Order
+// @Produce json,xml,application/x-yaml,application/x-msgpack
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /orders/{orderNumber} [delete]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param orderNumber path string true "orderNumber"
+func (controller *OrderController) DeleteOrder() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		orderNumber, orderNumberErr := getStringParam(context, constants.OrderNumber)
+		if !helpers.IsValidString(orderNumber, orderNumberErr) {
 			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.TotalAmountIsNotValid).
+				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.Address)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.AddressIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+		ctx, cancel := requestContext(context, deleteOrderTimeout)
+		defer cancel()
+
+		deleteErr := controller.orderService.DeleteOrder(ctx, orderNumber)
+		if deleteErr != nil {
+			negotiate(context, deleteErr.StatusCode, deleteErr)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.City)) == 0 {
+		negotiate(context, http.StatusNoContent, "")
+	}
+}
+
+// @Tags OrderController
+// @Description Transition Order Status
+// @Accept json,xml,application/x-yaml,application/x-msgpack
+// @Produce json,xml,application/x-yaml,application/x-msgpack
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /orders/{orderNumber}/status [patch]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param orderNumber path string true "orderNumber"
+// @Param request body request.TransitionOrderRequest true "Transition Order Request"
+func (controller *OrderController) TransitionOrder() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		orderNumber, orderNumberErr := getStringParam(context, constants.OrderNumber)
+		if !helpers.IsValidString(orderNumber, orderNumberErr) {
 			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.CityIsNotValid).
+				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.District)) == 0 {
+		transitionOrderRequest := &request.TransitionOrderRequest{}
+		if !bindRequestBody(context, transitionOrderRequest) {
 			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.DistrictIsNotValid).
+				SetError(http.StatusBadRequest, constants.TransitionOrderRequestIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		if len(strings.TrimSpace(updateOrderRequest.CurrencyCode)) == 0 {
-			errorResponse := response.NewErrorBuilder().
-				SetError(http.StatusBadRequest, constants.CurrencyCodeIsNotValid).
-				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+		if errorResponse := transitionOrderRequest.Validate(); errorResponse != nil {
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		createErr := controller.orderService.UpdateOrder(orderNumber, *updateOrderRequest)
-		if createErr != nil {
-			context.JSON(createErr.StatusCode, createErr)
+		ctx, cancel := requestContext(context, transitionTimeout)
+		defer cancel()
+
+		transitionErr := controller.orderService.TransitionOrder(ctx, orderNumber, *transitionOrderRequest)
+		if transitionErr != nil {
+			negotiate(context, transitionErr.StatusCode, transitionErr)
 			return
 		}
 
-		context.JSON(http.StatusNoContent, "")
+		negotiate(context, http.StatusNoContent, "")
 	}
 }
 
 // @Tags OrderController
-// @Description Delete Order
-// @Produce json
-// @Success 204
+// @Description Get Audit Log Of Order
+// @Produce json,xml,application/x-yaml,application/x-msgpack
+// @Success 200 {object} []response.AuditEntry
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
-// @Router /orders/{orderNumber} [delete]
+// @Router /orders/{orderNumber}/audit [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Param orderNumber path string true "orderNumber"
-func (controller *OrderController) DeleteOrder() func(context *gin.Context) {
+func (controller *OrderController) GetOrderAudit() func(context *gin.Context) {
 	return func(context *gin.Context) {
 		orderNumber, orderNumberErr := getStringParam(context, constants.OrderNumber)
 		if !helpers.IsValidString(orderNumber, orderNumberErr) {
 			errorResponse := response.NewErrorBuilder().
 				SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
 				Build()
-			context.JSON(errorResponse.StatusCode, errorResponse)
+			negotiate(context, errorResponse.StatusCode, errorResponse)
 			return
 		}
 
-		deleteErr := controller.orderService.DeleteOrder(orderNumber)
-		if deleteErr != nil {
-			context.JSON(deleteErr.StatusCode, deleteErr)
+		ctx, cancel := requestContext(context, orderAuditTimeout)
+		defer cancel()
+
+		auditEntries, errorResp := controller.orderService.GetOrderAudit(ctx, orderNumber)
+		if errorResp != nil {
+			negotiate(context, errorResp.StatusCode, errorResp)
 			return
 		}
 
-		context.JSON(http.StatusNoContent, "")
+		negotiate(context, http.StatusOK, auditEntries)
 	}
 }
 
 func (controller *OrderController) Register(engine *gin.Engine) {
-	engine.GET("/orders", controller.GetOrders())
-	engine.GET("/orders/:orderNumber", controller.GetOrderByOrderNumber())
-	engine.POST("/orders", controller.CreateOrder())
-	engine.PUT("/orders/:orderNumber", controller.UpdateOrder())
-	engine.DELETE("/orders/:orderNumber", controller.DeleteOrder())
+	engine.GET("/orders", middlewares.RequirePermission(policies.ReadOrders), controller.GetOrders())
+	engine.GET("/orders/:orderNumber", middlewares.RequirePermission(policies.ReadOrders), controller.GetOrderByOrderNumber())
+	engine.POST("/orders", middlewares.RequirePermission(policies.CreateOrders), controller.CreateOrder())
+	engine.PUT("/orders/:orderNumber", middlewares.RequirePermission(policies.UpdateOrders), controller.UpdateOrder())
+	engine.PATCH("/orders/:orderNumber/status", middlewares.RequirePermission(policies.TransitionOrders), controller.TransitionOrder())
+	engine.DELETE("/orders/:orderNumber", middlewares.RequirePermission(policies.DeleteOrders), controller.DeleteOrder())
+	engine.GET("/orders/:orderNumber/audit", middlewares.RequirePermission(policies.ReadOrderAudit), controller.GetOrderAudit())
 }
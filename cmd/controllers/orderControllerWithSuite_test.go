@@ -65,7 +65,7 @@ func (o *OrderControllerSuite) TestGetOrdersWithSuite() {
 			CurrencyCode: "TR",
 		},
 	}
-	o.mockOrderService.On("GetOrders").Return(orders, nil)
+	o.mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return(orders, nil)
 
 	//When
 	o.sendRequest("GET", "/orders", nil)
@@ -76,7 +76,7 @@ func (o *OrderControllerSuite) TestGetOrdersWithSuite() {
 	expectedResp := &[]response.Order{}
 	_ = json.Unmarshal(o.recorder.Body.Bytes(), expectedResp)
 	assert.Equal(o.T(), orders, *expectedResp)
-	o.mockOrderService.AssertCalled(o.T(), "GetOrders")
+	o.mockOrderService.AssertCalled(o.T(), "GetOrders", mock.Anything, mock.Anything)
 	o.mockOrderService.AssertNumberOfCalls(o.T(), "GetOrders", 1)
 }
 
@@ -85,7 +85,7 @@ func (o *OrderControllerSuite) TestGetOrdersWithSuite_WhenServiceReturnsError_Re
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	o.mockOrderService.On("GetOrders").Return(nil, &serviceErr)
+	o.mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return(nil, &serviceErr)
 
 	//When
 	o.sendRequest("GET", "/orders", nil)
@@ -109,7 +109,7 @@ func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite() {
 		StatusId:     2,
 		CurrencyCode: "TR",
 	}
-	o.mockOrderService.On("GetOrder", mock.Anything).Return(&order, nil)
+	o.mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(&order, nil)
 
 	//When
 	o.sendRequest("GET", "/orders/123456", nil)
@@ -120,7 +120,7 @@ func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite() {
 	expectedResp := &response.Order{}
 	_ = json.Unmarshal(o.recorder.Body.Bytes(), expectedResp)
 	assert.Equal(o.T(), order, *expectedResp)
-	o.mockOrderService.AssertCalled(o.T(), "GetOrder", "123456")
+	o.mockOrderService.AssertCalled(o.T(), "GetOrder", mock.Anything, "123456")
 	o.mockOrderService.AssertNumberOfCalls(o.T(), "GetOrder", 1)
 }
 
@@ -138,7 +138,7 @@ func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite_WhenOrderNumbe
 
 func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite_WhenOrderNotFound_returnsNotFoundError() {
 	//Given
-	o.mockOrderService.On("GetOrder", mock.Anything).Return(nil, nil)
+	o.mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(nil, nil)
 
 	//When
 	o.sendRequest("GET", "/orders/123456", nil)
@@ -153,7 +153,7 @@ func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite_WhenServiceRet
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	o.mockOrderService.On("GetOrder", mock.Anything).Return(nil, &serviceErr)
+	o.mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(nil, &serviceErr)
 
 	//When
 	o.sendRequest("GET", "/orders/123456", nil)
@@ -165,7 +165,7 @@ func (o *OrderControllerSuite) TestGetOrderByOrderNumberWithSuite_WhenServiceRet
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	reqBodyBytes := new(bytes.Buffer)
@@ -186,7 +186,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite() {
 		District:     "Bakırköy",
 		CurrencyCode: "TRY",
 	}
-	o.mockOrderService.AssertCalled(o.T(), "CreateOrder", createOrderRequest)
+	o.mockOrderService.AssertCalled(o.T(), "CreateOrder", mock.Anything, createOrderRequest)
 	o.mockOrderService.AssertNumberOfCalls(o.T(), "CreateOrder", 1)
 }
 
@@ -203,7 +203,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenRequestIsNotValid_Re
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenOrderNumberIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.OrderNumber = ""
@@ -221,7 +221,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenOrderNumberIsNotVali
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenFirstNameIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.FirstName = ""
@@ -239,7 +239,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenFirstNameIsNotValid_
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenLastNameIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.LastName = ""
@@ -257,7 +257,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenLastNameIsNotValid_R
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenTotalAmountIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.TotalAmount = -12.13
@@ -275,7 +275,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenTotalAmountIsNotVali
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenAddressIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.Address = ""
@@ -293,7 +293,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenAddressIsNotValid_Re
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenCityIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.City = ""
@@ -311,7 +311,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenCityIsNotValid_Retur
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenDistrictIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.District = ""
@@ -329,7 +329,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenDistrictIsNotValid_R
 
 func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenCurrencyCodeIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	serviceReq.CurrencyCode = ""
@@ -350,7 +350,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenOrderServiceReturnsE
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusConflict, "notFound").
 		Build()
-	o.mockOrderService.On("CreateOrder", mock.Anything).Return(&serviceErr)
+	o.mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(&serviceErr)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	reqBodyBytes := new(bytes.Buffer)
@@ -366,7 +366,7 @@ func (o *OrderControllerSuite) TestCreateOrderWithSuite_WhenOrderServiceReturnsE
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	reqBodyBytes := new(bytes.Buffer)
@@ -386,7 +386,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite() {
 		District:     "Bakırköy",
 		CurrencyCode: "TRY",
 	}
-	o.mockOrderService.AssertCalled(o.T(), "UpdateOrder", "123456", updateOrderRequest)
+	o.mockOrderService.AssertCalled(o.T(), "UpdateOrder", mock.Anything, "123456", updateOrderRequest)
 	o.mockOrderService.AssertNumberOfCalls(o.T(), "UpdateOrder", 1)
 }
 
@@ -403,7 +403,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenRequestIsNotValid_Re
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenOrderNumberIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	reqBodyBytes := new(bytes.Buffer)
@@ -420,7 +420,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenOrderNumberIsNotVali
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenFirstNameIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.FirstName = ""
@@ -438,7 +438,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenFirstNameIsNotValid_
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenLastNameIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.LastName = ""
@@ -456,7 +456,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenLastNameIsNotValid_R
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenTotalAmountIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.TotalAmount = -12.13
@@ -474,7 +474,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenTotalAmountIsNotVali
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenAddressIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.Address = ""
@@ -492,7 +492,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenAddressIsNotValid_Re
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenCityIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.City = ""
@@ -510,7 +510,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenCityIsNotValid_Retur
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenDistrictIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.District = ""
@@ -528,7 +528,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenDistrictIsNotValid_R
 
 func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenCurrencyCodeIsNotValid_ReturnsBadRequest() {
 	//Given
-	o.mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.CurrencyCode = ""
@@ -549,7 +549,7 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenOrderServiceReturnsE
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusNotFound, "notFound").
 		Build()
-	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(&serviceErr)
+	o.mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	reqBodyBytes := new(bytes.Buffer)
@@ -565,14 +565,14 @@ func (o *OrderControllerSuite) TestUpdateOrderWithSuite_WhenOrderServiceReturnsE
 
 func (o *OrderControllerSuite) TestDeleteOrderWithSuite() {
 	//Given
-	o.mockOrderService.On("DeleteOrder", mock.Anything).Return(nil)
+	o.mockOrderService.On("DeleteOrder", mock.Anything, mock.Anything).Return(nil)
 
 	//When
 	o.sendRequest("DELETE", "/orders/123456", nil)
 
 	//Then
 	assert.Equal(o.T(), http.StatusNoContent, o.recorder.Code)
-	o.mockOrderService.AssertCalled(o.T(), "DeleteOrder", "123456")
+	o.mockOrderService.AssertCalled(o.T(), "DeleteOrder", mock.Anything, "123456")
 	o.mockOrderService.AssertNumberOfCalls(o.T(), "DeleteOrder", 1)
 }
 
@@ -592,7 +592,7 @@ func (o *OrderControllerSuite) TestDeleteOrderWithSuite_WhenOrderServiceReturnsE
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusNotFound, "notFound").
 		Build()
-	o.mockOrderService.On("DeleteOrder", mock.Anything).Return(&serviceErr)
+	o.mockOrderService.On("DeleteOrder", mock.Anything, mock.Anything).Return(&serviceErr)
 
 	//When
 	o.sendRequest("DELETE", "/orders/123456", nil)
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -9,9 +10,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
 	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
 	"testing"
 )
 
@@ -32,7 +36,7 @@ func TestGetOrders(t *testing.T) {
 			CurrencyCode: "TR",
 		},
 	}
-	mockOrderService.On("GetOrders").Return(orders, nil)
+	mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return(orders, nil)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -47,7 +51,29 @@ func TestGetOrders(t *testing.T) {
 	expectedResp := &[]response.Order{}
 	_ = json.Unmarshal(w.Body.Bytes(), expectedResp)
 	assert.Equal(t, orders, *expectedResp)
-	mockOrderService.AssertCalled(t, "GetOrders")
+	mockOrderService.AssertCalled(t, "GetOrders", mock.Anything, mock.Anything)
+	mockOrderService.AssertNumberOfCalls(t, "GetOrders", 1)
+}
+
+func TestGetOrders_PassesRequestContextWithDeadlineToService(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	hasDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})
+	mockOrderService.On("GetOrders", hasDeadline, mock.Anything).Return([]response.Order{}, nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
 	mockOrderService.AssertNumberOfCalls(t, "GetOrders", 1)
 }
 
@@ -59,7 +85,7 @@ func TestGetOrders_WhenServiceReturnsError_ReturnsInternalServerError(t *testing
 		SetError(http.StatusInternalServerError, "test").
 		Build()
 
-	mockOrderService.On("GetOrders").Return(nil, &serviceErr)
+	mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return(nil, &serviceErr)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -75,6 +101,175 @@ func TestGetOrders_WhenServiceReturnsError_ReturnsInternalServerError(t *testing
 	assert.Equal(t, errResponse, serviceErr)
 }
 
+func TestGetOrders_PassesQueryFiltersToService(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	minTotalAmount := float32(10.5)
+	filter := request.OrderFilter{StatusIds: []int{1, 2}, City: "İstanbul", MinTotalAmount: &minTotalAmount}
+	mockOrderService.On("GetOrders", mock.Anything, filter).Return([]response.Order{}, nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders?status=1,2&city=%C4%B0stanbul&minTotalAmount=10.5", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockOrderService.AssertCalled(t, "GetOrders", mock.Anything, filter)
+}
+
+func TestGetOrders_WhenFilterIsNotValid_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders?maxTotalAmount=ten", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, constants.OrderFilterIsNotValid, errResponse.Message)
+	mockOrderService.AssertNotCalled(t, "GetOrders", mock.Anything, mock.Anything)
+}
+
+func TestGetOrders_WhenNdjsonIsAccepted_StreamsOneOrderPerLine(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	orders := []response.Order{{OrderNumber: "1", City: "Ankara"}, {OrderNumber: "2", City: "İzmir"}}
+	mockOrderService.On("StreamOrders", mock.Anything, mock.MatchedBy(func(filter request.OrderFilter) bool {
+		return filter.City == "Ankara"
+	}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(2).(func(order response.Order) bool)
+			for _, order := range orders {
+				visit(order)
+			}
+		}).
+		Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders?city=Ankara", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := bytes.Split(bytes.TrimSpace(w.Body.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	for i, line := range lines {
+		order := response.Order{}
+		assert.Nil(t, json.Unmarshal(line, &order))
+		assert.Equal(t, orders[i], order)
+	}
+	mockOrderService.AssertNotCalled(t, "GetOrders", mock.Anything, mock.Anything)
+}
+
+func TestGetOrders_WhenNdjsonIsAcceptedAndNothingMatches_ReturnsEmptyStream(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Body.Bytes())
+}
+
+func TestGetOrders_WhenStreamFailsBeforeFirstOrder_ReturnsError(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusGatewayTimeout, constants.RequestTimedOut).
+		Build()
+	mockOrderService.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	errorResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.Equal(t, constants.RequestTimedOut, errorResponse.Message)
+}
+
+func TestGetOrders_WhenClientDisconnects_StopsStreaming(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	ctx, disconnect := context.WithCancel(context.Background())
+	continued := make([]bool, 0)
+	mockOrderService.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(2).(func(order response.Order) bool)
+			continued = append(continued, visit(response.Order{OrderNumber: "1"}))
+			disconnect()
+			continued = append(continued, visit(response.Order{OrderNumber: "2"}))
+		}).
+		Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/orders", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, []bool{true, false}, continued)
+	assert.Equal(t, 1, bytes.Count(w.Body.Bytes(), []byte("\n")))
+}
+
+func TestGetOrders_WhenAnythingIsAccepted_ReturnsJsonArray(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrders", mock.Anything, mock.Anything).Return([]response.Order{{OrderNumber: "1"}}, nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set("Accept", "*/*")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	mockOrderService.AssertNotCalled(t, "StreamOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGetOrderByOrderNumber(t *testing.T) {
 	//Given
 	engine := gin.New()
@@ -91,7 +286,7 @@ func TestGetOrderByOrderNumber(t *testing.T) {
 		StatusId:     2,
 		CurrencyCode: "TR",
 	}
-	mockOrderService.On("GetOrder", mock.Anything).Return(&order, nil)
+	mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(&order, nil)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -106,7 +301,7 @@ func TestGetOrderByOrderNumber(t *testing.T) {
 	expectedResp := &response.Order{}
 	_ = json.Unmarshal(w.Body.Bytes(), expectedResp)
 	assert.Equal(t, order, *expectedResp)
-	mockOrderService.AssertCalled(t, "GetOrder", "123456")
+	mockOrderService.AssertCalled(t, "GetOrder", mock.Anything, "123456")
 	mockOrderService.AssertNumberOfCalls(t, "GetOrder", 1)
 }
 
@@ -134,7 +329,7 @@ func TestGetOrderByOrderNumber_WhenOrderNotFound_returnsNotFoundError(t *testing
 	//Given
 	engine := gin.New()
 	mockOrderService := &mocks.MockOrderService{}
-	mockOrderService.On("GetOrder", mock.Anything).Return(nil, nil)
+	mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(nil, nil)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -157,7 +352,7 @@ func TestGetOrderByOrderNumber_WhenOrderServiceReturnsError_returnsInternalServe
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderService.On("GetOrder", mock.Anything).Return(nil, &serviceErr)
+	mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(nil, &serviceErr)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -177,7 +372,7 @@ func TestCreateOrder(t *testing.T) {
 	//Given
 	engine := gin.New()
 	mockOrderService := &mocks.MockOrderService{}
-	mockOrderService.On("CreateOrder", mock.Anything).Return(nil)
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	controller := NewOrderController(mockOrderService)
@@ -202,7 +397,7 @@ func TestCreateOrder(t *testing.T) {
 		District:     "Bakırköy",
 		CurrencyCode: "TRY",
 	}
-	mockOrderService.AssertCalled(t, "CreateOrder", createOrderRequest)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, createOrderRequest)
 	mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 1)
 }
 
@@ -276,6 +471,226 @@ func TestCreateOrder_WhenFirstNameIsNotValid_ReturnsBadRequest(t *testing.T) {
 	mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 0)
 }
 
+func TestCreateOrder_WhenCustomerIsReferenced_AcceptsMissingNames(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	serviceReq.FirstName, serviceReq.LastName, serviceReq.CustomerId = "", "", "customer-1"
+	mockOrderService.On("CreateOrder", mock.Anything, *serviceReq).Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+	reqBodyBytes := new(bytes.Buffer)
+	_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, *serviceReq)
+}
+
+func TestCreateOrder_WhenCustomerIsNotValid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		customerId      string
+		customer        request.CustomerRequest
+		expectedMessage string
+	}{
+		{"referenced and inline", "customer-1", request.CustomerRequest{FirstName: "Ayşe", LastName: "Yılmaz", Email: "ayse@example.com"}, constants.CustomerReferenceIsNotValid},
+		{"inline without email", "", request.CustomerRequest{FirstName: "Ayşe", LastName: "Yılmaz"}, constants.EmailIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockOrderService := &mocks.MockOrderService{}
+			serviceReq := &request.CreateOrderRequest{}
+			_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+			serviceReq.CustomerId, serviceReq.Customer = test.customerId, &test.customer
+			controller := NewOrderController(mockOrderService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+			reqBodyBytes := new(bytes.Buffer)
+			_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+			//When
+			req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+			engine.ServeHTTP(w, req)
+
+			//Then
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 0)
+		})
+	}
+}
+
+func TestCreateOrder_WhenShippingAddressIsStructured_AcceptsItWithoutFlatFields(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	serviceReq.Address, serviceReq.City, serviceReq.District = "", "", ""
+	serviceReq.ShippingAddress = &response.Address{Lines: []string{"10 Downing Street"}, City: "London", PostalCode: "sw1a 2aa", CountryCode: "gb"}
+	mockOrderService.On("CreateOrder", mock.Anything, *serviceReq).Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+	reqBodyBytes := new(bytes.Buffer)
+	_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, *serviceReq)
+}
+
+func TestCreateOrder_WhenAddressIsNotValidForCountry_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		shipping        *response.Address
+		billing         *response.Address
+		expectedMessage string
+	}{
+		{"no lines", &response.Address{City: "Berlin", PostalCode: "10117", CountryCode: "DE"}, nil, constants.AddressIsNotValid},
+		{"blank line", &response.Address{Lines: []string{"Unter den Linden 1", " "}, City: "Berlin", PostalCode: "10117", CountryCode: "DE"}, nil, constants.AddressIsNotValid},
+		{"no city", &response.Address{Lines: []string{"Unter den Linden 1"}, PostalCode: "10117", CountryCode: "DE"}, nil, constants.CityIsNotValid},
+		{"no country", &response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "10117"}, nil, constants.CountryCodeIsNotValid},
+		{"unknown country", &response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "10117", CountryCode: "XX"}, nil, constants.CountryCodeIsNotValid},
+		{"german postal code", &response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "1011", CountryCode: "DE"}, nil, constants.PostalCodeIsNotValid},
+		{"missing turkish postal code", &response.Address{Lines: []string{"Bağdat Caddesi 1"}, District: "Kadıköy", City: "İstanbul", CountryCode: "TR"}, nil, constants.PostalCodeIsNotValid},
+		{"turkish district", &response.Address{Lines: []string{"Bağdat Caddesi 1"}, City: "İstanbul", PostalCode: "34710", CountryCode: "TR"}, nil, constants.DistrictIsNotValid},
+		{"billing postal code", nil, &response.Address{Lines: []string{"1 Main Street"}, City: "Springfield", PostalCode: "ABCDE", CountryCode: "US"}, constants.PostalCodeIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockOrderService := &mocks.MockOrderService{}
+			serviceReq := &request.CreateOrderRequest{}
+			_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+			serviceReq.ShippingAddress, serviceReq.BillingAddress = test.shipping, test.billing
+			controller := NewOrderController(mockOrderService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+			reqBodyBytes := new(bytes.Buffer)
+			_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+			//When
+			req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+			engine.ServeHTTP(w, req)
+
+			//Then
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 0)
+		})
+	}
+}
+
+func TestCreateOrder_WhenItemsAreGiven_AcceptsOrderWithoutTotalAmount(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	serviceReq.TotalAmount = 0
+	serviceReq.Items = []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}}
+	mockOrderService.On("CreateOrder", mock.Anything, *serviceReq).Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+	reqBodyBytes := new(bytes.Buffer)
+	_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, *serviceReq)
+}
+
+func TestCreateOrder_WhenCouponIsGivenWithoutItems_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	serviceReq.CouponCode = "WELCOME10"
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+	reqBodyBytes := new(bytes.Buffer)
+	_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.CouponCodeIsNotValid, errResponse.Message)
+	mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 0)
+}
+
+func TestCreateOrder_WhenItemsAreNotValid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []response.OrderItem
+	}{
+		{"no quantity", []response.OrderItem{{Sku: "MUG-WHT"}}},
+		{"too large quantity", []response.OrderItem{{Sku: "MUG-WHT", Quantity: 1001}}},
+		{"no sku", []response.OrderItem{{Quantity: 1}}},
+		{"same sku twice", []response.OrderItem{{Sku: "MUG-WHT", Quantity: 1}, {Sku: "mug-wht", Quantity: 1}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockOrderService := &mocks.MockOrderService{}
+			serviceReq := &request.CreateOrderRequest{}
+			_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+			serviceReq.Items = test.items
+			controller := NewOrderController(mockOrderService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+			reqBodyBytes := new(bytes.Buffer)
+			_ = json.NewEncoder(reqBodyBytes).Encode(*serviceReq)
+
+			//When
+			req, _ := http.NewRequest("POST", "/orders", reqBodyBytes)
+			engine.ServeHTTP(w, req)
+
+			//Then
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, constants.OrderItemsAreNotValid, errResponse.Message)
+			mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 0)
+		})
+	}
+}
+
 func TestCreateOrder_WhenLastNameIsNotValid_ReturnsBadRequest(t *testing.T) {
 	//Given
 	engine := gin.New()
@@ -433,7 +848,7 @@ func TestCreateOrder_WhenOrderServiceReturnsError_returnsInternalServerError(t *
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderService.On("CreateOrder", mock.Anything).Return(&serviceErr)
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(&serviceErr)
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	controller := NewOrderController(mockOrderService)
@@ -457,7 +872,7 @@ func TestUpdateOrder(t *testing.T) {
 	//Given
 	engine := gin.New()
 	mockOrderService := &mocks.MockOrderService{}
-	mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
+	mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	controller := NewOrderController(mockOrderService)
@@ -481,7 +896,7 @@ func TestUpdateOrder(t *testing.T) {
 		District:     "Bakırköy",
 		CurrencyCode: "TRY",
 	}
-	mockOrderService.AssertCalled(t, "UpdateOrder", "123456", updateOrderRequest)
+	mockOrderService.AssertCalled(t, "UpdateOrder", mock.Anything, "123456", updateOrderRequest)
 	mockOrderService.AssertNumberOfCalls(t, "UpdateOrder", 1)
 }
 
@@ -533,7 +948,7 @@ func TestUpdateOrder_WhenFirstNameIsNotValid_ReturnsBadRequest(t *testing.T) {
 	//Given
 	engine := gin.New()
 	mockOrderService := &mocks.MockOrderService{}
-	mockOrderService.On("UpdateOrder", mock.Anything).Return(nil)
+	mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	serviceReq.FirstName = ""
@@ -712,7 +1127,7 @@ func TestUpdateOrder_WhenOrderServiceReturnsError_returnsInternalServerError(t *
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything).Return(&serviceErr)
+	mockOrderService.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
 	serviceReq := &request.UpdateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 	controller := NewOrderController(mockOrderService)
@@ -736,7 +1151,7 @@ func TestDeleteOrder(t *testing.T) {
 	//Given
 	engine := gin.New()
 	mockOrderService := &mocks.MockOrderService{}
-	mockOrderService.On("DeleteOrder", mock.Anything).Return(nil)
+	mockOrderService.On("DeleteOrder", mock.Anything, mock.Anything).Return(nil)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -747,7 +1162,7 @@ func TestDeleteOrder(t *testing.T) {
 
 	//Then
 	assert.Equal(t, http.StatusNoContent, w.Code)
-	mockOrderService.AssertCalled(t, "DeleteOrder", "123456")
+	mockOrderService.AssertCalled(t, "DeleteOrder", mock.Anything, "123456")
 	mockOrderService.AssertNumberOfCalls(t, "DeleteOrder", 1)
 }
 
@@ -778,7 +1193,7 @@ func TestDeleteOrder_WhenOrderServiceReturnsError_returnsInternalServerError(t *
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderService.On("DeleteOrder", mock.Anything).Return(&serviceErr)
+	mockOrderService.On("DeleteOrder", mock.Anything, mock.Anything).Return(&serviceErr)
 	controller := NewOrderController(mockOrderService)
 	controller.Register(engine)
 	w := httptest.NewRecorder()
@@ -794,6 +1209,112 @@ func TestDeleteOrder_WhenOrderServiceReturnsError_returnsInternalServerError(t *
 	assert.Equal(t, errResponse, serviceErr)
 }
 
+func TestTransitionOrder(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("TransitionOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("PATCH", "/orders/123456/status", bytes.NewBuffer([]byte(`{"statusId": 2}`)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockOrderService.AssertCalled(t, "TransitionOrder", mock.Anything, "123456", request.TransitionOrderRequest{StatusId: 2})
+}
+
+func TestTransitionOrder_WhenStatusIsInvalid_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("PATCH", "/orders/123456/status", bytes.NewBuffer([]byte(`{"statusId": 9}`)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, constants.StatusIsNotValid, errResponse.Message)
+	mockOrderService.AssertNumberOfCalls(t, "TransitionOrder", 0)
+}
+
+func TestGetOrderAudit(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	auditEntries := []response.AuditEntry{{
+		Id:          "1",
+		OrderNumber: "123456",
+		Action:      "update",
+		Actor:       "user:operator-1",
+		Changes:     []response.FieldChange{{Field: "city", Before: "İstanbul", After: "Ankara"}},
+	}}
+	mockOrderService.On("GetOrderAudit", mock.Anything, mock.Anything).Return(auditEntries, nil)
+	controller := NewOrderController(mockOrderService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/123456/audit", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	var actualAuditEntries []response.AuditEntry
+	_ = json.Unmarshal(w.Body.Bytes(), &actualAuditEntries)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, auditEntries, actualAuditEntries)
+	mockOrderService.AssertCalled(t, "GetOrderAudit", mock.Anything, "123456")
+}
+
+func TestRegister_WhenPrincipalLacksPermission_ReturnsForbidden(t *testing.T) {
+	tests := []struct {
+		method string
+		uri    string
+		role   string
+	}{
+		{"PUT", "/orders/123456", policies.CustomerRole},
+		{"PATCH", "/orders/123456/status", policies.CustomerRole},
+		{"GET", "/orders/123456/audit", policies.CustomerRole},
+		{"DELETE", "/orders/123456", policies.CustomerRole},
+		{"DELETE", "/orders/123456", policies.OperatorRole},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.uri+" as "+test.role, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				principal := &models.Principal{Subject: "user-1", Roles: []string{test.role}}
+				c.Request = c.Request.WithContext(helpers.WithPrincipal(c.Request.Context(), principal))
+			})
+			mockOrderService := &mocks.MockOrderService{}
+			controller := NewOrderController(mockOrderService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest(test.method, test.uri, nil)
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Equal(t, constants.PermissionDenied, errResponse.Message)
+			assert.Empty(t, mockOrderService.Calls)
+		})
+	}
+}
+
 func getCreateOrderRequest() string {
 	return `{
   "orderNumber": "1",
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type OrderEventController struct {
+	eventStream       events.EventStream
+	heartbeatInterval time.Duration
+}
+
+func NewOrderEventController(
+	eventStream events.EventStream,
+	heartbeatInterval time.Duration,
+) Controller {
+	return &OrderEventController{
+		eventStream:       eventStream,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// @Tags OrderEventController
+// @Description Stream Order Events as Server-Sent Events. Each event carries its sequence as id; reconnecting clients send it back as Last-Event-ID to receive the events they missed.
+// @Produce text/event-stream
+// @Success 200 {object} events.OrderEvent
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /orders/events [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param status query string false "comma separated status ids"
+// @Param orderNumber query string false "comma separated order numbers"
+// @Param Last-Event-ID header string false "id of the last event received"
+func (controller *OrderEventController) StreamOrderEvents() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		statusIds, ok := parseStatusIds(context.Query("status"))
+		if !ok {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.StatusIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		filter := events.OrderEventFilter{
+			OrderNumbers: splitQuery(context.Query("orderNumber")),
+			StatusIds:    statusIds,
+		}
+		principal := helpers.GetPrincipal(context.Request.Context())
+		if !policies.HasPermission(principal, policies.ReadAllOrders) {
+			filter.CustomerId = principal.Subject
+		}
+
+		lastEventId, _ := strconv.ParseInt(context.GetHeader(constants.LastEventIdHeader), 10, 64)
+		replay, updates, unsubscribe := controller.eventStream.Subscribe(lastEventId, filter)
+		defer unsubscribe()
+
+		context.Header("Content-Type", sse.ContentType)
+		context.Header("Cache-Control", "no-cache")
+		context.Header("Connection", "keep-alive")
+		context.Header("X-Accel-Buffering", "no")
+		context.Status(http.StatusOK)
+		for _, sequencedEvent := range replay {
+			renderEvent(context, sequencedEvent)
+		}
+		context.Writer.Flush()
+
+		heartbeat := time.NewTicker(controller.heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-context.Request.Context().Done():
+				return
+			case sequencedEvent, open := <-updates:
+				if !open {
+					return
+				}
+				renderEvent(context, sequencedEvent)
+			case <-heartbeat.C:
+				_, _ = context.Writer.WriteString(": heartbeat\n\n")
+			}
+			context.Writer.Flush()
+		}
+	}
+}
+
+func (controller *OrderEventController) Register(engine *gin.Engine) {
+	engine.GET("/orders/events", middlewares.RequirePermission(policies.ReadOrders), controller.StreamOrderEvents())
+}
+
+func renderEvent(context *gin.Context, sequencedEvent events.SequencedEvent) {
+	context.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(sequencedEvent.Sequence, 10),
+		Event: string(sequencedEvent.Event.Type),
+		Data:  sequencedEvent.Event,
+	})
+}
+
+func splitQuery(value string) []string {
+	values := make([]string, 0)
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); len(part) > 0 {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+func parseStatusIds(value string) ([]int, bool) {
+	statusIds := make([]int, 0)
+	for _, part := range splitQuery(value) {
+		statusId, err := strconv.Atoi(part)
+		if err != nil || !enum.OrderStatus(statusId).IsValid() {
+			return nil, false
+		}
+		statusIds = append(statusIds, statusId)
+	}
+	return statusIds, true
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newOrderEventServer(t *testing.T, eventStream events.EventStream, heartbeatInterval time.Duration) *httptest.Server {
+	engine := gin.New()
+	controller := NewOrderEventController(eventStream, heartbeatInterval)
+	controller.Register(engine)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// openOrderEvents connects to the stream and returns a function reading its next
+// non-empty line.
+func openOrderEvents(t *testing.T, server *httptest.Server, query string, lastEventId string) (*http.Response, func() string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/orders/events"+query, nil)
+	if len(lastEventId) > 0 {
+		req.Header.Set(constants.LastEventIdHeader, lastEventId)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	reader := bufio.NewReader(resp.Body)
+	return resp, func() string {
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			if line = strings.TrimSpace(line); len(line) > 0 {
+				return line
+			}
+		}
+	}
+}
+
+func TestStreamOrderEvents_StreamsMatchingEvents(t *testing.T) {
+	//Given
+	eventStream := events.NewEventStream(10, 10)
+	server := newOrderEventServer(t, eventStream, time.Minute)
+	resp, readLine := openOrderEvents(t, server, "?orderNumber=2&status=4", "")
+	shippedEvent := events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "2", StatusId: int(enum.Shipped)})
+
+	//When
+	_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "1", StatusId: int(enum.Shipped)}))
+	_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "2", StatusId: int(enum.Transferred)}))
+	_ = eventStream.HandleEvent(context.Background(), shippedEvent)
+
+	//Then
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id:3", readLine())
+	assert.Equal(t, "event:order.status.changed", readLine())
+	streamedEvent := events.OrderEvent{}
+	_ = json.Unmarshal([]byte(strings.TrimPrefix(readLine(), "data:")), &streamedEvent)
+	assert.Equal(t, shippedEvent.Id, streamedEvent.Id)
+}
+
+func TestStreamOrderEvents_WithLastEventId_ReplaysMissedEvents(t *testing.T) {
+	//Given
+	eventStream := events.NewEventStream(10, 10)
+	for _, orderNumber := range []string{"1", "2", "3"} {
+		_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: orderNumber}))
+	}
+	server := newOrderEventServer(t, eventStream, time.Minute)
+
+	//When
+	_, readLine := openOrderEvents(t, server, "", "1")
+
+	//Then
+	assert.Equal(t, "id:2", readLine())
+	readLine()
+	readLine()
+	assert.Equal(t, "id:3", readLine())
+}
+
+func TestStreamOrderEvents_SendsHeartbeatComments(t *testing.T) {
+	//Given
+	server := newOrderEventServer(t, events.NewEventStream(10, 10), 10*time.Millisecond)
+
+	//When
+	_, readLine := openOrderEvents(t, server, "", "")
+
+	//Then
+	assert.Equal(t, ": heartbeat", readLine())
+}
+
+func TestStreamOrderEvents_WhenStatusIsInvalid_ReturnsBadRequest(t *testing.T) {
+	//Given
+	engine := gin.New()
+	controller := NewOrderEventController(events.NewEventStream(10, 10), time.Minute)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/events?status=9", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, constants.StatusIsNotValid, errResponse.Message)
+}
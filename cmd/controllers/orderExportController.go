@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/exports"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const exportOrdersTimeout = 30 * time.Second
+
+type OrderExportController struct {
+	orderService services.OrderService
+	config       models.ExportConfig
+}
+
+func NewOrderExportController(
+	orderService services.OrderService,
+	config models.ExportConfig,
+) Controller {
+	return &OrderExportController{
+		orderService: orderService,
+		config:       config,
+	}
+}
+
+// @Tags OrderExportController
+// @Description Export the Orders matching the filters of GET /orders as a CSV or Excel file. Csv files are streamed while the orders are read; xlsx files are assembled in memory first. Amounts in csv files are formatted for the locale; locales writing decimals with a comma get semicolon separated files.
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /orders/export [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param format query string false "csv or xlsx" default(csv)
+// @Param columns query string false "comma separated columns, e.g. orderNumber,totalAmount,address"
+// @Param locale query string false "BCP 47 language tag of the number format, e.g. tr-TR"
+// @Param status query string false "comma separated status ids"
+// @Param customerId query string false "customerId"
+// @Param city query string false "city"
+// @Param district query string false "district"
+// @Param currencyCode query string false "currencyCode"
+// @Param minTotalAmount query number false "minimum total amount"
+// @Param maxTotalAmount query number false "maximum total amount"
+func (controller *OrderExportController) ExportOrders() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		filter, errorResponse := parseOrderFilter(context)
+		if errorResponse != nil {
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		columnKeys := splitQuery(context.Query("columns"))
+		if len(columnKeys) == 0 {
+			columnKeys = controller.config.Columns
+		}
+		columns, ok := exports.ParseColumns(columnKeys)
+		if !ok {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ExportColumnsAreNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		locale, err := language.Parse(context.DefaultQuery("locale", controller.config.Locale))
+		if err != nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.LocaleIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		exporter, ok := exports.NewExporter(context.DefaultQuery("format", exports.CsvFormat), locale)
+		if !ok {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ExportFormatIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, exportOrdersTimeout)
+		defer cancel()
+
+		fileName := fmt.Sprintf("orders-%s.%s", time.Now().UTC().Format("20060102-150405"), exporter.FileExtension())
+		writer := &attachmentWriter{context: context, contentType: exporter.ContentType(), fileName: fileName}
+		var errorResp *response.ErrorResponse
+		err = exporter.Export(writer, columns, func(visit func(order response.Order) bool) error {
+			errorResp = controller.orderService.StreamOrders(ctx, filter, func(order response.Order) bool {
+				return ctx.Err() == nil && visit(order)
+			})
+			if errorResp != nil {
+				return errors.New(errorResp.Message)
+			}
+			return nil
+		})
+
+		if errorResp != nil && !writer.started {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+		if err != nil {
+			// the file is already being sent; the client sees a truncated download
+			_ = context.Error(err)
+		}
+	}
+}
+
+// attachmentWriter sends the headers of the file with its first bytes, so that an
+// export failing before can still be answered with an error response.
+type attachmentWriter struct {
+	context     *gin.Context
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (writer *attachmentWriter) Write(data []byte) (int, error) {
+	if !writer.started {
+		writer.started = true
+		writer.context.Header("Content-Type", writer.contentType)
+		writer.context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", writer.fileName))
+		writer.context.Status(http.StatusOK)
+	}
+
+	return writer.context.Writer.Write(data)
+}
+
+func (controller *OrderExportController) Register(engine *gin.Engine) {
+	engine.GET("/orders/export", middlewares.RequirePermission(policies.ReadOrders), controller.ExportOrders())
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+func getExportConfig() models.ExportConfig {
+	return models.ExportConfig{
+		Columns: []string{"orderNumber", "totalAmount"},
+		Locale:  "en-US",
+	}
+}
+
+func mockExportOrders(orderService *mocks.MockOrderService, filter interface{}, orders ...response.Order) {
+	orderService.On("StreamOrders", mock.Anything, filter, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(2).(func(order response.Order) bool)
+			for _, order := range orders {
+				if !visit(order) {
+					return
+				}
+			}
+		}).
+		Return(nil)
+}
+
+func TestExportOrders_WritesCsvAttachmentOfFilteredOrders(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	filter := request.OrderFilter{StatusIds: []int{2}, City: "Ankara"}
+	orders := []response.Order{{OrderNumber: "1", TotalAmount: 1234.5, Address: "Kızılay, No: 1", City: "Ankara", StatusId: 2}}
+	mockExportOrders(mockOrderService, filter, orders...)
+	controller := NewOrderExportController(mockOrderService, getExportConfig())
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/export?status=2&city=Ankara&columns=orderNumber,address,totalAmount&locale=de-DE", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="orders-\d{8}-\d{6}\.csv"$`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Order Number;Address;Total Amount\n1;Kızılay, No: 1;1.234,50\n", strings.TrimPrefix(w.Body.String(), "\uFEFF"))
+}
+
+func TestExportOrders_WhenNoColumnsAreRequested_UsesConfiguredColumns(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	mockExportOrders(mockOrderService, mock.Anything, response.Order{OrderNumber: "1", TotalAmount: 10})
+	controller := NewOrderExportController(mockOrderService, getExportConfig())
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/export?format=xlsx", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "PK"))
+}
+
+func TestExportOrders_WhenQueryIsNotValid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		query   string
+		message string
+	}{
+		{"format=pdf", constants.ExportFormatIsNotValid},
+		{"columns=orderNumber,password", constants.ExportColumnsAreNotValid},
+		{"locale=not_a_locale!", constants.LocaleIsNotValid},
+		{"status=9", constants.StatusIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockOrderService := &mocks.MockOrderService{}
+			controller := NewOrderExportController(mockOrderService, getExportConfig())
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("GET", "/orders/export?"+test.query, nil)
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.message, errResponse.Message)
+			mockOrderService.AssertNotCalled(t, "StreamOrders", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestExportOrders_WhenOrdersCanNotBeRead_ReturnsError(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockOrderService := &mocks.MockOrderService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusGatewayTimeout, constants.RequestTimedOut).
+		Build()
+	mockOrderService.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	controller := NewOrderExportController(mockOrderService, getExportConfig())
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders/export", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	assert.Equal(t, constants.RequestTimedOut, errResponse.Message)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
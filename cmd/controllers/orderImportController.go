@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/imports"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	importOrdersTimeout = 30 * time.Second
+	importJobTimeout    = 5 * time.Second
+)
+
+type OrderImportController struct {
+	importService services.ImportService
+	config        models.ImportConfig
+}
+
+func NewOrderImportController(
+	importService services.ImportService,
+	config models.ImportConfig,
+) Controller {
+	return &OrderImportController{
+		importService: importService,
+		config:        config,
+	}
+}
+
+// @Tags OrderImportController
+// @Description Import Orders from a CSV file with a header row naming the order fields or from an NDJSON file with one order per line. Every row is validated like a created order; rows failing are listed in the job's errors with their line. Small files are imported within the request (200), larger ones as a background job (202) polled at the Location header. With dryRun nothing is stored.
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Success 200 {object} response.ImportJob
+// @Success 202 {object} response.ImportJob
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 413 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /orders/imports [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param format query string false "csv or ndjson, taken from the Content-Type when missing"
+// @Param dryRun query bool false "only validate the rows"
+// @Param file body string true "csv or ndjson content"
+func (controller *OrderImportController) ImportOrders() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		format := strings.ToLower(context.Query("format"))
+		if len(format) == 0 {
+			format = importFormat(context.ContentType())
+		}
+		dryRun, dryRunErr := strconv.ParseBool(context.DefaultQuery("dryRun", "false"))
+		if !imports.IsValidFormat(format) || dryRunErr != nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ImportFormatIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		content, err := io.ReadAll(io.LimitReader(context.Request.Body, controller.config.MaxFileSize+1))
+		if err != nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ImportFileIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if int64(len(content)) > controller.config.MaxFileSize {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusRequestEntityTooLarge, constants.ImportFileIsTooLarge).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		rows, err := imports.ReadOrders(bytes.NewReader(content), format)
+		if err != nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ImportFileIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, importOrdersTimeout)
+		defer cancel()
+
+		importJob, errorResp := controller.importService.ImportOrders(ctx, rows, format, dryRun)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		if importJob.Status == string(enum.RunningImportStatus) {
+			context.Header("Location", "/orders/imports/"+importJob.Id)
+			context.JSON(http.StatusAccepted, importJob)
+			return
+		}
+
+		context.JSON(http.StatusOK, importJob)
+	}
+}
+
+// @Tags OrderImportController
+// @Description Get Import Job with its progress and row errors
+// @Produce json
+// @Success 200 {object} response.ImportJob
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /orders/imports/{importJobId} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param importJobId path string true "importJobId"
+func (controller *OrderImportController) GetImportJob() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		importJobId, importJobIdErr := getStringParam(context, constants.ImportJobId)
+		if !helpers.IsValidString(importJobId, importJobIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.ImportJobIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, importJobTimeout)
+		defer cancel()
+
+		importJob, errorResp := controller.importService.GetImportJob(ctx, importJobId)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, importJob)
+	}
+}
+
+func (controller *OrderImportController) Register(engine *gin.Engine) {
+	orderImports := engine.Group("/orders/imports", middlewares.RequirePermission(policies.CreateOrders))
+	orderImports.POST("", controller.ImportOrders())
+	orderImports.GET("/:importJobId", controller.GetImportJob())
+}
+
+func importFormat(contentType string) string {
+	switch contentType {
+	case "application/x-ndjson", "application/ndjson":
+		return imports.NdjsonFormat
+	default:
+		return imports.CsvFormat
+	}
+}
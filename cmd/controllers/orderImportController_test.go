@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/imports"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+var testImportConfig = models.ImportConfig{MaxFileSize: 1024, SyncRowLimit: 10, MaxReportedErrors: 10}
+
+func TestImportOrders_ReadsNdjsonAndReturnsFinishedDryRun(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockImportService := &mocks.MockImportService{}
+	importJob := &response.ImportJob{Id: "job-1", Status: string(enum.CompletedImportStatus), TotalRows: 2}
+	hasTwoRows := mock.MatchedBy(func(rows []imports.Row) bool {
+		return len(rows) == 2 && rows[0].Request.OrderNumber == "1" && rows[1].Line == 2
+	})
+	mockImportService.On("ImportOrders", mock.Anything, hasTwoRows, imports.NdjsonFormat, true).Return(importJob, nil)
+	controller := NewOrderImportController(mockImportService, testImportConfig)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := strings.NewReader(`{"orderNumber": "1"}` + "\n" + `{"orderNumber": "2"}`)
+	req, _ := http.NewRequest("POST", "/orders/imports?dryRun=true", body)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := response.ImportJob{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, *importJob, resp)
+}
+
+func TestImportOrders_WhenJobRunsInBackground_ReturnsAcceptedWithLocation(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockImportService := &mocks.MockImportService{}
+	importJob := &response.ImportJob{Id: "job-1", Status: string(enum.RunningImportStatus)}
+	mockImportService.On("ImportOrders", mock.Anything, mock.Anything, imports.CsvFormat, false).Return(importJob, nil)
+	mockImportService.On("GetImportJob", mock.Anything, "job-1").Return(importJob, nil)
+	controller := NewOrderImportController(mockImportService, testImportConfig)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+	jobW := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("POST", "/orders/imports", strings.NewReader("orderNumber\n1\n"))
+	engine.ServeHTTP(w, req)
+	jobReq, _ := http.NewRequest("GET", w.Header().Get("Location"), nil)
+	engine.ServeHTTP(jobW, jobReq)
+
+	//Then
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "/orders/imports/job-1", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusOK, jobW.Code)
+}
+
+func TestImportOrders_WhenFileCanNotBeImported_ReturnsError(t *testing.T) {
+	tests := []struct {
+		query      string
+		body       string
+		statusCode int
+		message    string
+	}{
+		{"format=xml", "orderNumber\n1\n", http.StatusBadRequest, constants.ImportFormatIsNotValid},
+		{"dryRun=maybe", "orderNumber\n1\n", http.StatusBadRequest, constants.ImportFormatIsNotValid},
+		{"format=csv", "orderNumber,password\n1,secret\n", http.StatusBadRequest, constants.ImportFileIsNotValid},
+		{"format=csv", "orderNumber\n" + strings.Repeat("1\n", 1024), http.StatusRequestEntityTooLarge, constants.ImportFileIsTooLarge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockImportService := &mocks.MockImportService{}
+			controller := NewOrderImportController(mockImportService, testImportConfig)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/orders/imports?"+test.query, strings.NewReader(test.body))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, test.statusCode, w.Code)
+			assert.Equal(t, test.message, errResponse.Message)
+			mockImportService.AssertNotCalled(t, "ImportOrders", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
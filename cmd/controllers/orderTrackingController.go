@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/url"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"sort"
+	"time"
+)
+
+const (
+	subscribeTimeout     = 5 * time.Second
+	maxTrackingRequest   = 4096
+	subscribeAction      = "subscribe"
+	unsubscribeAction    = "unsubscribe"
+	subscriptionsMessage = "subscriptions"
+	errorMessage         = "error"
+)
+
+type OrderTrackingController struct {
+	orderService services.OrderService
+	eventStream  events.EventStream
+	config       models.WebSocketConfig
+	upgrader     websocket.Upgrader
+}
+
+func NewOrderTrackingController(
+	orderService services.OrderService,
+	eventStream events.EventStream,
+	config models.WebSocketConfig,
+	allowedOrigins []string,
+) Controller {
+	return &OrderTrackingController{
+		orderService: orderService,
+		eventStream:  eventStream,
+		config:       config,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if len(origin) == 0 || middlewares.IsAllowedOrigin(allowedOrigins, origin) {
+					return true
+				}
+				originUrl, err := url.Parse(origin)
+				return err == nil && originUrl.Host == r.Host
+			},
+		},
+	}
+}
+
+// @Tags OrderTrackingController
+// @Description Track Order Status over a WebSocket. Clients send {"action": "subscribe"|"unsubscribe", "orderNumbers": [...]} messages and receive a "subscriptions" message with the tracked order numbers, an "order.status.changed" message for every transition of a tracked order and "error" messages for rejected subscriptions. Browsers may pass the bearer token as access_token query parameter.
+// @Success 101 {object} response.OrderTrackingMessage
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Router /orders/track [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param orderNumber query string false "comma separated order numbers to subscribe to"
+// @Param access_token query string false "bearer token for clients that can not set headers"
+func (controller *OrderTrackingController) TrackOrders() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		connection, err := controller.upgrader.Upgrade(context.Writer, context.Request, nil)
+		if err != nil {
+			// the upgrader has already answered with an http error
+			return
+		}
+		defer connection.Close()
+
+		filter := events.OrderEventFilter{}
+		principal := helpers.GetPrincipal(context.Request.Context())
+		if !policies.HasPermission(principal, policies.ReadAllOrders) {
+			filter.CustomerId = principal.Subject
+		}
+		_, updates, unsubscribe := controller.eventStream.Subscribe(0, filter)
+		defer unsubscribe()
+
+		session := &orderTrackingSession{
+			controller:    controller,
+			context:       context,
+			connection:    connection,
+			subscriptions: make(map[string]struct{}),
+		}
+		session.run(updates)
+	}
+}
+
+func (controller *OrderTrackingController) Register(engine *gin.Engine) {
+	engine.GET("/orders/track", middlewares.RequirePermission(policies.ReadOrders), controller.TrackOrders())
+}
+
+// orderTrackingSession serves one WebSocket connection. Every write happens on the
+// goroutine calling run while a second goroutine reads the client's requests.
+type orderTrackingSession struct {
+	controller    *OrderTrackingController
+	context       *gin.Context
+	connection    *websocket.Conn
+	subscriptions map[string]struct{}
+}
+
+func (session *orderTrackingSession) run(updates <-chan events.SequencedEvent) {
+	requests := make(chan request.OrderSubscriptionRequest)
+	readerDone := make(chan struct{})
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go session.readRequests(requests, readerDone, stopped)
+
+	if orderNumbers := splitQuery(session.context.Query("orderNumber")); len(orderNumbers) > 0 {
+		if !session.subscribe(orderNumbers) {
+			return
+		}
+	}
+
+	ping := time.NewTicker(session.controller.config.PingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-readerDone:
+			return
+		case subscriptionRequest := <-requests:
+			if !session.handleRequest(subscriptionRequest) {
+				return
+			}
+		case sequencedEvent, open := <-updates:
+			if !open {
+				// the client reads slower than the orders change; it reconnects and subscribes again
+				session.close(websocket.CloseTryAgainLater, "subscriber is too slow")
+				return
+			}
+			if !session.handleEvent(sequencedEvent.Event) {
+				return
+			}
+		case <-ping.C:
+			deadline := time.Now().Add(session.controller.config.WriteWait)
+			if err := session.connection.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readRequests forwards the client's requests until the connection fails or no pong
+// arrives within the pong wait.
+func (session *orderTrackingSession) readRequests(requests chan<- request.OrderSubscriptionRequest, readerDone, stopped chan struct{}) {
+	defer close(readerDone)
+	pongWait := session.controller.config.PongWait
+	session.connection.SetReadLimit(maxTrackingRequest)
+	_ = session.connection.SetReadDeadline(time.Now().Add(pongWait))
+	session.connection.SetPongHandler(func(string) error {
+		return session.connection.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, message, err := session.connection.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		subscriptionRequest := request.OrderSubscriptionRequest{}
+		if err = json.Unmarshal(message, &subscriptionRequest); err != nil {
+			subscriptionRequest = request.OrderSubscriptionRequest{}
+		}
+		select {
+		case requests <- subscriptionRequest:
+		case <-stopped:
+			return
+		}
+	}
+}
+
+func (session *orderTrackingSession) handleRequest(subscriptionRequest request.OrderSubscriptionRequest) bool {
+	if len(subscriptionRequest.OrderNumbers) == 0 {
+		return session.writeError("", http.StatusBadRequest, constants.SubscriptionRequestIsNotValid)
+	}
+
+	switch subscriptionRequest.Action {
+	case subscribeAction:
+		return session.subscribe(subscriptionRequest.OrderNumbers)
+	case unsubscribeAction:
+		for _, orderNumber := range subscriptionRequest.OrderNumbers {
+			delete(session.subscriptions, orderNumber)
+		}
+		return session.writeSubscriptions()
+	default:
+		return session.writeError("", http.StatusBadRequest, constants.SubscriptionRequestIsNotValid)
+	}
+}
+
+// subscribe tracks the orders the caller may read; the service applies the same
+// ownership rules as GET /orders/{orderNumber}.
+func (session *orderTrackingSession) subscribe(orderNumbers []string) bool {
+	ctx, cancel := requestContext(session.context, subscribeTimeout)
+	defer cancel()
+
+	for _, orderNumber := range orderNumbers {
+		if _, ok := session.subscriptions[orderNumber]; ok {
+			continue
+		}
+
+		if len(session.subscriptions) >= session.controller.config.MaxSubscriptions {
+			if !session.writeError(orderNumber, http.StatusBadRequest, constants.SubscriptionLimitExceeded) {
+				return false
+			}
+			continue
+		}
+
+		order, errorResp := session.controller.orderService.GetOrder(ctx, orderNumber)
+		if errorResp != nil {
+			if !session.writeError(orderNumber, errorResp.StatusCode, errorResp.Message) {
+				return false
+			}
+			continue
+		}
+		if order == nil {
+			if !session.writeError(orderNumber, http.StatusNotFound, constants.OrderNotFoundByOrderNumber) {
+				return false
+			}
+			continue
+		}
+		session.subscriptions[orderNumber] = struct{}{}
+	}
+
+	return session.writeSubscriptions()
+}
+
+func (session *orderTrackingSession) handleEvent(event events.OrderEvent) bool {
+	if _, ok := session.subscriptions[event.OrderNumber]; !ok {
+		return true
+	}
+
+	switch event.Type {
+	case enum.OrderStatusChanged:
+		occurredAt := event.OccurredAt
+		return session.write(response.OrderTrackingMessage{
+			Type:             string(event.Type),
+			OrderNumber:      event.OrderNumber,
+			StatusId:         event.Order.StatusId,
+			PreviousStatusId: event.PreviousStatusId,
+			OccurredAt:       &occurredAt,
+		})
+	case enum.OrderDeleted:
+		delete(session.subscriptions, event.OrderNumber)
+		occurredAt := event.OccurredAt
+		return session.write(response.OrderTrackingMessage{
+			Type:        string(event.Type),
+			OrderNumber: event.OrderNumber,
+			OccurredAt:  &occurredAt,
+		})
+	default:
+		return true
+	}
+}
+
+func (session *orderTrackingSession) writeSubscriptions() bool {
+	orderNumbers := make([]string, 0, len(session.subscriptions))
+	for orderNumber := range session.subscriptions {
+		orderNumbers = append(orderNumbers, orderNumber)
+	}
+	sort.Strings(orderNumbers)
+
+	return session.write(response.OrderTrackingMessage{Type: subscriptionsMessage, OrderNumbers: orderNumbers})
+}
+
+func (session *orderTrackingSession) writeError(orderNumber string, statusCode int, message string) bool {
+	errorResponse := response.NewErrorBuilder().
+		SetError(statusCode, message).
+		Build()
+	return session.write(response.OrderTrackingMessage{Type: errorMessage, OrderNumber: orderNumber, Error: &errorResponse})
+}
+
+// write reports whether the message was sent; a client not accepting it within the
+// write wait is considered gone.
+func (session *orderTrackingSession) write(message response.OrderTrackingMessage) bool {
+	_ = session.connection.SetWriteDeadline(time.Now().Add(session.controller.config.WriteWait))
+	return session.connection.WriteJSON(message) == nil
+}
+
+func (session *orderTrackingSession) close(code int, reason string) {
+	deadline := time.Now().Add(session.controller.config.WriteWait)
+	_ = session.connection.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), deadline)
+}
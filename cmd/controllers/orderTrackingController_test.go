@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newOrderTrackingServer(t *testing.T, orderService *mocks.MockOrderService, eventStream events.EventStream, config models.WebSocketConfig) *httptest.Server {
+	engine := gin.New()
+	controller := NewOrderTrackingController(orderService, eventStream, config, []string{"https://app.example.com"})
+	controller.Register(engine)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getWebSocketConfig() models.WebSocketConfig {
+	return models.WebSocketConfig{
+		PingInterval:     time.Minute,
+		PongWait:         time.Minute,
+		WriteWait:        time.Second,
+		MaxSubscriptions: 2,
+	}
+}
+
+func dialOrderTracking(t *testing.T, server *httptest.Server, query string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	trackingUrl := "ws" + strings.TrimPrefix(server.URL, "http") + "/orders/track" + query
+	connection, resp, err := websocket.DefaultDialer.Dial(trackingUrl, header)
+	if err == nil {
+		t.Cleanup(func() { _ = connection.Close() })
+	}
+	return connection, resp, err
+}
+
+func readTrackingMessage(t *testing.T, connection *websocket.Conn) response.OrderTrackingMessage {
+	_ = connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	message := response.OrderTrackingMessage{}
+	require.NoError(t, connection.ReadJSON(&message))
+	return message
+}
+
+func TestTrackOrders_SendsStatusTransitionsOfSubscribedOrders(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, mock.Anything).Return(&response.Order{}, nil)
+	eventStream := events.NewEventStream(10, 10)
+	server := newOrderTrackingServer(t, mockOrderService, eventStream, getWebSocketConfig())
+	connection, _, err := dialOrderTracking(t, server, "?orderNumber=1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, readTrackingMessage(t, connection).OrderNumbers)
+	shippedEvent := events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "2", StatusId: int(enum.Shipped)})
+	shippedEvent.PreviousStatusId = int(enum.Transferred)
+
+	//When
+	require.NoError(t, connection.WriteJSON(request.OrderSubscriptionRequest{Action: "subscribe", OrderNumbers: []string{"2"}}))
+	subscriptions := readTrackingMessage(t, connection)
+	_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "3", StatusId: int(enum.Shipped)}))
+	_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderUpdated, response.Order{OrderNumber: "2", StatusId: int(enum.Transferred)}))
+	_ = eventStream.HandleEvent(context.Background(), shippedEvent)
+
+	//Then
+	assert.Equal(t, []string{"1", "2"}, subscriptions.OrderNumbers)
+	message := readTrackingMessage(t, connection)
+	assert.Equal(t, string(enum.OrderStatusChanged), message.Type)
+	assert.Equal(t, "2", message.OrderNumber)
+	assert.Equal(t, int(enum.Shipped), message.StatusId)
+	assert.Equal(t, int(enum.Transferred), message.PreviousStatusId)
+	mockOrderService.AssertCalled(t, "GetOrder", mock.Anything, "1")
+	mockOrderService.AssertCalled(t, "GetOrder", mock.Anything, "2")
+}
+
+func TestTrackOrders_WhenOrderCanNotBeRead_SendsErrorAndSkipsIt(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	accessDenied := response.NewErrorBuilder().
+		SetError(http.StatusForbidden, constants.OrderAccessDenied).
+		Build()
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{}, nil)
+	mockOrderService.On("GetOrder", mock.Anything, "2").Return(nil, &accessDenied)
+	mockOrderService.On("GetOrder", mock.Anything, "3").Return(&response.Order{}, nil)
+	eventStream := events.NewEventStream(10, 10)
+	server := newOrderTrackingServer(t, mockOrderService, eventStream, getWebSocketConfig())
+
+	//When
+	connection, _, err := dialOrderTracking(t, server, "?orderNumber=1,2,3,4", nil)
+
+	//Then
+	require.NoError(t, err)
+	denied := readTrackingMessage(t, connection)
+	assert.Equal(t, "error", denied.Type)
+	assert.Equal(t, "2", denied.OrderNumber)
+	assert.Equal(t, accessDenied, *denied.Error)
+	limitExceeded := readTrackingMessage(t, connection)
+	assert.Equal(t, "4", limitExceeded.OrderNumber)
+	assert.Equal(t, constants.SubscriptionLimitExceeded, limitExceeded.Error.Message)
+	assert.Equal(t, []string{"1", "3"}, readTrackingMessage(t, connection).OrderNumbers)
+	mockOrderService.AssertNotCalled(t, "GetOrder", mock.Anything, "4")
+}
+
+func TestTrackOrders_WhenOrderIsNotFound_SendsNotFoundErrorAndSkipsIt(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{}, nil)
+	mockOrderService.On("GetOrder", mock.Anything, "2").Return(nil, nil)
+	eventStream := events.NewEventStream(10, 10)
+	server := newOrderTrackingServer(t, mockOrderService, eventStream, getWebSocketConfig())
+
+	//When
+	connection, _, err := dialOrderTracking(t, server, "?orderNumber=1,2", nil)
+
+	//Then
+	require.NoError(t, err)
+	notFound := readTrackingMessage(t, connection)
+	assert.Equal(t, "error", notFound.Type)
+	assert.Equal(t, "2", notFound.OrderNumber)
+	assert.Equal(t, http.StatusNotFound, notFound.Error.StatusCode)
+	assert.Equal(t, constants.OrderNotFoundByOrderNumber, notFound.Error.Message)
+	assert.Equal(t, []string{"1"}, readTrackingMessage(t, connection).OrderNumbers)
+}
+
+func TestTrackOrders_WhenRequestIsNotValid_SendsError(t *testing.T) {
+	//Given
+	server := newOrderTrackingServer(t, &mocks.MockOrderService{}, events.NewEventStream(10, 10), getWebSocketConfig())
+	connection, _, err := dialOrderTracking(t, server, "", nil)
+	require.NoError(t, err)
+
+	//When
+	require.NoError(t, connection.WriteMessage(websocket.TextMessage, []byte("not-json")))
+
+	//Then
+	message := readTrackingMessage(t, connection)
+	assert.Equal(t, "error", message.Type)
+	assert.Equal(t, constants.SubscriptionRequestIsNotValid, message.Error.Message)
+}
+
+func TestTrackOrders_PingsTheClient(t *testing.T) {
+	//Given
+	config := getWebSocketConfig()
+	config.PingInterval = 10 * time.Millisecond
+	server := newOrderTrackingServer(t, &mocks.MockOrderService{}, events.NewEventStream(10, 10), config)
+	connection, _, err := dialOrderTracking(t, server, "", nil)
+	require.NoError(t, err)
+	pinged := make(chan struct{}, 1)
+	connection.SetPingHandler(func(string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	//When
+	go func() {
+		for {
+			if _, _, err := connection.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	//Then
+	select {
+	case <-pinged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no ping received")
+	}
+}
+
+func TestTrackOrders_WhenClientFallsBehind_ClosesWithTryAgainLater(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	subscribing := make(chan struct{})
+	release := make(chan struct{})
+	mockOrderService.On("GetOrder", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			close(subscribing)
+			<-release
+		}).
+		Return(&response.Order{}, nil)
+	eventStream := events.NewEventStream(10, 1)
+	server := newOrderTrackingServer(t, mockOrderService, eventStream, getWebSocketConfig())
+	connection, _, err := dialOrderTracking(t, server, "", nil)
+	require.NoError(t, err)
+	require.NoError(t, connection.WriteJSON(request.OrderSubscriptionRequest{Action: "subscribe", OrderNumbers: []string{"1"}}))
+	<-subscribing
+
+	//When
+	for i := 0; i < 3; i++ {
+		_ = eventStream.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "1"}))
+	}
+	close(release)
+
+	//Then
+	assert.Equal(t, []string{"1"}, readTrackingMessage(t, connection).OrderNumbers)
+	_ = connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for err == nil {
+		// the event buffered before the overflow is still delivered
+		_, _, err = connection.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), err)
+}
+
+func TestTrackOrders_WhenOriginIsNotAllowed_RejectsHandshake(t *testing.T) {
+	//Given
+	server := newOrderTrackingServer(t, &mocks.MockOrderService{}, events.NewEventStream(10, 10), getWebSocketConfig())
+
+	//When
+	_, allowedResp, allowedErr := dialOrderTracking(t, server, "", http.Header{"Origin": {"https://app.example.com"}})
+	_, rejectedResp, rejectedErr := dialOrderTracking(t, server, "", http.Header{"Origin": {"https://evil.example.com"}})
+
+	//Then
+	require.NoError(t, allowedErr)
+	assert.Equal(t, http.StatusSwitchingProtocols, allowedResp.StatusCode)
+	assert.Error(t, rejectedErr)
+	assert.Equal(t, http.StatusForbidden, rejectedResp.StatusCode)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const productTimeout = 5 * time.Second
+
+type ProductController struct {
+	productService services.ProductService
+}
+
+func NewProductController(
+	productService services.ProductService,
+) Controller {
+	return &ProductController{
+		productService: productService,
+	}
+}
+
+// @Tags ProductController
+// @Description Get Products. Inactive products are listed only to callers who may change the catalog.
+// @Produce json
+// @Success 200 {object} []response.Product
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /products [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (controller *ProductController) GetProducts() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, productTimeout)
+		defer cancel()
+
+		products, errorResp := controller.productService.GetProducts(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, products)
+	}
+}
+
+// @Tags ProductController
+// @Description Get Product By SKU
+// @Produce json
+// @Success 200 {object} response.Product
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /products/{sku} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param sku path string true "sku"
+func (controller *ProductController) GetProduct() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		sku, ok := getSku(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, productTimeout)
+		defer cancel()
+
+		product, errorResp := controller.productService.GetProduct(ctx, sku)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, product)
+	}
+}
+
+// @Tags ProductController
+// @Description Create Product. SKUs and currency codes are upper cased; products are active unless active is false.
+// @Accept json
+// @Produce json
+// @Success 201 {object} response.Product
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /products [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param request body request.CreateProductRequest true "Create Product Request"
+func (controller *ProductController) CreateProduct() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		createProductRequest := &request.CreateProductRequest{}
+		if !bindProductRequest(context, createProductRequest) {
+			return
+		}
+
+		ctx, cancel := requestContext(context, productTimeout)
+		defer cancel()
+
+		product, createErr := controller.productService.CreateProduct(ctx, *createProductRequest)
+		if createErr != nil {
+			context.JSON(createErr.StatusCode, createErr)
+			return
+		}
+
+		context.JSON(http.StatusCreated, product)
+	}
+}
+
+// @Tags ProductController
+// @Description Update Product. Deactivate products with active false; they are kept for the orders referencing them.
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Product
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /products/{sku} [put]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param sku path string true "sku"
+// @Param request body request.UpdateProductRequest true "Update Product Request"
+func (controller *ProductController) UpdateProduct() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		sku, ok := getSku(context)
+		if !ok {
+			return
+		}
+
+		updateProductRequest := &request.UpdateProductRequest{}
+		if !bindProductRequest(context, updateProductRequest) {
+			return
+		}
+
+		ctx, cancel := requestContext(context, productTimeout)
+		defer cancel()
+
+		product, updateErr := controller.productService.UpdateProduct(ctx, sku, *updateProductRequest)
+		if updateErr != nil {
+			context.JSON(updateErr.StatusCode, updateErr)
+			return
+		}
+
+		context.JSON(http.StatusOK, product)
+	}
+}
+
+func (controller *ProductController) Register(engine *gin.Engine) {
+	products := engine.Group("/products")
+	products.GET("", middlewares.RequirePermission(policies.ReadProducts), controller.GetProducts())
+	products.GET("/:sku", middlewares.RequirePermission(policies.ReadProducts), controller.GetProduct())
+	products.POST("", middlewares.RequirePermission(policies.WriteProducts), controller.CreateProduct())
+	products.PUT("/:sku", middlewares.RequirePermission(policies.WriteProducts), controller.UpdateProduct())
+}
+
+func getSku(context *gin.Context) (string, bool) {
+	sku, skuErr := getStringParam(context, constants.Sku)
+	if !helpers.IsValidString(sku, skuErr) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.SkuIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return "", false
+	}
+
+	return sku, true
+}
+
+// bindProductRequest binds and validates a create or update request, answering the
+// request when it is not valid.
+func bindProductRequest(context *gin.Context, productRequest interface {
+	Validate() *response.ErrorResponse
+}) bool {
+	if !bindRequestBody(context, productRequest) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.ProductRequestIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return false
+	}
+
+	if errorResponse := productRequest.Validate(); errorResponse != nil {
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return false
+	}
+
+	return true
+}
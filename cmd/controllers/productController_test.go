@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestCreateProduct(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockProductService := &mocks.MockProductService{}
+	product := response.Product{Sku: "CAP-RED", Name: "Red Cap", Prices: []response.Price{{CurrencyCode: "TRY", Amount: 199.9}}, Active: true}
+	mockProductService.On("CreateProduct", mock.Anything, mock.Anything).Return(&product, nil)
+	controller := NewProductController(mockProductService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"sku": "CAP-RED", "name": "Red Cap", "prices": [{"currencyCode": "TRY", "amount": 199.9}]}`
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	resp := response.Product{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, product, resp)
+	mockProductService.AssertCalled(t, "CreateProduct", mock.Anything, request.CreateProductRequest{
+		Sku:    "CAP-RED",
+		Name:   "Red Cap",
+		Prices: []response.Price{{CurrencyCode: "TRY", Amount: 199.9}},
+	})
+}
+
+func TestCreateProduct_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{"malformed body", `{"sku": `, constants.ProductRequestIsNotValid},
+		{"sku with spaces", `{"sku": "RED CAP", "name": "Red Cap", "prices": [{"currencyCode": "TRY", "amount": 1}]}`, constants.SkuIsNotValid},
+		{"missing name", `{"sku": "CAP-RED", "prices": [{"currencyCode": "TRY", "amount": 1}]}`, constants.ProductNameIsNotValid},
+		{"missing prices", `{"sku": "CAP-RED", "name": "Red Cap"}`, constants.ProductPricesAreNotValid},
+		{"unknown currency", `{"sku": "CAP-RED", "name": "Red Cap", "prices": [{"currencyCode": "TR", "amount": 1}]}`, constants.ProductPricesAreNotValid},
+		{"zero price", `{"sku": "CAP-RED", "name": "Red Cap", "prices": [{"currencyCode": "TRY", "amount": 0}]}`, constants.ProductPricesAreNotValid},
+		{"two prices in a currency", `{"sku": "CAP-RED", "name": "Red Cap",
+			"prices": [{"currencyCode": "TRY", "amount": 1}, {"currencyCode": "try", "amount": 2}]}`, constants.ProductPricesAreNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockProductService := &mocks.MockProductService{}
+			controller := NewProductController(mockProductService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/products", bytes.NewBuffer([]byte(test.body)))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockProductService.AssertNumberOfCalls(t, "CreateProduct", 0)
+		})
+	}
+}
+
+func TestUpdateProduct_DeactivatesProduct(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockProductService := &mocks.MockProductService{}
+	product := response.Product{Sku: "MUG-WHT", Name: "White Mug", Prices: []response.Price{{CurrencyCode: "EUR", Amount: 7.5}}}
+	mockProductService.On("UpdateProduct", mock.Anything, "MUG-WHT", mock.Anything).Return(&product, nil)
+	controller := NewProductController(mockProductService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"name": "White Mug", "prices": [{"currencyCode": "EUR", "amount": 7.5}], "active": false}`
+	req, _ := http.NewRequest("PUT", "/products/MUG-WHT", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	active := false
+	mockProductService.AssertCalled(t, "UpdateProduct", mock.Anything, "MUG-WHT", request.UpdateProductRequest{
+		Name:   "White Mug",
+		Prices: []response.Price{{CurrencyCode: "EUR", Amount: 7.5}},
+		Active: &active,
+	})
+}
+
+func TestGetProduct_WhenProductDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockProductService := &mocks.MockProductService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.ProductNotFound).
+		Build()
+	mockProductService.On("GetProduct", mock.Anything, "NOTHING").Return(nil, &serviceErr)
+	controller := NewProductController(mockProductService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/products/NOTHING", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.ProductNotFound, errResponse.Message)
+}
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const promotionTimeout = 5 * time.Second
+
+type PromotionController struct {
+	promotionService services.PromotionService
+}
+
+func NewPromotionController(
+	promotionService services.PromotionService,
+) Controller {
+	return &PromotionController{
+		promotionService: promotionService,
+	}
+}
+
+// @Tags PromotionController
+// @Description Get Coupons And Automatic Promotions with the number of orders using them
+// @Produce json
+// @Success 200 {object} []response.Promotion
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (controller *PromotionController) GetPromotions() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, promotionTimeout)
+		defer cancel()
+
+		promotions, errorResp := controller.promotionService.GetPromotions(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, promotions)
+	}
+}
+
+// @Tags PromotionController
+// @Description Get Promotion By Code
+// @Produce json
+// @Success 200 {object} response.Promotion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions/{promotionCode} [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param promotionCode path string true "promotionCode"
+func (controller *PromotionController) GetPromotion() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		code, ok := getPromotionCode(context)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := requestContext(context, promotionTimeout)
+		defer cancel()
+
+		promotion, errorResp := controller.promotionService.GetPromotion(ctx, code)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, promotion)
+	}
+}
+
+// @Tags PromotionController
+// @Description Create Promotion. Coupons are applied to orders giving their code, automatic promotions to every order they apply to; type is percentage or fixed.
+// @Accept json
+// @Produce json
+// @Success 201 {object} response.Promotion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 409 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param request body request.CreatePromotionRequest true "Create Promotion Request"
+func (controller *PromotionController) CreatePromotion() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		createPromotionRequest := &request.CreatePromotionRequest{}
+		if !bindPromotionRequest(context, createPromotionRequest) {
+			return
+		}
+
+		ctx, cancel := requestContext(context, promotionTimeout)
+		defer cancel()
+
+		promotion, createErr := controller.promotionService.CreatePromotion(ctx, *createPromotionRequest)
+		if createErr != nil {
+			context.JSON(createErr.StatusCode, createErr)
+			return
+		}
+
+		context.JSON(http.StatusCreated, promotion)
+	}
+}
+
+// @Tags PromotionController
+// @Description Update Promotion. End campaigns with active false or validUntil; orders keep the discounts they got.
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Promotion
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /promotions/{promotionCode} [put]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param promotionCode path string true "promotionCode"
+// @Param request body request.UpdatePromotionRequest true "Update Promotion Request"
+func (controller *PromotionController) UpdatePromotion() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		code, ok := getPromotionCode(context)
+		if !ok {
+			return
+		}
+
+		updatePromotionRequest := &request.UpdatePromotionRequest{}
+		if !bindPromotionRequest(context, updatePromotionRequest) {
+			return
+		}
+
+		ctx, cancel := requestContext(context, promotionTimeout)
+		defer cancel()
+
+		promotion, updateErr := controller.promotionService.UpdatePromotion(ctx, code, *updatePromotionRequest)
+		if updateErr != nil {
+			context.JSON(updateErr.StatusCode, updateErr)
+			return
+		}
+
+		context.JSON(http.StatusOK, promotion)
+	}
+}
+
+func (controller *PromotionController) Register(engine *gin.Engine) {
+	promotions := engine.Group("/promotions")
+	promotions.GET("", middlewares.RequirePermission(policies.ReadPromotions), controller.GetPromotions())
+	promotions.GET("/:promotionCode", middlewares.RequirePermission(policies.ReadPromotions), controller.GetPromotion())
+	promotions.POST("", middlewares.RequirePermission(policies.WritePromotions), controller.CreatePromotion())
+	promotions.PUT("/:promotionCode", middlewares.RequirePermission(policies.WritePromotions), controller.UpdatePromotion())
+}
+
+func getPromotionCode(context *gin.Context) (string, bool) {
+	code, codeErr := getStringParam(context, constants.PromotionCode)
+	if !helpers.IsValidString(code, codeErr) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.PromotionCodeIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return "", false
+	}
+
+	return code, true
+}
+
+// bindPromotionRequest binds and validates a create or update request, answering the
+// request when it is not valid.
+func bindPromotionRequest(context *gin.Context, promotionRequest interface {
+	Validate() *response.ErrorResponse
+}) bool {
+	if !bindRequestBody(context, promotionRequest) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.PromotionRequestIsNotValid).
+			Build()
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return false
+	}
+
+	if errorResponse := promotionRequest.Validate(); errorResponse != nil {
+		context.JSON(errorResponse.StatusCode, errorResponse)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockPromotionService := &mocks.MockPromotionService{}
+	promotion := response.Promotion{Code: "SAVE10EUR", Name: "10 EUR off", Type: "fixed", Value: 10, CurrencyCode: "EUR", MinBasketAmount: 50, Active: true}
+	mockPromotionService.On("CreatePromotion", mock.Anything, mock.Anything).Return(&promotion, nil)
+	controller := NewPromotionController(mockPromotionService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"code": "save10eur", "name": "10 EUR off", "type": "fixed", "value": 10, "currencyCode": "EUR", "minBasketAmount": 50}`
+	req, _ := http.NewRequest("POST", "/promotions", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	resp := response.Promotion{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, promotion, resp)
+	mockPromotionService.AssertCalled(t, "CreatePromotion", mock.Anything, request.CreatePromotionRequest{
+		Code:            "save10eur",
+		Name:            "10 EUR off",
+		Type:            "fixed",
+		Value:           10,
+		CurrencyCode:    "EUR",
+		MinBasketAmount: 50,
+	})
+}
+
+func TestCreatePromotion_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{"malformed body", `{"code": `, constants.PromotionRequestIsNotValid},
+		{"code with spaces", `{"code": "SAVE 10", "name": "Save", "type": "percentage", "value": 10}`, constants.PromotionCodeIsNotValid},
+		{"missing name", `{"code": "SAVE10", "type": "percentage", "value": 10}`, constants.PromotionNameIsNotValid},
+		{"unknown type", `{"code": "SAVE10", "name": "Save", "type": "gift", "value": 10}`, constants.PromotionTypeIsNotValid},
+		{"percentage above 100", `{"code": "SAVE10", "name": "Save", "type": "percentage", "value": 110}`, constants.PromotionValueIsNotValid},
+		{"fixed without currency", `{"code": "SAVE10", "name": "Save", "type": "fixed", "value": 10}`, constants.PromotionCurrencyCodeIsNotValid},
+		{"minimum basket without currency", `{"code": "SAVE10", "name": "Save", "type": "percentage", "value": 10, "minBasketAmount": 50}`,
+			constants.PromotionMinBasketAmountIsNotValid},
+		{"validity ending before it starts", `{"code": "SAVE10", "name": "Save", "type": "percentage", "value": 10,
+			"validFrom": "2026-06-01T00:00:00Z", "validUntil": "2026-05-01T00:00:00Z"}`, constants.PromotionValidityIsNotValid},
+		{"negative usage limit", `{"code": "SAVE10", "name": "Save", "type": "percentage", "value": 10, "usageLimit": -1}`,
+			constants.PromotionUsageLimitIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockPromotionService := &mocks.MockPromotionService{}
+			controller := NewPromotionController(mockPromotionService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/promotions", bytes.NewBuffer([]byte(test.body)))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockPromotionService.AssertNumberOfCalls(t, "CreatePromotion", 0)
+		})
+	}
+}
+
+func TestGetPromotion_WhenPromotionDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockPromotionService := &mocks.MockPromotionService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.PromotionNotFound).
+		Build()
+	mockPromotionService.On("GetPromotion", mock.Anything, "NOPE10").Return(nil, &serviceErr)
+	controller := NewPromotionController(mockPromotionService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/promotions/NOPE10", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	assert.Equal(t, constants.PromotionNotFound, errResponse.Message)
+}
@@ -13,7 +13,6 @@ func NewSwaggerController() Controller {
 	return &SwaggerControllerImp{}
 }
 
-// @Param Authorization header string true "With the bearer started"
 func (controller *SwaggerControllerImp) Register(engine *gin.Engine) {
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/mitchellh/mapstructure"
+	"net/http"
+	"net/url"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+	"time"
+)
+
+const (
+	webhookTimeout      = 5 * time.Second
+	redeliveryTimeout   = 30 * time.Second
+	minWebhookSecretLen = 16
+)
+
+type WebhookController struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookController(
+	webhookService services.WebhookService,
+) Controller {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// @Tags WebhookController
+// @Description Get Webhooks
+// @Produce json
+// @Success 200 {object} []response.Webhook
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /webhooks [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (controller *WebhookController) GetWebhooks() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		ctx, cancel := requestContext(context, webhookTimeout)
+		defer cancel()
+
+		webhooks, errorResp := controller.webhookService.GetWebhooks(ctx)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, webhooks)
+	}
+}
+
+// @Tags WebhookController
+// @Description Create Webhook. Deliveries are signed with the secret, which is generated when not given and shown only once.
+// @Produce json
+// @Success 201 {object} response.CreatedWebhook
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /webhooks [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param request body request.CreateWebhookRequest true "Create Webhook Request"
+func (controller *WebhookController) CreateWebhook() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		var createWebhookRequest *request.CreateWebhookRequest
+		_ = mapstructure.Decode(getRequestBody(createWebhookRequest, context), &createWebhookRequest)
+
+		if createWebhookRequest == nil {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.CreateWebhookRequestIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if !isValidWebhookUrl(createWebhookRequest.Url) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookUrlIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if !areValidEventTypes(createWebhookRequest.EventTypes) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookEventTypesAreNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		if len(createWebhookRequest.Secret) > 0 && len(createWebhookRequest.Secret) < minWebhookSecretLen {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookSecretIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, webhookTimeout)
+		defer cancel()
+
+		webhook, createErr := controller.webhookService.CreateWebhook(ctx, *createWebhookRequest)
+		if createErr != nil {
+			context.JSON(createErr.StatusCode, createErr)
+			return
+		}
+
+		context.JSON(http.StatusCreated, webhook)
+	}
+}
+
+// @Tags WebhookController
+// @Description Delete Webhook
+// @Produce json
+// @Success 204
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /webhooks/{webhookId} [delete]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+func (controller *WebhookController) DeleteWebhook() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		webhookId, webhookIdErr := getStringParam(context, constants.WebhookId)
+		if !helpers.IsValidString(webhookId, webhookIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, webhookTimeout)
+		defer cancel()
+
+		deleteErr := controller.webhookService.DeleteWebhook(ctx, webhookId)
+		if deleteErr != nil {
+			context.JSON(deleteErr.StatusCode, deleteErr)
+			return
+		}
+
+		context.JSON(http.StatusNoContent, "")
+	}
+}
+
+// @Tags WebhookController
+// @Description Get Delivery Logs Of Webhook
+// @Produce json
+// @Success 200 {object} []response.WebhookDelivery
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /webhooks/{webhookId}/deliveries [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+func (controller *WebhookController) GetDeliveries() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		webhookId, webhookIdErr := getStringParam(context, constants.WebhookId)
+		if !helpers.IsValidString(webhookId, webhookIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, webhookTimeout)
+		defer cancel()
+
+		deliveries, errorResp := controller.webhookService.GetDeliveries(ctx, webhookId)
+		if errorResp != nil {
+			context.JSON(errorResp.StatusCode, errorResp)
+			return
+		}
+
+		context.JSON(http.StatusOK, deliveries)
+	}
+}
+
+// @Tags WebhookController
+// @Description Redeliver Webhook Delivery. The logged payload is sent once more and the updated delivery log is returned.
+// @Produce json
+// @Success 200 {object} response.WebhookDelivery
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 403 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Param webhookId path string true "webhookId"
+// @Param deliveryId path string true "deliveryId"
+func (controller *WebhookController) RedeliverDelivery() func(context *gin.Context) {
+	return func(context *gin.Context) {
+		webhookId, webhookIdErr := getStringParam(context, constants.WebhookId)
+		if !helpers.IsValidString(webhookId, webhookIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		deliveryId, deliveryIdErr := getStringParam(context, constants.DeliveryId)
+		if !helpers.IsValidString(deliveryId, deliveryIdErr) {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookDeliveryIdIsNotValid).
+				Build()
+			context.JSON(errorResponse.StatusCode, errorResponse)
+			return
+		}
+
+		ctx, cancel := requestContext(context, redeliveryTimeout)
+		defer cancel()
+
+		delivery, redeliverErr := controller.webhookService.RedeliverDelivery(ctx, webhookId, deliveryId)
+		if redeliverErr != nil {
+			context.JSON(redeliverErr.StatusCode, redeliverErr)
+			return
+		}
+
+		context.JSON(http.StatusOK, delivery)
+	}
+}
+
+func (controller *WebhookController) Register(engine *gin.Engine) {
+	webhooks := engine.Group("/webhooks", middlewares.RequirePermission(policies.ManageWebhooks))
+	webhooks.GET("", controller.GetWebhooks())
+	webhooks.POST("", controller.CreateWebhook())
+	webhooks.DELETE("/:webhookId", controller.DeleteWebhook())
+	webhooks.GET("/:webhookId/deliveries", controller.GetDeliveries())
+	webhooks.POST("/:webhookId/deliveries/:deliveryId/redeliver", controller.RedeliverDelivery())
+}
+
+func isValidWebhookUrl(rawUrl string) bool {
+	webhookUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return false
+	}
+
+	return (webhookUrl.Scheme == "http" || webhookUrl.Scheme == "https") && len(webhookUrl.Host) > 0
+}
+
+func areValidEventTypes(eventTypes []string) bool {
+	if len(eventTypes) == 0 {
+		return false
+	}
+
+	for _, eventType := range eventTypes {
+		if !enum.IsValidEventType(eventType) {
+			return false
+		}
+	}
+
+	return true
+}
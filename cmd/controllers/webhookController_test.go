@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockWebhookService := &mocks.MockWebhookService{}
+	createdWebhook := response.CreatedWebhook{
+		Webhook: response.Webhook{Id: "webhook-1", Url: "https://partner.example.com/orders", EventTypes: []string{"order.status.changed"}},
+		Secret:  "whsec_secret",
+	}
+	mockWebhookService.On("CreateWebhook", mock.Anything, mock.Anything).Return(&createdWebhook, nil)
+	controller := NewWebhookController(mockWebhookService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	body := `{"url": "https://partner.example.com/orders", "eventTypes": ["order.status.changed"]}`
+	req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBuffer([]byte(body)))
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusCreated, w.Code)
+	resp := response.CreatedWebhook{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "whsec_secret", resp.Secret)
+	mockWebhookService.AssertCalled(t, "CreateWebhook", mock.Anything, request.CreateWebhookRequest{
+		Url:        "https://partner.example.com/orders",
+		EventTypes: []string{"order.status.changed"},
+	})
+}
+
+func TestCreateWebhook_WhenRequestIsInvalid_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedMessage string
+	}{
+		{"missing url", `{"eventTypes": ["order.created"]}`, constants.WebhookUrlIsNotValid},
+		{"relative url", `{"url": "/orders", "eventTypes": ["order.created"]}`, constants.WebhookUrlIsNotValid},
+		{"unsupported scheme", `{"url": "ftp://partner.example.com", "eventTypes": ["order.created"]}`, constants.WebhookUrlIsNotValid},
+		{"missing event types", `{"url": "https://partner.example.com"}`, constants.WebhookEventTypesAreNotValid},
+		{"unknown event type", `{"url": "https://partner.example.com", "eventTypes": ["order.shipped"]}`, constants.WebhookEventTypesAreNotValid},
+		{"short secret", `{"url": "https://partner.example.com", "eventTypes": ["order.created"], "secret": "short"}`, constants.WebhookSecretIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := gin.New()
+			mockWebhookService := &mocks.MockWebhookService{}
+			controller := NewWebhookController(mockWebhookService)
+			controller.Register(engine)
+			w := httptest.NewRecorder()
+
+			//When
+			req, _ := http.NewRequest("POST", "/webhooks", bytes.NewBuffer([]byte(test.body)))
+			engine.ServeHTTP(w, req)
+
+			//Then
+			errResponse := response.ErrorResponse{}
+			_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, test.expectedMessage, errResponse.Message)
+			mockWebhookService.AssertNumberOfCalls(t, "CreateWebhook", 0)
+		})
+	}
+}
+
+func TestRedeliverDelivery(t *testing.T) {
+	//Given
+	engine := gin.New()
+	mockWebhookService := &mocks.MockWebhookService{}
+	delivery := response.WebhookDelivery{
+		Id:        "delivery-1",
+		WebhookId: "webhook-1",
+		Payload:   json.RawMessage(`{"orderNumber":"1"}`),
+		Status:    "succeeded",
+		Attempts:  2,
+	}
+	mockWebhookService.On("RedeliverDelivery", mock.Anything, mock.Anything, mock.Anything).Return(&delivery, nil)
+	controller := NewWebhookController(mockWebhookService)
+	controller.Register(engine)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("POST", "/webhooks/webhook-1/deliveries/delivery-1/redeliver", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	resp := response.WebhookDelivery{}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, delivery, resp)
+	mockWebhookService.AssertCalled(t, "RedeliverDelivery", mock.Anything, "webhook-1", "delivery-1")
+}
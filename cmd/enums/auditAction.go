@@ -0,0 +1,10 @@
+package enum
+
+type AuditAction string
+
+var (
+	CreateAction     AuditAction = "create"
+	UpdateAction     AuditAction = "update"
+	DeleteAction     AuditAction = "delete"
+	TransitionAction AuditAction = "transition"
+)
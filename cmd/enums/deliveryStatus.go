@@ -0,0 +1,9 @@
+package enum
+
+type DeliveryStatus string
+
+var (
+	PendingDeliveryStatus   DeliveryStatus = "pending"
+	SucceededDeliveryStatus DeliveryStatus = "succeeded"
+	FailedDeliveryStatus    DeliveryStatus = "failed"
+)
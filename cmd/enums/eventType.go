@@ -0,0 +1,18 @@
+package enum
+
+type EventType string
+
+var (
+	OrderCreated       EventType = "order.created"
+	OrderUpdated       EventType = "order.updated"
+	OrderStatusChanged EventType = "order.status.changed"
+	OrderDeleted       EventType = "order.deleted"
+)
+
+func IsValidEventType(eventType string) bool {
+	switch EventType(eventType) {
+	case OrderCreated, OrderUpdated, OrderStatusChanged, OrderDeleted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package enum
+
+type ImportStatus string
+
+var (
+	RunningImportStatus   ImportStatus = "running"
+	CompletedImportStatus ImportStatus = "completed"
+	CancelledImportStatus ImportStatus = "cancelled"
+)
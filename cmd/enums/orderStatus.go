@@ -9,3 +9,12 @@ var (
 	Shipped     OrderStatus = 4
 	Delivered   OrderStatus = 5
 )
+
+func (status OrderStatus) IsValid() bool {
+	return status >= Created && status <= Delivered
+}
+
+// CanTransitionTo allows an order to move only one step forward in its lifecycle.
+func (status OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return next.IsValid() && next == status+1
+}
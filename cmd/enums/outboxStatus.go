@@ -0,0 +1,9 @@
+package enum
+
+type OutboxStatus string
+
+var (
+	PendingOutboxStatus      OutboxStatus = "pending"
+	PublishedOutboxStatus    OutboxStatus = "published"
+	DeadLetteredOutboxStatus OutboxStatus = "dead-lettered"
+)
@@ -0,0 +1,12 @@
+package enum
+
+type PromotionType string
+
+var (
+	PercentagePromotion PromotionType = "percentage"
+	FixedPromotion      PromotionType = "fixed"
+)
+
+func (promotionType PromotionType) IsValid() bool {
+	return promotionType == PercentagePromotion || promotionType == FixedPromotion
+}
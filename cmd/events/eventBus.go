@@ -0,0 +1,176 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"simple-order-api/cmd/constants"
+	"strings"
+	"sync"
+)
+
+var tracer = otel.Tracer("simple-order-api/cmd/events")
+
+const asyncQueueSize = 256
+
+// ErrEventBusClosed is returned when an event is published after Close.
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+// EventHandler reacts to an order event. Events are delivered at least once, so a
+// handler may see the same event Id again after a failed publish is retried.
+type EventHandler func(ctx context.Context, event OrderEvent) error
+
+//go:generate mockery --name=EventBus --structname=MockEventBus --output=../mocks --filename=fakeEventBusWithMockery.go
+type EventBus interface {
+	// Publish returns the errors of synchronous handlers; errors of async handlers are
+	// only recorded on their spans.
+	Publish(ctx context.Context, event OrderEvent) error
+	// Subscribe registers a handler that runs within Publish, in subscription order.
+	Subscribe(handler EventHandler)
+	// SubscribeAsync registers a handler that runs on its own goroutine and receives
+	// events in publishing order.
+	SubscribeAsync(handler EventHandler)
+	// Close stops accepting events and waits for async handlers to drain their queues.
+	Close()
+}
+
+type asyncSubscriber struct {
+	handler EventHandler
+	queue   chan asyncEvent
+}
+
+type asyncEvent struct {
+	spanContext trace.SpanContext
+	event       OrderEvent
+}
+
+// InProcessEventBus holds its lock only to read or change the subscribers, so a
+// publisher waiting on a full async queue blocks neither other publishers nor Close.
+type InProcessEventBus struct {
+	mutex            sync.RWMutex
+	closed           bool
+	done             chan struct{}
+	subscribers      []EventHandler
+	asyncSubscribers []*asyncSubscriber
+	publishing       sync.WaitGroup
+	waitGroup        sync.WaitGroup
+}
+
+func (b *InProcessEventBus) Publish(ctx context.Context, event OrderEvent) (err error) {
+	ctx, span := tracer.Start(ctx, "EventBus.Publish")
+	span.SetAttributes(
+		attribute.String(constants.OrderNumber, event.OrderNumber),
+		attribute.String(constants.EventType, string(event.Type)),
+	)
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	b.mutex.RLock()
+	if b.closed {
+		b.mutex.RUnlock()
+		return ErrEventBusClosed
+	}
+	subscribers, asyncSubscribers := b.subscribers, b.asyncSubscribers
+	// Close waits for running publishers before it closes the queues they send to.
+	b.publishing.Add(1)
+	defer b.publishing.Done()
+	b.mutex.RUnlock()
+
+	failures := make([]string, 0)
+	for _, handler := range subscribers {
+		if err := handle(ctx, handler, event); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d handlers failed: %s", len(failures), len(subscribers), strings.Join(failures, "; "))
+	}
+
+	for _, subscriber := range asyncSubscribers {
+		select {
+		case subscriber.queue <- asyncEvent{spanContext: span.SpanContext(), event: event}:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.done:
+			return ErrEventBusClosed
+		}
+	}
+	return nil
+}
+
+func (b *InProcessEventBus) Subscribe(handler EventHandler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.subscribers = append(b.subscribers, handler)
+}
+
+func (b *InProcessEventBus) SubscribeAsync(handler EventHandler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return
+	}
+
+	subscriber := &asyncSubscriber{handler: handler, queue: make(chan asyncEvent, asyncQueueSize)}
+	b.asyncSubscribers = append(b.asyncSubscribers, subscriber)
+	b.waitGroup.Add(1)
+	go func() {
+		defer b.waitGroup.Done()
+		for queued := range subscriber.queue {
+			// The request that published the event may be gone already, so only its
+			// trace is carried over.
+			// Failures are recorded on the handler span, nobody is waiting for them.
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), queued.spanContext)
+			_ = handle(ctx, subscriber.handler, queued.event)
+		}
+	}()
+}
+
+func (b *InProcessEventBus) Close() {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		b.waitGroup.Wait()
+		return
+	}
+	b.closed = true
+	close(b.done)
+	b.mutex.Unlock()
+
+	// publishers blocked on a full queue give up once done is closed
+	b.publishing.Wait()
+	for _, subscriber := range b.asyncSubscribers {
+		close(subscriber.queue)
+	}
+	b.waitGroup.Wait()
+}
+
+// handle runs the handler, turning a panic into an error.
+func handle(ctx context.Context, handler EventHandler, event OrderEvent) (err error) {
+	ctx, span := tracer.Start(ctx, "EventBus.Handle")
+	span.SetAttributes(attribute.String(constants.EventType, string(event.Type)))
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return handler(ctx, event)
+}
+
+func NewEventBus() EventBus {
+	return &InProcessEventBus{done: make(chan struct{})}
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPublish_RunsSyncSubscribersInOrderAndReturnsTheirErrors(t *testing.T) {
+	//Given
+	eventBus := NewEventBus()
+	defer eventBus.Close()
+	var calls []string
+	eventBus.Subscribe(func(ctx context.Context, event OrderEvent) error {
+		calls = append(calls, "first")
+		return errors.New("failed")
+	})
+	eventBus.Subscribe(func(ctx context.Context, event OrderEvent) error {
+		panic("broken subscriber")
+	})
+	eventBus.Subscribe(func(ctx context.Context, event OrderEvent) error {
+		calls = append(calls, "third:"+event.OrderNumber)
+		return nil
+	})
+
+	//When
+	err := eventBus.Publish(context.Background(), NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+
+	//Then
+	assert.Equal(t, []string{"first", "third:1"}, calls)
+	assert.EqualError(t, err, "2 of 3 handlers failed: failed; handler panicked: broken subscriber")
+}
+
+func TestPublish_DeliversEventsToAsyncSubscribersInPublishingOrder(t *testing.T) {
+	//Given
+	eventBus := NewEventBus()
+	var mutex sync.Mutex
+	var orderNumbers []string
+	eventBus.SubscribeAsync(func(ctx context.Context, event OrderEvent) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		orderNumbers = append(orderNumbers, event.OrderNumber)
+		return nil
+	})
+
+	//When
+	for _, orderNumber := range []string{"1", "2", "3"} {
+		_ = eventBus.Publish(context.Background(), NewOrderEvent(enum.OrderUpdated, response.Order{OrderNumber: orderNumber}))
+	}
+	eventBus.Close()
+
+	//Then
+	assert.Equal(t, []string{"1", "2", "3"}, orderNumbers)
+}
+
+func TestPublish_WhenBusIsClosed_ReturnsError(t *testing.T) {
+	//Given
+	eventBus := NewEventBus()
+	called := false
+	eventBus.Subscribe(func(ctx context.Context, event OrderEvent) error {
+		called = true
+		return nil
+	})
+	eventBus.Close()
+
+	//When
+	err := eventBus.Publish(context.Background(), NewOrderEvent(enum.OrderDeleted, response.Order{OrderNumber: "1"}))
+
+	//Then
+	assert.Equal(t, ErrEventBusClosed, err)
+	assert.False(t, called)
+}
+
+func TestClose_WhenAsyncQueueIsFull_ReleasesBlockedPublisher(t *testing.T) {
+	//Given
+	eventBus := NewEventBus()
+	release := make(chan struct{})
+	eventBus.SubscribeAsync(func(ctx context.Context, event OrderEvent) error {
+		<-release
+		return nil
+	})
+	published := make(chan error, 1)
+	go func() {
+		var err error
+		for i := 0; i < asyncQueueSize+2 && err == nil; i++ {
+			err = eventBus.Publish(context.Background(), NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+		}
+		published <- err
+	}()
+	queue := eventBus.(*InProcessEventBus).asyncSubscribers[0].queue
+	assert.Eventually(t, func() bool { return len(queue) == asyncQueueSize }, time.Second, time.Millisecond)
+
+	//When
+	eventBus.Subscribe(func(ctx context.Context, event OrderEvent) error { return nil })
+	closed := make(chan struct{})
+	go func() {
+		eventBus.Close()
+		close(closed)
+	}()
+
+	//Then
+	assert.Equal(t, ErrEventBusClosed, <-published)
+	close(release)
+	<-closed
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// SequencedEvent is an order event numbered in the order the stream received it. The
+// sequence is what SSE clients send back as Last-Event-ID.
+type SequencedEvent struct {
+	Sequence int64
+	Event    OrderEvent
+}
+
+// OrderEventFilter selects events by order number, the order's status after the event
+// and the owning customer. Empty fields match every event.
+type OrderEventFilter struct {
+	OrderNumbers []string
+	StatusIds    []int
+	CustomerId   string
+}
+
+func (f OrderEventFilter) Matches(event OrderEvent) bool {
+	if len(f.CustomerId) > 0 && event.Order.CustomerId != f.CustomerId {
+		return false
+	}
+
+	if len(f.OrderNumbers) > 0 && !containsString(f.OrderNumbers, event.OrderNumber) {
+		return false
+	}
+
+	return len(f.StatusIds) == 0 || containsInt(f.StatusIds, event.Order.StatusId)
+}
+
+// EventStream fans order events out to live subscribers and keeps the latest ones so
+// that reconnecting clients can catch up.
+type EventStream interface {
+	// Subscribe returns the buffered events after lastSequence that match the filter and a
+	// channel receiving the following ones. The channel is closed when the subscriber
+	// falls behind by more than its buffer; the caller then reconnects with the last
+	// sequence it handled. unsubscribe must be called once the caller stops reading.
+	Subscribe(lastSequence int64, filter OrderEventFilter) (replay []SequencedEvent, updates <-chan SequencedEvent, unsubscribe func())
+	// HandleEvent never blocks, so it is subscribed to the event bus synchronously.
+	// Events are delivered at least once, so an event whose Id is still in the replay
+	// buffer is dropped instead of being sent again.
+	HandleEvent(ctx context.Context, event OrderEvent) error
+}
+
+type streamSubscriber struct {
+	filter  OrderEventFilter
+	updates chan SequencedEvent
+}
+
+type InMemoryEventStream struct {
+	mutex            sync.Mutex
+	sequence         int64
+	replayBuffer     []SequencedEvent
+	bufferedEventIds map[string]struct{}
+	replayBufferSize int
+	clientBufferSize int
+	subscribers      map[*streamSubscriber]struct{}
+}
+
+func (s *InMemoryEventStream) Subscribe(lastSequence int64, filter OrderEventFilter) ([]SequencedEvent, <-chan SequencedEvent, func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	replay := make([]SequencedEvent, 0)
+	if lastSequence > 0 {
+		for _, sequencedEvent := range s.replayBuffer {
+			if sequencedEvent.Sequence > lastSequence && filter.Matches(sequencedEvent.Event) {
+				replay = append(replay, sequencedEvent)
+			}
+		}
+	}
+
+	subscriber := &streamSubscriber{filter: filter, updates: make(chan SequencedEvent, s.clientBufferSize)}
+	s.subscribers[subscriber] = struct{}{}
+	return replay, subscriber.updates, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.removeSubscriber(subscriber)
+	}
+}
+
+func (s *InMemoryEventStream) HandleEvent(_ context.Context, event OrderEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.bufferedEventIds[event.Id]; ok {
+		return nil
+	}
+
+	s.sequence++
+	sequencedEvent := SequencedEvent{Sequence: s.sequence, Event: event}
+	s.replayBuffer = append(s.replayBuffer, sequencedEvent)
+	s.bufferedEventIds[event.Id] = struct{}{}
+	if len(s.replayBuffer) > s.replayBufferSize {
+		dropped := len(s.replayBuffer) - s.replayBufferSize
+		for _, droppedEvent := range s.replayBuffer[:dropped] {
+			delete(s.bufferedEventIds, droppedEvent.Event.Id)
+		}
+		s.replayBuffer = append([]SequencedEvent(nil), s.replayBuffer[dropped:]...)
+	}
+
+	for subscriber := range s.subscribers {
+		if !subscriber.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case subscriber.updates <- sequencedEvent:
+		default:
+			s.removeSubscriber(subscriber)
+		}
+	}
+	return nil
+}
+
+func (s *InMemoryEventStream) removeSubscriber(subscriber *streamSubscriber) {
+	if _, ok := s.subscribers[subscriber]; ok {
+		delete(s.subscribers, subscriber)
+		close(subscriber.updates)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func NewEventStream(replayBufferSize int, clientBufferSize int) EventStream {
+	return &InMemoryEventStream{
+		replayBufferSize: replayBufferSize,
+		clientBufferSize: clientBufferSize,
+		bufferedEventIds: map[string]struct{}{},
+		subscribers:      map[*streamSubscriber]struct{}{},
+	}
+}
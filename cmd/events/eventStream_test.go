@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"testing"
+)
+
+func newStreamEvent(orderNumber string, status enum.OrderStatus) OrderEvent {
+	return NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: orderNumber, StatusId: int(status)})
+}
+
+func TestSubscribe_ReplaysBufferedEventsAfterLastSequenceThatMatchFilter(t *testing.T) {
+	//Given
+	eventStream := NewEventStream(3, 10)
+	for _, event := range []OrderEvent{
+		newStreamEvent("1", enum.Approved),
+		newStreamEvent("2", enum.Approved),
+		newStreamEvent("1", enum.Transferred),
+		newStreamEvent("2", enum.Transferred),
+		newStreamEvent("1", enum.Shipped),
+	} {
+		_ = eventStream.HandleEvent(context.Background(), event)
+	}
+
+	//When
+	replay, _, unsubscribe := eventStream.Subscribe(1, OrderEventFilter{OrderNumbers: []string{"1"}})
+	defer unsubscribe()
+
+	//Then
+	sequences := make([]int64, 0)
+	for _, sequencedEvent := range replay {
+		sequences = append(sequences, sequencedEvent.Sequence)
+	}
+	assert.Equal(t, []int64{3, 5}, sequences)
+}
+
+func TestHandleEvent_DeliversMatchingEventsToSubscribers(t *testing.T) {
+	//Given
+	eventStream := NewEventStream(10, 10)
+	replay, updates, unsubscribe := eventStream.Subscribe(0, OrderEventFilter{StatusIds: []int{int(enum.Shipped)}})
+	defer unsubscribe()
+
+	//When
+	_ = eventStream.HandleEvent(context.Background(), newStreamEvent("1", enum.Transferred))
+	_ = eventStream.HandleEvent(context.Background(), newStreamEvent("2", enum.Shipped))
+
+	//Then
+	assert.Empty(t, replay)
+	sequencedEvent := <-updates
+	assert.Equal(t, int64(2), sequencedEvent.Sequence)
+	assert.Equal(t, "2", sequencedEvent.Event.OrderNumber)
+	assert.Empty(t, updates)
+}
+
+func TestHandleEvent_WhenEventIsRedelivered_SendsItOnce(t *testing.T) {
+	//Given
+	eventStream := NewEventStream(10, 10)
+	_, updates, unsubscribe := eventStream.Subscribe(0, OrderEventFilter{})
+	defer unsubscribe()
+	event := newStreamEvent("1", enum.Shipped)
+
+	//When
+	_ = eventStream.HandleEvent(context.Background(), event)
+	_ = eventStream.HandleEvent(context.Background(), event)
+
+	//Then
+	sequencedEvent := <-updates
+	assert.Equal(t, event.Id, sequencedEvent.Event.Id)
+	assert.Empty(t, updates)
+}
+
+func TestHandleEvent_WhenSubscriberFallsBehind_ClosesItsChannel(t *testing.T) {
+	//Given
+	eventStream := NewEventStream(10, 1)
+	_, updates, unsubscribe := eventStream.Subscribe(0, OrderEventFilter{})
+	defer unsubscribe()
+
+	//When
+	_ = eventStream.HandleEvent(context.Background(), newStreamEvent("1", enum.Approved))
+	_ = eventStream.HandleEvent(context.Background(), newStreamEvent("1", enum.Transferred))
+
+	//Then
+	_, open := <-updates
+	assert.True(t, open)
+	_, open = <-updates
+	assert.False(t, open)
+}
+
+func TestOrderEventFilter_WhenCustomerIsSet_MatchesOnlyTheirOrders(t *testing.T) {
+	//Given
+	filter := OrderEventFilter{CustomerId: "customer-1"}
+
+	//When
+	ownOrder := filter.Matches(NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1", CustomerId: "customer-1"}))
+	otherOrder := filter.Matches(NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "2", CustomerId: "customer-2"}))
+
+	//Then
+	assert.True(t, ownOrder)
+	assert.False(t, otherOrder)
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"time"
+)
+
+// OrderEvent is published after an order change has been stored. Order holds the
+// state after the change, or the last known state for a deletion.
+type OrderEvent struct {
+	Id               string         `json:"id"`
+	Type             enum.EventType `json:"type"`
+	OrderNumber      string         `json:"orderNumber"`
+	Order            response.Order `json:"order"`
+	PreviousStatusId int            `json:"previousStatusId,omitempty"`
+	Actor            string         `json:"actor"`
+	RequestId        string         `json:"requestId"`
+	OccurredAt       time.Time      `json:"occurredAt"`
+}
+
+func NewOrderEvent(eventType enum.EventType, order response.Order) OrderEvent {
+	return OrderEvent{
+		Id:          newEventId(),
+		Type:        eventType,
+		OrderNumber: order.OrderNumber,
+		Order:       order,
+		OccurredAt:  time.Now().UTC(),
+	}
+}
+
+func newEventId() string {
+	randomBytes := make([]byte, 16)
+	_, _ = rand.Read(randomBytes)
+	return hex.EncodeToString(randomBytes)
+}
@@ -0,0 +1,30 @@
+package events
+
+import (
+	enum "simple-order-api/cmd/enums"
+	"time"
+)
+
+// OutboxMessage is an order event waiting in the outbox to be published. Messages of
+// the same order are published in Id order.
+type OutboxMessage struct {
+	Id             int64             `json:"id"`
+	Event          OrderEvent        `json:"event"`
+	Status         enum.OutboxStatus `json:"status"`
+	Attempts       int               `json:"attempts"`
+	LastError      string            `json:"lastError,omitempty"`
+	NextAttemptAt  time.Time         `json:"nextAttemptAt"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	PublishedAt    *time.Time        `json:"publishedAt,omitempty"`
+	DeadLetteredAt *time.Time        `json:"deadLetteredAt,omitempty"`
+}
+
+func NewOutboxMessage(event OrderEvent) OutboxMessage {
+	now := time.Now().UTC()
+	return OutboxMessage{
+		Event:         event,
+		Status:        enum.PendingOutboxStatus,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
@@ -0,0 +1,70 @@
+package exports
+
+import (
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// Column is an order field written to an export, in the order the columns are requested.
+type Column struct {
+	Key    string
+	Header string
+	// Amount columns are written as locale formatted numbers instead of text.
+	Amount bool
+	value  func(order response.Order) interface{}
+}
+
+var columns = []Column{
+	{Key: "orderNumber", Header: "Order Number", value: func(order response.Order) interface{} { return order.OrderNumber }},
+	{Key: "firstName", Header: "First Name", value: func(order response.Order) interface{} { return order.FirstName }},
+	{Key: "lastName", Header: "Last Name", value: func(order response.Order) interface{} { return order.LastName }},
+	{Key: "totalAmount", Header: "Total Amount", Amount: true, value: func(order response.Order) interface{} { return order.TotalAmount }},
+	{Key: "currencyCode", Header: "Currency", value: func(order response.Order) interface{} { return order.CurrencyCode }},
+	{Key: "address", Header: "Address", value: func(order response.Order) interface{} { return order.Address }},
+	{Key: "city", Header: "City", value: func(order response.Order) interface{} { return order.City }},
+	{Key: "district", Header: "District", value: func(order response.Order) interface{} { return order.District }},
+	{Key: "statusId", Header: "Status", value: func(order response.Order) interface{} { return order.StatusId }},
+	{Key: "customerId", Header: "Customer Id", value: func(order response.Order) interface{} { return order.CustomerId }},
+}
+
+// ColumnKeys lists every exportable column in its default order.
+func ColumnKeys() []string {
+	keys := make([]string, 0, len(columns))
+	for _, column := range columns {
+		keys = append(keys, column.Key)
+	}
+	return keys
+}
+
+// ParseColumns resolves the requested column keys; unknown and repeated keys are rejected.
+func ParseColumns(keys []string) ([]Column, bool) {
+	selectedColumns := make([]Column, 0, len(keys))
+	selectedKeys := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		column, ok := findColumn(strings.TrimSpace(key))
+		if !ok {
+			return nil, false
+		}
+
+		if _, ok = selectedKeys[column.Key]; ok {
+			return nil, false
+		}
+		selectedKeys[column.Key] = struct{}{}
+		selectedColumns = append(selectedColumns, column)
+	}
+
+	return selectedColumns, len(selectedColumns) > 0
+}
+
+func findColumn(key string) (Column, bool) {
+	for _, column := range columns {
+		if strings.EqualFold(column.Key, key) {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+func (column Column) Value(order response.Order) interface{} {
+	return column.value(order)
+}
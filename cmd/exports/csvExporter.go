@@ -0,0 +1,97 @@
+package exports
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"io"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// utf8Bom makes spreadsheet applications read non-ASCII text as UTF-8.
+const utf8Bom = "\uFEFF"
+
+type CsvExporter struct {
+	printer   *message.Printer
+	delimiter rune
+}
+
+func NewCsvExporter(locale language.Tag) Exporter {
+	printer := message.NewPrinter(locale)
+	delimiter := ','
+	// locales writing decimals with a comma expect semicolon separated files
+	if strings.Contains(printer.Sprintf("%.1f", 0.5), ",") {
+		delimiter = ';'
+	}
+
+	return &CsvExporter{
+		printer:   printer,
+		delimiter: delimiter,
+	}
+}
+
+func (exporter *CsvExporter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (exporter *CsvExporter) FileExtension() string {
+	return CsvFormat
+}
+
+// Export quotes fields containing delimiters, quotes or line breaks and writes each row
+// as its order is read, so that large exports are streamed to the writer. Nothing
+// reaches the writer before the first rows fill its buffer.
+func (exporter *CsvExporter) Export(writer io.Writer, columns []Column, orders OrderSource) error {
+	bufferedWriter := bufio.NewWriter(writer)
+	if _, err := bufferedWriter.WriteString(utf8Bom); err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(bufferedWriter)
+	csvWriter.Comma = exporter.delimiter
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.Header
+	}
+	if err := csvWriter.Write(record); err != nil {
+		return err
+	}
+
+	var writeErr error
+	err := orders(func(order response.Order) bool {
+		for i, column := range columns {
+			record[i] = exporter.format(column, order)
+		}
+		writeErr = csvWriter.Write(record)
+		return writeErr == nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func (exporter *CsvExporter) format(column Column, order response.Order) string {
+	value := column.Value(order)
+	if column.Amount {
+		return exporter.printer.Sprintf("%.2f", value)
+	}
+	return escapeFormula(fmt.Sprint(value))
+}
+
+// escapeFormula keeps spreadsheet applications from evaluating text starting like a
+// formula, e.g. an address entered as "=HYPERLINK(...)".
+func escapeFormula(value string) string {
+	if len(value) > 0 && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
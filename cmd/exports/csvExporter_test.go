@@ -0,0 +1,99 @@
+package exports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+func getExportOrders() []response.Order {
+	return []response.Order{
+		{OrderNumber: "1", TotalAmount: 1234.5, Address: "Atatürk Cd. No: 5, Daire \"3\"", City: "İstanbul", StatusId: 2},
+		{OrderNumber: "2", TotalAmount: 10, Address: "=HYPERLINK(\"http://example.com\")", City: "Ankara", StatusId: 1},
+	}
+}
+
+func orderSource(orders []response.Order) OrderSource {
+	return func(visit func(order response.Order) bool) error {
+		for _, order := range orders {
+			if !visit(order) {
+				break
+			}
+		}
+		return nil
+	}
+}
+
+func TestCsvExport_QuotesAddressesAndKeepsNonAsciiText(t *testing.T) {
+	//Given
+	exporter := NewCsvExporter(language.AmericanEnglish)
+	columns, _ := ParseColumns([]string{"orderNumber", "address", "city", "totalAmount"})
+	buffer := &bytes.Buffer{}
+
+	//When
+	err := exporter.Export(buffer, columns, orderSource(getExportOrders()))
+
+	//Then
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buffer.String(), utf8Bom))
+	assert.Contains(t, buffer.String(), `"Atatürk Cd. No: 5, Daire ""3"""`)
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buffer.String(), utf8Bom))).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Order Number", "Address", "City", "Total Amount"},
+		{"1", "Atatürk Cd. No: 5, Daire \"3\"", "İstanbul", "1,234.50"},
+		{"2", "'=HYPERLINK(\"http://example.com\")", "Ankara", "10.00"},
+	}, records)
+}
+
+func TestCsvExport_WhenLocaleUsesDecimalComma_FormatsAmountsAndSeparatesWithSemicolon(t *testing.T) {
+	//Given
+	exporter := NewCsvExporter(language.Turkish)
+	columns, _ := ParseColumns([]string{"orderNumber", "totalAmount"})
+	buffer := &bytes.Buffer{}
+
+	//When
+	err := exporter.Export(buffer, columns, orderSource(getExportOrders()[:1]))
+
+	//Then
+	require.NoError(t, err)
+	assert.Equal(t, utf8Bom+"Order Number;Total Amount\n1;1.234,50\n", buffer.String())
+}
+
+func TestCsvExport_WhenSourceFails_ReturnsErrorWithoutWriting(t *testing.T) {
+	//Given
+	exporter := NewCsvExporter(language.AmericanEnglish)
+	columns, _ := ParseColumns([]string{"orderNumber"})
+	buffer := &bytes.Buffer{}
+	sourceErr := errors.New("orders can not be read")
+
+	//When
+	err := exporter.Export(buffer, columns, func(visit func(order response.Order) bool) error {
+		visit(response.Order{OrderNumber: "1"})
+		return sourceErr
+	})
+
+	//Then
+	assert.Equal(t, sourceErr, err)
+	assert.Empty(t, buffer.String())
+}
+
+func TestParseColumns_WhenKeyIsUnknownOrRepeated_ReturnsFalse(t *testing.T) {
+	_, unknownOk := ParseColumns([]string{"orderNumber", "password"})
+	_, repeatedOk := ParseColumns([]string{"city", "City"})
+	_, emptyOk := ParseColumns([]string{})
+	columns, ok := ParseColumns([]string{" city ", "orderNumber"})
+
+	assert.False(t, unknownOk)
+	assert.False(t, repeatedOk)
+	assert.False(t, emptyOk)
+	assert.True(t, ok)
+	assert.Equal(t, "city", columns[0].Key)
+	assert.Equal(t, "orderNumber", columns[1].Key)
+}
@@ -0,0 +1,36 @@
+package exports
+
+import (
+	"golang.org/x/text/language"
+	"io"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+const (
+	CsvFormat  = "csv"
+	XlsxFormat = "xlsx"
+)
+
+// OrderSource calls visit with every exported order, one at a time, until visit returns
+// false.
+type OrderSource func(visit func(order response.Order) bool) error
+
+// Exporter writes orders as a spreadsheet document to the writer.
+type Exporter interface {
+	ContentType() string
+	FileExtension() string
+	Export(writer io.Writer, columns []Column, orders OrderSource) error
+}
+
+// NewExporter returns the exporter of the format whose numbers follow the locale.
+func NewExporter(format string, locale language.Tag) (Exporter, bool) {
+	switch strings.ToLower(format) {
+	case CsvFormat:
+		return NewCsvExporter(locale), true
+	case XlsxFormat:
+		return NewXlsxExporter(), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,89 @@
+package exports
+
+import (
+	"github.com/xuri/excelize/v2"
+	"io"
+	"simple-order-api/cmd/models/response"
+)
+
+const (
+	ordersSheet = "Orders"
+	// amountFormat is the built-in "#,##0.00" format which spreadsheet applications
+	// display with the reader's locale separators.
+	amountFormat = 4
+)
+
+type XlsxExporter struct {
+}
+
+func NewXlsxExporter() Exporter {
+	return &XlsxExporter{}
+}
+
+func (exporter *XlsxExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (exporter *XlsxExporter) FileExtension() string {
+	return XlsxFormat
+}
+
+// Export writes the rows through a stream writer so that the sheet is not kept in
+// memory cell by cell. The workbook is still assembled in memory before its first byte
+// is written, so large exports should use csv. Amounts are numeric cells; text cells
+// are never evaluated as formulas.
+func (exporter *XlsxExporter) Export(writer io.Writer, columns []Column, orders OrderSource) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), ordersSheet); err != nil {
+		return err
+	}
+	streamWriter, err := file.NewStreamWriter(ordersSheet)
+	if err != nil {
+		return err
+	}
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	amountStyle, err := file.NewStyle(&excelize.Style{NumFmt: amountFormat})
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(columns))
+	for i, column := range columns {
+		row[i] = excelize.Cell{StyleID: headerStyle, Value: column.Header}
+	}
+	if err = streamWriter.SetRow("A1", row); err != nil {
+		return err
+	}
+
+	rowIndex := 2
+	var writeErr error
+	err = orders(func(order response.Order) bool {
+		for i, column := range columns {
+			if column.Amount {
+				row[i] = excelize.Cell{StyleID: amountStyle, Value: column.Value(order)}
+			} else {
+				row[i] = column.Value(order)
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowIndex)
+		rowIndex++
+		writeErr = streamWriter.SetRow(cell, row)
+		return writeErr == nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = streamWriter.Flush(); err != nil {
+		return err
+	}
+	return file.Write(writer)
+}
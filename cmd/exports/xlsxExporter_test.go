@@ -0,0 +1,36 @@
+package exports
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+	"testing"
+)
+
+func TestXlsxExport_WritesTextAndNumericAmountCells(t *testing.T) {
+	//Given
+	exporter := NewXlsxExporter()
+	columns, _ := ParseColumns([]string{"orderNumber", "address", "totalAmount"})
+	buffer := &bytes.Buffer{}
+
+	//When
+	err := exporter.Export(buffer, columns, orderSource(getExportOrders()))
+
+	//Then
+	require.NoError(t, err)
+	file, err := excelize.OpenReader(buffer)
+	require.NoError(t, err)
+	rows, err := file.GetRows(ordersSheet, excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Order Number", "Address", "Total Amount"}, rows[0])
+	assert.Equal(t, []string{"1", "Atatürk Cd. No: 5, Daire \"3\"", "1234.5"}, rows[1])
+	assert.Equal(t, "=HYPERLINK(\"http://example.com\")", rows[2][1])
+	formula, _ := file.GetCellFormula(ordersSheet, "B3")
+	assert.Empty(t, formula)
+	// numeric cells are written without a type attribute, text cells as inline strings
+	amountType, _ := file.GetCellType(ordersSheet, "C2")
+	addressType, _ := file.GetCellType(ordersSheet, "B2")
+	assert.Equal(t, excelize.CellTypeUnset, amountType)
+	assert.Equal(t, excelize.CellTypeInlineString, addressType)
+}
@@ -0,0 +1,187 @@
+package graphqlserver
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/mitchellh/mapstructure"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/services"
+)
+
+// OrderResolver resolves the schema fields through the order service. Permissions the
+// REST api checks per route are checked per field here.
+type OrderResolver struct {
+	orderService services.OrderService
+}
+
+type orderPage struct {
+	Items       []response.Order `json:"items"`
+	TotalCount  int              `json:"totalCount"`
+	HasNextPage bool             `json:"hasNextPage"`
+}
+
+func (resolver *OrderResolver) Order(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.ReadOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return resolver.getOrder(p, p.Args["orderNumber"].(string))
+}
+
+func (resolver *OrderResolver) Orders(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.ReadOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	offset, limit := p.Args["offset"].(int), p.Args["limit"].(int)
+	if offset < 0 || limit < 0 || limit > maxPageLimit {
+		return nil, newResponseError(newBadRequest(constants.PagingIsNotValid))
+	}
+
+	filter := request.OrderFilter{}
+	if err := mapstructure.Decode(p.Args["filter"], &filter); err != nil {
+		return nil, newResponseError(newBadRequest(constants.GraphqlRequestIsNotValid))
+	}
+
+	orders, errorResp := resolver.orderService.GetOrders(p.Context, filter)
+	if errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	page := orderPage{Items: make([]response.Order, 0), TotalCount: len(orders)}
+	if offset < len(orders) {
+		end := offset + limit
+		if end > len(orders) {
+			end = len(orders)
+		}
+		page.Items = orders[offset:end]
+		page.HasNextPage = end < len(orders)
+	}
+	return page, nil
+}
+
+func (resolver *OrderResolver) History(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.ReadOrderAudit); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	auditEntries, errorResp := resolver.orderService.GetOrderAudit(p.Context, p.Source.(response.Order).OrderNumber)
+	if errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return auditEntries, nil
+}
+
+func (resolver *OrderResolver) CreateOrder(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.CreateOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	createOrderRequest := request.CreateOrderRequest{}
+	if err := mapstructure.Decode(p.Args["input"], &createOrderRequest); err != nil {
+		return nil, newResponseError(newBadRequest(constants.CreateOrderRequestIsNotValid))
+	}
+
+	if errorResp := createOrderRequest.Validate(); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	if errorResp := resolver.orderService.CreateOrder(p.Context, createOrderRequest); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return resolver.getOrder(p, createOrderRequest.OrderNumber)
+}
+
+func (resolver *OrderResolver) UpdateOrder(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.UpdateOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	orderNumber := p.Args["orderNumber"].(string)
+	updateOrderRequest := request.UpdateOrderRequest{}
+	if err := mapstructure.Decode(p.Args["input"], &updateOrderRequest); err != nil {
+		return nil, newResponseError(newBadRequest(constants.UpdateOrderRequestIsNotValid))
+	}
+
+	if errorResp := updateOrderRequest.Validate(); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	if errorResp := resolver.orderService.UpdateOrder(p.Context, orderNumber, updateOrderRequest); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return resolver.getOrder(p, orderNumber)
+}
+
+func (resolver *OrderResolver) TransitionOrder(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.TransitionOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	orderNumber := p.Args["orderNumber"].(string)
+	transitionOrderRequest := request.TransitionOrderRequest{StatusId: p.Args["statusId"].(int)}
+	if errorResp := transitionOrderRequest.Validate(); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	if errorResp := resolver.orderService.TransitionOrder(p.Context, orderNumber, transitionOrderRequest); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return resolver.getOrder(p, orderNumber)
+}
+
+func (resolver *OrderResolver) DeleteOrder(p graphql.ResolveParams) (interface{}, error) {
+	if errorResp := requirePermission(p, policies.DeleteOrders); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	if errorResp := resolver.orderService.DeleteOrder(p.Context, p.Args["orderNumber"].(string)); errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	return true, nil
+}
+
+// getOrder returns the stored order, also after a mutation so that clients can select its
+// fields; a missing order is not found.
+func (resolver *OrderResolver) getOrder(p graphql.ResolveParams, orderNumber string) (interface{}, error) {
+	order, errorResp := resolver.orderService.GetOrder(p.Context, orderNumber)
+	if errorResp != nil {
+		return nil, newResponseError(errorResp)
+	}
+
+	if order == nil {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
+			Build()
+		return nil, newResponseError(&errorResponse)
+	}
+
+	return *order, nil
+}
+
+func requirePermission(p graphql.ResolveParams, permission policies.Permission) *response.ErrorResponse {
+	if policies.HasPermission(helpers.GetPrincipal(p.Context), permission) {
+		return nil
+	}
+
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusForbidden, constants.PermissionDenied).
+		Build()
+	return &errorResponse
+}
+
+func newBadRequest(message string) *response.ErrorResponse {
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusBadRequest, message).
+		Build()
+	return &errorResponse
+}
@@ -0,0 +1,261 @@
+package graphqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+	"time"
+)
+
+func execute(t *testing.T, orderService *mocks.MockOrderService, ctx context.Context, query string, variables map[string]interface{}) map[string]interface{} {
+	schema, err := NewSchema(orderService)
+	require.NoError(t, err)
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query, VariableValues: variables, Context: ctx})
+	resultBytes, _ := json.Marshal(result)
+	decodedResult := map[string]interface{}{}
+	_ = json.Unmarshal(resultBytes, &decodedResult)
+	return decodedResult
+}
+
+func withRole(role string) context.Context {
+	return helpers.WithPrincipal(context.Background(), &models.Principal{Subject: "user-1", Roles: []string{role}})
+}
+
+func getOrders() []response.Order {
+	return []response.Order{
+		{OrderNumber: "1", City: "İstanbul", StatusId: 1, TotalAmount: 10},
+		{OrderNumber: "2", City: "Ankara", StatusId: 2, TotalAmount: 20},
+		{OrderNumber: "3", City: "İstanbul", StatusId: 2, TotalAmount: 30},
+		{OrderNumber: "4", City: "İstanbul", StatusId: 3, TotalAmount: 40},
+	}
+}
+
+func TestOrders_FiltersAndPages(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	minTotalAmount := float32(20)
+	filter := request.OrderFilter{City: "İstanbul", MinTotalAmount: &minTotalAmount}
+	mockOrderService.On("GetOrders", mock.Anything, filter).Return(filter.Apply(getOrders()), nil)
+	query := `query($filter: OrderFilter) {
+		orders(filter: $filter, offset: 1, limit: 1) { totalCount hasNextPage items { orderNumber } }
+	}`
+	variables := map[string]interface{}{"filter": map[string]interface{}{"city": "İstanbul", "minTotalAmount": 20}}
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), query, variables)
+
+	//Then
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"totalCount":  float64(2),
+		"hasNextPage": false,
+		"items":       []interface{}{map[string]interface{}{"orderNumber": "4"}},
+	}, result["data"].(map[string]interface{})["orders"])
+}
+
+func TestOrders_WhenLimitIsTooLarge_ReturnsPagingError(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), `{ orders(limit: 1000) { totalCount } }`, nil)
+
+	//Then
+	errors := result["errors"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"code": constants.PagingIsNotValid, "statusCode": float64(http.StatusBadRequest)},
+		errors[0].(map[string]interface{})["extensions"])
+	mockOrderService.AssertNotCalled(t, "GetOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrder_WhenOrderIsNotFound_ReturnsNotFoundError(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(nil, nil)
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), `{ order(orderNumber: "1") { orderNumber } }`, nil)
+
+	//Then
+	errors := result["errors"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"code": constants.OrderNotFoundByOrderNumber, "statusCode": float64(http.StatusNotFound)},
+		errors[0].(map[string]interface{})["extensions"])
+	assert.Nil(t, result["data"].(map[string]interface{})["order"])
+}
+
+func TestOrder_WithHistory_ResolvesOrderAndAuditInOneRequest(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{OrderNumber: "1", Address: "Lorem ipsum", StatusId: 2}, nil)
+	mockOrderService.On("GetOrderAudit", mock.Anything, "1").Return([]response.AuditEntry{{
+		Id:        "audit-1",
+		Action:    "order.status.changed",
+		Actor:     "operator-1",
+		Timestamp: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+		Changes:   []response.FieldChange{{Field: "statusId", Before: 1, After: 2}},
+	}}, nil)
+
+	//When
+	result := execute(t, mockOrderService, withRole("operator"),
+		`{ order(orderNumber: "1") { address statusId history { actor timestamp changes { field before after } } } }`, nil)
+
+	//Then
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"address":  "Lorem ipsum",
+		"statusId": float64(2),
+		"history": []interface{}{map[string]interface{}{
+			"actor":     "operator-1",
+			"timestamp": "2023-03-01T10:00:00Z",
+			"changes":   []interface{}{map[string]interface{}{"field": "statusId", "before": "1", "after": "2"}},
+		}},
+	}, result["data"].(map[string]interface{})["order"])
+}
+
+func TestOrder_WhenPrincipalCanNotReadAudit_ReturnsOrderWithHistoryError(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{OrderNumber: "1"}, nil)
+
+	//When
+	result := execute(t, mockOrderService, withRole("customer"), `{ order(orderNumber: "1") { orderNumber history { id } } }`, nil)
+
+	//Then
+	assert.Equal(t, map[string]interface{}{"orderNumber": "1", "history": nil}, result["data"].(map[string]interface{})["order"])
+	errors := result["errors"].([]interface{})
+	assert.Equal(t, constants.PermissionDenied, errors[0].(map[string]interface{})["message"])
+	mockOrderService.AssertNotCalled(t, "GetOrderAudit", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_ValidatesAndReturnsStoredOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{OrderNumber: "1", StatusId: 1}, nil)
+	query := `mutation {
+		createOrder(input: {orderNumber: "1", firstName: "Ahmet", lastName: "Ata", totalAmount: 12.5, address: "Lorem ipsum",
+			city: "İstanbul", district: "Silivri", currencyCode: "TRY"}) { orderNumber statusId }
+	}`
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), query, nil)
+
+	//Then
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]interface{}{"orderNumber": "1", "statusId": float64(1)}, result["data"].(map[string]interface{})["createOrder"])
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, request.CreateOrderRequest{
+		OrderNumber:  "1",
+		FirstName:    "Ahmet",
+		LastName:     "Ata",
+		TotalAmount:  12.5,
+		Address:      "Lorem ipsum",
+		City:         "İstanbul",
+		District:     "Silivri",
+		CurrencyCode: "TRY",
+	})
+}
+
+func TestCreateOrder_WithItemsCouponCustomerAndAddresses_ReturnsPricedOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	address := response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE"}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{
+		OrderNumber:     "1",
+		TotalAmount:     13.5,
+		ShippingAddress: &address,
+		Items:           []response.OrderItem{{Sku: "MUG-WHT", Name: "White Mug", Quantity: 2, UnitPrice: 7.5, Amount: 15}},
+		SubtotalAmount:  15,
+		CouponCode:      "WELCOME10",
+		Discounts:       []response.OrderDiscount{{PromotionCode: "WELCOME10", Name: "Welcome", Amount: 1.5}},
+	}, nil)
+	query := `mutation {
+		createOrder(input: {orderNumber: "1", currencyCode: "EUR", couponCode: "WELCOME10",
+			customer: {firstName: "Jane", lastName: "Doe", email: "jane@example.com"},
+			shippingAddress: {lines: ["Unter den Linden 1"], city: "Berlin", postalCode: "10117", countryCode: "DE"},
+			items: [{sku: "MUG-WHT", quantity: 2}]}) {
+			totalAmount subtotalAmount couponCode
+			shippingAddress { postalCode countryCode }
+			items { sku name quantity amount }
+			discounts { promotionCode amount }
+		}
+	}`
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), query, nil)
+
+	//Then
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]interface{}{
+		"totalAmount":     13.5,
+		"subtotalAmount":  float64(15),
+		"couponCode":      "WELCOME10",
+		"shippingAddress": map[string]interface{}{"postalCode": "10117", "countryCode": "DE"},
+		"items":           []interface{}{map[string]interface{}{"sku": "MUG-WHT", "name": "White Mug", "quantity": float64(2), "amount": float64(15)}},
+		"discounts":       []interface{}{map[string]interface{}{"promotionCode": "WELCOME10", "amount": 1.5}},
+	}, result["data"].(map[string]interface{})["createOrder"])
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, request.CreateOrderRequest{
+		OrderNumber:     "1",
+		CurrencyCode:    "EUR",
+		CouponCode:      "WELCOME10",
+		Customer:        &request.CustomerRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		ShippingAddress: &response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE"},
+		Items:           []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}},
+	})
+}
+
+func TestCreateOrder_WhenInputIsNotValid_ReturnsValidationError(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	query := `mutation {
+		createOrder(input: {orderNumber: "1", firstName: " ", lastName: "Ata", totalAmount: 12.5, address: "Lorem ipsum",
+			city: "İstanbul", district: "Silivri", currencyCode: "TRY"}) { orderNumber }
+	}`
+
+	//When
+	result := execute(t, mockOrderService, context.Background(), query, nil)
+
+	//Then
+	errors := result["errors"].([]interface{})
+	assert.Equal(t, constants.FirstNameIsNotValid, errors[0].(map[string]interface{})["message"])
+	mockOrderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestTransitionOrder_ReturnsTransitionedOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("TransitionOrder", mock.Anything, "1", request.TransitionOrderRequest{StatusId: 3}).Return(nil)
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&response.Order{OrderNumber: "1", StatusId: 3}, nil)
+
+	//When
+	result := execute(t, mockOrderService, withRole("operator"), `mutation { transitionOrder(orderNumber: "1", statusId: 3) { statusId } }`, nil)
+
+	//Then
+	assert.Nil(t, result["errors"])
+	assert.Equal(t, map[string]interface{}{"statusId": float64(3)}, result["data"].(map[string]interface{})["transitionOrder"])
+}
+
+func TestDeleteOrder_WhenPrincipalLacksPermission_ReturnsPermissionDenied(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+
+	//When
+	result := execute(t, mockOrderService, withRole("operator"), `mutation { deleteOrder(orderNumber: "1") }`, nil)
+
+	//Then
+	errors := result["errors"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"code": constants.PermissionDenied, "statusCode": float64(http.StatusForbidden)},
+		errors[0].(map[string]interface{})["extensions"])
+	mockOrderService.AssertNotCalled(t, "DeleteOrder", mock.Anything, mock.Anything)
+}
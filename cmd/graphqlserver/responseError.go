@@ -0,0 +1,24 @@
+package graphqlserver
+
+import "simple-order-api/cmd/models/response"
+
+// responseError carries an error response into the "extensions" of a graphql error,
+// so that clients get the same error codes as from the REST api.
+type responseError struct {
+	errorResponse *response.ErrorResponse
+}
+
+func newResponseError(errorResponse *response.ErrorResponse) error {
+	return &responseError{errorResponse: errorResponse}
+}
+
+func (err *responseError) Error() string {
+	return err.errorResponse.Message
+}
+
+func (err *responseError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":       err.errorResponse.Message,
+		"statusCode": err.errorResponse.StatusCode,
+	}
+}
@@ -0,0 +1,265 @@
+package graphqlserver
+
+import (
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/services"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// NewSchema builds the order schema whose fields are resolved through the order service.
+func NewSchema(orderService services.OrderService) (graphql.Schema, error) {
+	resolver := &OrderResolver{orderService: orderService}
+
+	fieldChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "FieldChange",
+		Fields: graphql.Fields{
+			"field":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"before": &graphql.Field{Type: graphql.String, Description: "JSON encoded value before the change", Resolve: resolveJsonValue(func(change response.FieldChange) interface{} { return change.Before })},
+			"after":  &graphql.Field{Type: graphql.String, Description: "JSON encoded value after the change", Resolve: resolveJsonValue(func(change response.FieldChange) interface{} { return change.After })},
+		},
+	})
+
+	auditEntryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AuditEntry",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"action":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"actor":     &graphql.Field{Type: graphql.String},
+			"requestId": &graphql.Field{Type: graphql.String},
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"changes":   &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(fieldChangeType))},
+		},
+	})
+
+	addressType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Address",
+		Fields: graphql.Fields{
+			"lines":       &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+			"district":    &graphql.Field{Type: graphql.String},
+			"city":        &graphql.Field{Type: graphql.String},
+			"postalCode":  &graphql.Field{Type: graphql.String},
+			"countryCode": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	orderItemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OrderItem",
+		Fields: graphql.Fields{
+			"sku":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name":      &graphql.Field{Type: graphql.String},
+			"quantity":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"unitPrice": &graphql.Field{Type: graphql.Float},
+			"amount":    &graphql.Field{Type: graphql.Float},
+		},
+	})
+
+	orderDiscountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OrderDiscount",
+		Fields: graphql.Fields{
+			"promotionCode": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name":          &graphql.Field{Type: graphql.String},
+			"amount":        &graphql.Field{Type: graphql.Float},
+		},
+	})
+
+	orderType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.Fields{
+			"orderNumber":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"firstName":       &graphql.Field{Type: graphql.String},
+			"lastName":        &graphql.Field{Type: graphql.String},
+			"totalAmount":     &graphql.Field{Type: graphql.Float},
+			"address":         &graphql.Field{Type: graphql.String},
+			"city":            &graphql.Field{Type: graphql.String},
+			"district":        &graphql.Field{Type: graphql.String},
+			"currencyCode":    &graphql.Field{Type: graphql.String},
+			"statusId":        &graphql.Field{Type: graphql.Int},
+			"customerId":      &graphql.Field{Type: graphql.String},
+			"shippingAddress": &graphql.Field{Type: addressType},
+			"billingAddress":  &graphql.Field{Type: addressType},
+			"items":           &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(orderItemType)), Description: "empty for orders placed with a total amount only"},
+			"subtotalAmount":  &graphql.Field{Type: graphql.Float, Description: "sum of the items before the discounts"},
+			"couponCode":      &graphql.Field{Type: graphql.String},
+			"discounts":       &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(orderDiscountType))},
+			"history": &graphql.Field{
+				Type:        graphql.NewList(graphql.NewNonNull(auditEntryType)),
+				Description: "audit log of the order, oldest entry first",
+				Resolve:     resolver.History,
+			},
+		},
+	})
+
+	orderPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OrderPage",
+		Fields: graphql.Fields{
+			"items":       &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(orderType)))},
+			"totalCount":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+
+	orderFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "OrderFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"statusIds":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+			"customerId":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"city":           &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"district":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"currencyCode":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"minTotalAmount": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"maxTotalAmount": &graphql.InputObjectFieldConfig{Type: graphql.Float},
+		},
+	})
+
+	addressInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AddressInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"lines":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+			"district":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"city":        &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"postalCode":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"countryCode": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	orderItemInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "OrderItemInput",
+		Description: "the name and prices of an item are taken from the product catalog",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"sku":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"quantity": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	customerInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CustomerInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"firstName": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"lastName":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"email":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"phone":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"addresses": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.NewInputObject(graphql.InputObjectConfig{
+				Name: "CustomerAddressInput",
+				Fields: graphql.InputObjectConfigFieldMap{
+					"label":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+					"address":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+					"city":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+					"district": &graphql.InputObjectFieldConfig{Type: graphql.String},
+				},
+			})))},
+		},
+	})
+
+	// The flat address fields can be left out for a shippingAddress, the names for those of
+	// the customer and totalAmount for items, as in the REST api; the request validation checks
+	// what remains.
+	orderFields := graphql.InputObjectConfigFieldMap{
+		"firstName":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"lastName":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"totalAmount":     &graphql.InputObjectFieldConfig{Type: graphql.Float, Description: "only taken for orders without items"},
+		"address":         &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"city":            &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"district":        &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"currencyCode":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"shippingAddress": &graphql.InputObjectFieldConfig{Type: addressInputType},
+		"billingAddress":  &graphql.InputObjectFieldConfig{Type: addressInputType},
+		"items":           &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(orderItemInputType))},
+	}
+	createOrderFields := graphql.InputObjectConfigFieldMap{
+		"orderNumber": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"customerId":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"customer":    &graphql.InputObjectFieldConfig{Type: customerInputType},
+		"couponCode":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+	}
+	for name, field := range orderFields {
+		createOrderFields[name] = field
+	}
+	createOrderInputType := graphql.NewInputObject(graphql.InputObjectConfig{Name: "CreateOrderInput", Fields: createOrderFields})
+	updateOrderInputType := graphql.NewInputObject(graphql.InputObjectConfig{Name: "UpdateOrderInput", Fields: orderFields})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"order": &graphql.Field{
+				Type: orderType,
+				Args: graphql.FieldConfigArgument{
+					"orderNumber": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolver.Order,
+			},
+			"orders": &graphql.Field{
+				Type: graphql.NewNonNull(orderPageType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: orderFilterType},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageLimit},
+				},
+				Resolve: resolver.Orders,
+			},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createOrder": &graphql.Field{
+				Type: orderType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createOrderInputType)},
+				},
+				Resolve: resolver.CreateOrder,
+			},
+			"updateOrder": &graphql.Field{
+				Type: orderType,
+				Args: graphql.FieldConfigArgument{
+					"orderNumber": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"input":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateOrderInputType)},
+				},
+				Resolve: resolver.UpdateOrder,
+			},
+			"transitionOrder": &graphql.Field{
+				Type: orderType,
+				Args: graphql.FieldConfigArgument{
+					"orderNumber": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"statusId":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: resolver.TransitionOrder,
+			},
+			"deleteOrder": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"orderNumber": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolver.DeleteOrder,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+}
+
+// resolveJsonValue renders the untyped audit values as JSON so that numbers, strings
+// and nulls stay distinguishable.
+func resolveJsonValue(value func(change response.FieldChange) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		change, ok := p.Source.(response.FieldChange)
+		if !ok {
+			return nil, nil
+		}
+
+		encodedValue, err := json.Marshal(value(change))
+		if err != nil {
+			return nil, err
+		}
+		return string(encodedValue), nil
+	}
+}
@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/grpcserver/orderpb"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"strings"
+)
+
+// methodPermissions mirrors the permissions required by the REST routes; methods
+// missing here are denied.
+var methodPermissions = map[string]policies.Permission{
+	fullMethod("GetOrder"):        policies.ReadOrders,
+	fullMethod("ListOrders"):      policies.ReadOrders,
+	fullMethod("CreateOrder"):     policies.CreateOrders,
+	fullMethod("UpdateOrder"):     policies.UpdateOrders,
+	fullMethod("TransitionOrder"): policies.TransitionOrders,
+	fullMethod("DeleteOrder"):     policies.DeleteOrders,
+}
+
+type AuthInterceptor struct {
+	authenticator middlewares.Authenticator
+}
+
+// NewAuthInterceptor authenticates the "authorization" or "x-api-key" metadata of
+// every call and checks the permission of the called method. A nil authenticator
+// leaves calls anonymous, as the REST api does when authentication is disabled.
+func NewAuthInterceptor(authenticator middlewares.Authenticator) *AuthInterceptor {
+	return &AuthInterceptor{
+		authenticator: authenticator,
+	}
+}
+
+func (interceptor *AuthInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, errorResp := interceptor.authorize(ctx, info.FullMethod)
+	if errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return handler(ctx, req)
+}
+
+func (interceptor *AuthInterceptor) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, errorResp := interceptor.authorize(stream.Context(), info.FullMethod)
+	if errorResp != nil {
+		return toStatusError(errorResp)
+	}
+
+	return handler(srv, &authorizedStream{ServerStream: stream, ctx: ctx})
+}
+
+func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, *response.ErrorResponse) {
+	if interceptor.authenticator != nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		principal, errorResp := interceptor.authenticator.Authenticate(ctx,
+			firstMetadataValue(md, "authorization"),
+			firstMetadataValue(md, strings.ToLower(constants.ApiKeyHeader)))
+		if errorResp != nil {
+			return ctx, errorResp
+		}
+		if principal != nil {
+			ctx = helpers.WithPrincipal(ctx, principal)
+		}
+	}
+
+	permission, ok := methodPermissions[method]
+	if !ok || !policies.HasPermission(helpers.GetPrincipal(ctx), permission) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusForbidden, constants.PermissionDenied).
+			Build()
+		return ctx, &errorResponse
+	}
+
+	return ctx, nil
+}
+
+// authorizedStream exposes the context carrying the principal to stream handlers.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authorizedStream) Context() context.Context {
+	return stream.ctx
+}
+
+func fullMethod(name string) string {
+	return "/" + orderpb.OrderService_ServiceDesc.ServiceName + "/" + name
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,272 @@
+package grpcserver
+
+import (
+	"context"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/grpcserver/orderpb"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/services"
+	"strings"
+)
+
+type OrderServer struct {
+	orderpb.UnimplementedOrderServiceServer
+	orderService services.OrderService
+}
+
+// NewOrderServer serves the grpc order api by delegating to the same order service,
+// and therefore the same rules, as the REST api.
+func NewOrderServer(orderService services.OrderService) orderpb.OrderServiceServer {
+	return &OrderServer{
+		orderService: orderService,
+	}
+}
+
+func (server *OrderServer) GetOrder(ctx context.Context, getOrderRequest *orderpb.GetOrderRequest) (*orderpb.Order, error) {
+	if errorResponse := validateOrderNumber(getOrderRequest.GetOrderNumber()); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	order, errorResp := server.orderService.GetOrder(ctx, getOrderRequest.GetOrderNumber())
+	if errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	if order == nil {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
+			Build()
+		return nil, toStatusError(&errorResponse)
+	}
+
+	return toOrderMessage(*order), nil
+}
+
+// ListOrders sends the matching orders while reading them, so that they are never all
+// held in memory.
+func (server *OrderServer) ListOrders(listOrdersRequest *orderpb.ListOrdersRequest, stream orderpb.OrderService_ListOrdersServer) error {
+	filter, errorResp := toOrderFilter(listOrdersRequest)
+	if errorResp != nil {
+		return toStatusError(errorResp)
+	}
+
+	var sendErr error
+	errorResp = server.orderService.StreamOrders(stream.Context(), filter, func(order response.Order) bool {
+		sendErr = stream.Send(toOrderMessage(order))
+		return sendErr == nil
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if errorResp != nil {
+		return toStatusError(errorResp)
+	}
+
+	return nil
+}
+
+func (server *OrderServer) CreateOrder(ctx context.Context, createOrderMessage *orderpb.CreateOrderRequest) (*orderpb.CreateOrderResponse, error) {
+	createOrderRequest := request.CreateOrderRequest{
+		OrderNumber:     createOrderMessage.GetOrderNumber(),
+		FirstName:       createOrderMessage.GetFirstName(),
+		LastName:        createOrderMessage.GetLastName(),
+		TotalAmount:     createOrderMessage.GetTotalAmount(),
+		Address:         createOrderMessage.GetAddress(),
+		City:            createOrderMessage.GetCity(),
+		District:        createOrderMessage.GetDistrict(),
+		CurrencyCode:    createOrderMessage.GetCurrencyCode(),
+		CustomerId:      createOrderMessage.GetCustomerId(),
+		Customer:        toCustomerRequest(createOrderMessage.GetCustomer()),
+		ShippingAddress: toAddress(createOrderMessage.GetShippingAddress()),
+		BillingAddress:  toAddress(createOrderMessage.GetBillingAddress()),
+		Items:           toOrderItems(createOrderMessage.GetItems()),
+		CouponCode:      createOrderMessage.GetCouponCode(),
+	}
+	if errorResponse := createOrderRequest.Validate(); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	if errorResp := server.orderService.CreateOrder(ctx, createOrderRequest); errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return &orderpb.CreateOrderResponse{}, nil
+}
+
+func (server *OrderServer) UpdateOrder(ctx context.Context, updateOrderMessage *orderpb.UpdateOrderRequest) (*orderpb.UpdateOrderResponse, error) {
+	if errorResponse := validateOrderNumber(updateOrderMessage.GetOrderNumber()); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	updateOrderRequest := request.UpdateOrderRequest{
+		FirstName:       updateOrderMessage.GetFirstName(),
+		LastName:        updateOrderMessage.GetLastName(),
+		TotalAmount:     updateOrderMessage.GetTotalAmount(),
+		Address:         updateOrderMessage.GetAddress(),
+		City:            updateOrderMessage.GetCity(),
+		District:        updateOrderMessage.GetDistrict(),
+		CurrencyCode:    updateOrderMessage.GetCurrencyCode(),
+		ShippingAddress: toAddress(updateOrderMessage.GetShippingAddress()),
+		BillingAddress:  toAddress(updateOrderMessage.GetBillingAddress()),
+		Items:           toOrderItems(updateOrderMessage.GetItems()),
+	}
+	if errorResponse := updateOrderRequest.Validate(); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	if errorResp := server.orderService.UpdateOrder(ctx, updateOrderMessage.GetOrderNumber(), updateOrderRequest); errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return &orderpb.UpdateOrderResponse{}, nil
+}
+
+func (server *OrderServer) TransitionOrder(ctx context.Context, transitionOrderMessage *orderpb.TransitionOrderRequest) (*orderpb.TransitionOrderResponse, error) {
+	if errorResponse := validateOrderNumber(transitionOrderMessage.GetOrderNumber()); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	transitionOrderRequest := request.TransitionOrderRequest{StatusId: int(transitionOrderMessage.GetStatusId())}
+	if errorResponse := transitionOrderRequest.Validate(); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	if errorResp := server.orderService.TransitionOrder(ctx, transitionOrderMessage.GetOrderNumber(), transitionOrderRequest); errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return &orderpb.TransitionOrderResponse{}, nil
+}
+
+func (server *OrderServer) DeleteOrder(ctx context.Context, deleteOrderRequest *orderpb.DeleteOrderRequest) (*orderpb.DeleteOrderResponse, error) {
+	if errorResponse := validateOrderNumber(deleteOrderRequest.GetOrderNumber()); errorResponse != nil {
+		return nil, toStatusError(errorResponse)
+	}
+
+	if errorResp := server.orderService.DeleteOrder(ctx, deleteOrderRequest.GetOrderNumber()); errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return &orderpb.DeleteOrderResponse{}, nil
+}
+
+func toOrderMessage(order response.Order) *orderpb.Order {
+	orderMessage := &orderpb.Order{
+		OrderNumber:     order.OrderNumber,
+		FirstName:       order.FirstName,
+		LastName:        order.LastName,
+		TotalAmount:     order.TotalAmount,
+		Address:         order.Address,
+		City:            order.City,
+		District:        order.District,
+		CurrencyCode:    order.CurrencyCode,
+		StatusId:        int32(order.StatusId),
+		CustomerId:      order.CustomerId,
+		ShippingAddress: toAddressMessage(order.ShippingAddress),
+		BillingAddress:  toAddressMessage(order.BillingAddress),
+		SubtotalAmount:  order.SubtotalAmount,
+		CouponCode:      order.CouponCode,
+	}
+	for _, item := range order.Items {
+		orderMessage.Items = append(orderMessage.Items, &orderpb.OrderItem{
+			Sku:       item.Sku,
+			Name:      item.Name,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Amount,
+		})
+	}
+	for _, discount := range order.Discounts {
+		orderMessage.Discounts = append(orderMessage.Discounts, &orderpb.OrderDiscount{
+			PromotionCode: discount.PromotionCode,
+			Name:          discount.Name,
+			Amount:        discount.Amount,
+		})
+	}
+	return orderMessage
+}
+
+func toAddressMessage(address *response.Address) *orderpb.Address {
+	if address == nil {
+		return nil
+	}
+
+	return &orderpb.Address{
+		Lines:       address.Lines,
+		District:    address.District,
+		City:        address.City,
+		PostalCode:  address.PostalCode,
+		CountryCode: address.CountryCode,
+	}
+}
+
+func toAddress(addressMessage *orderpb.Address) *response.Address {
+	if addressMessage == nil {
+		return nil
+	}
+
+	return &response.Address{
+		Lines:       addressMessage.GetLines(),
+		District:    addressMessage.GetDistrict(),
+		City:        addressMessage.GetCity(),
+		PostalCode:  addressMessage.GetPostalCode(),
+		CountryCode: addressMessage.GetCountryCode(),
+	}
+}
+
+// toOrderItems reads the sku and quantity of the items, the rest is priced from the catalog.
+func toOrderItems(itemMessages []*orderpb.OrderItem) []response.OrderItem {
+	var items []response.OrderItem
+	for _, itemMessage := range itemMessages {
+		items = append(items, response.OrderItem{Sku: itemMessage.GetSku(), Quantity: int(itemMessage.GetQuantity())})
+	}
+	return items
+}
+
+func toCustomerRequest(customerMessage *orderpb.Customer) *request.CustomerRequest {
+	if customerMessage == nil {
+		return nil
+	}
+
+	customerRequest := &request.CustomerRequest{
+		FirstName: customerMessage.GetFirstName(),
+		LastName:  customerMessage.GetLastName(),
+		Email:     customerMessage.GetEmail(),
+		Phone:     customerMessage.GetPhone(),
+	}
+	for _, address := range customerMessage.GetAddresses() {
+		customerRequest.Addresses = append(customerRequest.Addresses, request.CustomerAddress{
+			Label:    address.GetLabel(),
+			Address:  address.GetAddress(),
+			City:     address.GetCity(),
+			District: address.GetDistrict(),
+		})
+	}
+	return customerRequest
+}
+
+func toOrderFilter(listOrdersRequest *orderpb.ListOrdersRequest) (request.OrderFilter, *response.ErrorResponse) {
+	statusIds := make([]int, 0, len(listOrdersRequest.GetStatusIds()))
+	for _, statusId := range listOrdersRequest.GetStatusIds() {
+		if !enum.OrderStatus(statusId).IsValid() {
+			errorResponse := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.StatusIsNotValid).
+				Build()
+			return request.OrderFilter{}, &errorResponse
+		}
+		statusIds = append(statusIds, int(statusId))
+	}
+
+	return request.OrderFilter{
+		StatusIds:      statusIds,
+		CustomerId:     strings.TrimSpace(listOrdersRequest.GetCustomerId()),
+		City:           strings.TrimSpace(listOrdersRequest.GetCity()),
+		District:       strings.TrimSpace(listOrdersRequest.GetDistrict()),
+		CurrencyCode:   strings.TrimSpace(listOrdersRequest.GetCurrencyCode()),
+		MinTotalAmount: listOrdersRequest.MinTotalAmount,
+		MaxTotalAmount: listOrdersRequest.MaxTotalAmount,
+	}, nil
+}
@@ -0,0 +1,432 @@
+package grpcserver
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/grpcserver/orderpb"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"testing"
+	"time"
+)
+
+func newOrderClient(t *testing.T, orderService *mocks.MockOrderService, authenticator middlewares.Authenticator) orderpb.OrderServiceClient {
+	return newRateLimitedOrderClient(t, orderService, authenticator, models.RateLimitConfig{})
+}
+
+func newRateLimitedOrderClient(t *testing.T, orderService *mocks.MockOrderService, authenticator middlewares.Authenticator, rateLimitConfig models.RateLimitConfig) orderpb.OrderServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGrpcServer(orderService, authenticator, rateLimitConfig, middlewares.NewMemoryRateLimitStore())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	connection, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+	return orderpb.NewOrderServiceClient(connection)
+}
+
+func getErrorDetail(t *testing.T, err error) *orderpb.Error {
+	errorStatus, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Len(t, errorStatus.Details(), 1)
+	return errorStatus.Details()[0].(*orderpb.Error)
+}
+
+func TestGetOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	order := response.Order{OrderNumber: "1", FirstName: "Ahmet", LastName: "Ata", TotalAmount: 121.13, City: "İstanbul", StatusId: 2}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&order, nil)
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	orderMessage, err := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+
+	//Then
+	require.NoError(t, err)
+	assert.Equal(t, "1", orderMessage.OrderNumber)
+	assert.Equal(t, "Ahmet", orderMessage.FirstName)
+	assert.Equal(t, float32(121.13), orderMessage.TotalAmount)
+	assert.Equal(t, "İstanbul", orderMessage.City)
+	assert.Equal(t, int32(2), orderMessage.StatusId)
+}
+
+func TestGetOrder_ReturnsItemsDiscountsAndAddresses(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	address := response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", District: "Mitte", PostalCode: "10117", CountryCode: "DE"}
+	order := response.Order{
+		OrderNumber:     "1",
+		TotalAmount:     13.5,
+		ShippingAddress: &address,
+		BillingAddress:  &address,
+		Items:           []response.OrderItem{{Sku: "MUG-WHT", Name: "White Mug", Quantity: 2, UnitPrice: 7.5, Amount: 15}},
+		SubtotalAmount:  15,
+		CouponCode:      "WELCOME10",
+		Discounts:       []response.OrderDiscount{{PromotionCode: "WELCOME10", Name: "Welcome", Amount: 1.5}},
+	}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&order, nil)
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	orderMessage, err := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, orderMessage.Items, 1)
+	assert.Equal(t, "White Mug", orderMessage.Items[0].Name)
+	assert.Equal(t, int32(2), orderMessage.Items[0].Quantity)
+	assert.Equal(t, float32(15), orderMessage.Items[0].Amount)
+	assert.Equal(t, float32(15), orderMessage.SubtotalAmount)
+	assert.Equal(t, "WELCOME10", orderMessage.CouponCode)
+	require.Len(t, orderMessage.Discounts, 1)
+	assert.Equal(t, float32(1.5), orderMessage.Discounts[0].Amount)
+	assert.Equal(t, "10117", orderMessage.ShippingAddress.PostalCode)
+	assert.Equal(t, "DE", orderMessage.BillingAddress.CountryCode)
+}
+
+func TestGetOrder_WhenOrderIsNotFound_ReturnsNotFoundWithErrorDetail(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(nil, nil)
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	_, err := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+
+	//Then
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	errorDetail := getErrorDetail(t, err)
+	assert.Equal(t, constants.OrderNotFoundByOrderNumber, errorDetail.Message)
+	assert.Equal(t, int32(http.StatusNotFound), errorDetail.StatusCode)
+}
+
+func mockStreamOrders(orderService *mocks.MockOrderService, orders ...response.Order) {
+	orderService.On("StreamOrders", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(2).(func(response.Order) bool)
+			for _, order := range orders {
+				if !visit(order) {
+					return
+				}
+			}
+		}).
+		Return(nil)
+}
+
+func receiveOrderNumbers(t *testing.T, stream orderpb.OrderService_ListOrdersClient) []string {
+	orderNumbers := make([]string, 0)
+	for {
+		orderMessage, err := stream.Recv()
+		if err == io.EOF {
+			return orderNumbers
+		}
+		require.NoError(t, err)
+		orderNumbers = append(orderNumbers, orderMessage.OrderNumber)
+	}
+}
+
+func TestListOrders_StreamsEveryOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockStreamOrders(mockOrderService, response.Order{OrderNumber: "1"}, response.Order{OrderNumber: "2"})
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	stream, err := client.ListOrders(context.Background(), &orderpb.ListOrdersRequest{})
+	require.NoError(t, err)
+	orderNumbers := receiveOrderNumbers(t, stream)
+
+	//Then
+	assert.Equal(t, []string{"1", "2"}, orderNumbers)
+}
+
+func TestListOrders_PassesFilterToService(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockStreamOrders(mockOrderService, response.Order{OrderNumber: "1"})
+	client := newOrderClient(t, mockOrderService, nil)
+	minTotalAmount := float32(10)
+
+	//When
+	stream, err := client.ListOrders(context.Background(), &orderpb.ListOrdersRequest{
+		StatusIds:      []int32{1, 2},
+		City:           " İstanbul ",
+		CurrencyCode:   "TRY",
+		MinTotalAmount: &minTotalAmount,
+	})
+	require.NoError(t, err)
+	orderNumbers := receiveOrderNumbers(t, stream)
+
+	//Then
+	assert.Equal(t, []string{"1"}, orderNumbers)
+	mockOrderService.AssertCalled(t, "StreamOrders", mock.Anything, request.OrderFilter{
+		StatusIds:      []int{1, 2},
+		City:           "İstanbul",
+		CurrencyCode:   "TRY",
+		MinTotalAmount: &minTotalAmount,
+	}, mock.Anything)
+}
+
+func TestListOrders_WhenStatusIsNotValid_ReturnsInvalidArgument(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	stream, err := client.ListOrders(context.Background(), &orderpb.ListOrdersRequest{StatusIds: []int32{9}})
+	require.NoError(t, err)
+	_, recvErr := stream.Recv()
+
+	//Then
+	assert.Equal(t, codes.InvalidArgument, status.Code(recvErr))
+	assert.Equal(t, constants.StatusIsNotValid, getErrorDetail(t, recvErr).Message)
+	mockOrderService.AssertNotCalled(t, "StreamOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	client := newOrderClient(t, mockOrderService, nil)
+	createOrderMessage := &orderpb.CreateOrderRequest{
+		OrderNumber:  "1",
+		FirstName:    "Ahmet",
+		LastName:     "Ata",
+		TotalAmount:  10,
+		Address:      "Lorem ipsum",
+		City:         "İstanbul",
+		District:     "Silivri",
+		CurrencyCode: "TRY",
+		CustomerId:   "customer-1",
+	}
+
+	//When
+	_, err := client.CreateOrder(context.Background(), createOrderMessage)
+
+	//Then
+	require.NoError(t, err)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, request.CreateOrderRequest{
+		OrderNumber:  "1",
+		FirstName:    "Ahmet",
+		LastName:     "Ata",
+		TotalAmount:  10,
+		Address:      "Lorem ipsum",
+		City:         "İstanbul",
+		District:     "Silivri",
+		CurrencyCode: "TRY",
+		CustomerId:   "customer-1",
+	})
+}
+
+func TestCreateOrder_PassesItemsCouponCustomerAndAddressesToService(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	client := newOrderClient(t, mockOrderService, nil)
+	createOrderMessage := &orderpb.CreateOrderRequest{
+		OrderNumber:     "1",
+		CurrencyCode:    "EUR",
+		Customer:        &orderpb.Customer{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		ShippingAddress: &orderpb.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", District: "Mitte", PostalCode: "10117", CountryCode: "DE"},
+		BillingAddress:  &orderpb.Address{Lines: []string{"Friedrichstraße 2"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE"},
+		Items:           []*orderpb.OrderItem{{Sku: "MUG-WHT", Quantity: 2, UnitPrice: 0.01}},
+		CouponCode:      "WELCOME10",
+	}
+
+	//When
+	_, err := client.CreateOrder(context.Background(), createOrderMessage)
+
+	//Then
+	require.NoError(t, err)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.Anything, request.CreateOrderRequest{
+		OrderNumber:     "1",
+		CurrencyCode:    "EUR",
+		Customer:        &request.CustomerRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		ShippingAddress: &response.Address{Lines: []string{"Unter den Linden 1"}, City: "Berlin", District: "Mitte", PostalCode: "10117", CountryCode: "DE"},
+		BillingAddress:  &response.Address{Lines: []string{"Friedrichstraße 2"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE"},
+		Items:           []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}},
+		CouponCode:      "WELCOME10",
+	})
+}
+
+func TestCreateOrder_WhenRequestIsNotValid_ReturnsInvalidArgument(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	_, err := client.CreateOrder(context.Background(), &orderpb.CreateOrderRequest{OrderNumber: "1"})
+
+	//Then
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, constants.FirstNameIsNotValid, getErrorDetail(t, err).Message)
+	mockOrderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestTransitionOrder_WhenStatusIsNotValid_ReturnsInvalidArgument(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	client := newOrderClient(t, mockOrderService, nil)
+
+	//When
+	_, err := client.TransitionOrder(context.Background(), &orderpb.TransitionOrderRequest{OrderNumber: "1", StatusId: 9})
+
+	//Then
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, constants.StatusIsNotValid, getErrorDetail(t, err).Message)
+	mockOrderService.AssertNotCalled(t, "TransitionOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthInterceptor_WhenAuthenticationFails_ReturnsUnauthenticated(t *testing.T) {
+	//Given
+	mockAuthenticator := &mocks.MockAuthenticator{}
+	missingToken := response.NewErrorBuilder().
+		SetError(http.StatusUnauthorized, constants.AuthorizationTokenIsMissing).
+		Build()
+	mockAuthenticator.On("Authenticate", mock.Anything, "", "").Return(nil, &missingToken)
+	client := newOrderClient(t, &mocks.MockOrderService{}, mockAuthenticator)
+
+	//When
+	_, err := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+
+	//Then
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, constants.AuthorizationTokenIsMissing, getErrorDetail(t, err).Message)
+}
+
+func TestAuthInterceptor_AuthenticatesMetadataAndChecksPermission(t *testing.T) {
+	//Given
+	mockAuthenticator := &mocks.MockAuthenticator{}
+	customer := &models.Principal{Subject: "customer-1", Roles: []string{"customer"}}
+	mockAuthenticator.On("Authenticate", mock.Anything, "Bearer token", "").Return(customer, nil)
+	mockOrderService := &mocks.MockOrderService{}
+	mockStreamOrders(mockOrderService)
+	client := newOrderClient(t, mockOrderService, mockAuthenticator)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	//When
+	stream, listErr := client.ListOrders(ctx, &orderpb.ListOrdersRequest{})
+	_, recvErr := stream.Recv()
+	_, deleteErr := client.DeleteOrder(ctx, &orderpb.DeleteOrderRequest{OrderNumber: "1"})
+
+	//Then
+	require.NoError(t, listErr)
+	assert.Equal(t, io.EOF, recvErr)
+	assert.Equal(t, codes.PermissionDenied, status.Code(deleteErr))
+	assert.Equal(t, constants.PermissionDenied, getErrorDetail(t, deleteErr).Message)
+	mockOrderService.AssertNotCalled(t, "DeleteOrder", mock.Anything, mock.Anything)
+}
+
+func TestRateLimitInterceptor_WhenLimitIsExceeded_ReturnsResourceExhausted(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	order := response.Order{OrderNumber: "1"}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&order, nil)
+	mockStreamOrders(mockOrderService)
+	rateLimitConfig := models.RateLimitConfig{
+		Enabled: true,
+		Default: models.RateLimitRule{Limit: 1, Period: time.Minute},
+	}
+	client := newRateLimitedOrderClient(t, mockOrderService, nil, rateLimitConfig)
+
+	//When
+	_, firstErr := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+	_, secondErr := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "1"})
+	stream, listErr := client.ListOrders(context.Background(), &orderpb.ListOrdersRequest{})
+	require.NoError(t, listErr)
+	_, recvErr := stream.Recv()
+
+	//Then
+	require.NoError(t, firstErr)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(secondErr))
+	assert.Equal(t, constants.RateLimitExceeded, getErrorDetail(t, secondErr).Message)
+	assert.Equal(t, io.EOF, recvErr)
+	mockOrderService.AssertNumberOfCalls(t, "GetOrder", 1)
+}
+
+func TestRateLimitInterceptor_LimitsApiKeysSeparatelyAfterAuthentication(t *testing.T) {
+	//Given
+	mockAuthenticator := &mocks.MockAuthenticator{}
+	for _, apiKey := range []string{"soa_first", "soa_second"} {
+		principal := &models.Principal{Type: models.ApiKeyPrincipal, Subject: apiKey, Scopes: []string{"orders:read"}}
+		mockAuthenticator.On("Authenticate", mock.Anything, "", apiKey).Return(principal, nil)
+	}
+	mockOrderService := &mocks.MockOrderService{}
+	order := response.Order{OrderNumber: "1"}
+	mockOrderService.On("GetOrder", mock.Anything, "1").Return(&order, nil)
+	rateLimitConfig := models.RateLimitConfig{
+		Enabled:       true,
+		Default:       models.RateLimitRule{Limit: 1, Period: time.Minute},
+		IpLimitFactor: 10,
+	}
+	client := newRateLimitedOrderClient(t, mockOrderService, mockAuthenticator, rateLimitConfig)
+	getOrder := func(apiKey string) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", apiKey)
+		_, err := client.GetOrder(ctx, &orderpb.GetOrderRequest{OrderNumber: "1"})
+		return err
+	}
+
+	//When
+	firstErr := getOrder("soa_first")
+	otherKeyErr := getOrder("soa_second")
+	repeatedErr := getOrder("soa_first")
+
+	//Then
+	require.NoError(t, firstErr)
+	require.NoError(t, otherKeyErr)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(repeatedErr))
+	mockOrderService.AssertNumberOfCalls(t, "GetOrder", 2)
+}
+
+func TestToStatusError_MapsHttpStatusToGrpcCode(t *testing.T) {
+	tests := []struct {
+		statusCode   int
+		message      string
+		expectedCode codes.Code
+	}{
+		{http.StatusBadRequest, constants.OrderNumberIsNotValid, codes.InvalidArgument},
+		{http.StatusUnauthorized, constants.AuthorizationTokenIsNotValid, codes.Unauthenticated},
+		{http.StatusForbidden, constants.OrderAccessDenied, codes.PermissionDenied},
+		{http.StatusNotFound, constants.OrderNotFoundByOrderNumber, codes.NotFound},
+		{http.StatusConflict, constants.SameOrderFoundByUniqueId, codes.AlreadyExists},
+		{http.StatusTooManyRequests, constants.RateLimitExceeded, codes.ResourceExhausted},
+		{http.StatusServiceUnavailable, constants.RequestCancelled, codes.Canceled},
+		{http.StatusGatewayTimeout, constants.RequestTimedOut, codes.DeadlineExceeded},
+		{http.StatusInternalServerError, constants.UnexpectedErrorOccurred, codes.Internal},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			//Given
+			errorResponse := response.NewErrorBuilder().
+				SetError(test.statusCode, test.message).
+				Build()
+
+			//When
+			err := toStatusError(&errorResponse)
+
+			//Then
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			assert.Equal(t, test.message, status.Convert(err).Message())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"net"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// methodRoutes maps each method to its REST route, so that both apis are limited by
+// the same rules and share the budget of a client.
+var methodRoutes = map[string][2]string{
+	fullMethod("GetOrder"):        {http.MethodGet, "/orders/:orderNumber"},
+	fullMethod("ListOrders"):      {http.MethodGet, "/orders"},
+	fullMethod("CreateOrder"):     {http.MethodPost, "/orders"},
+	fullMethod("UpdateOrder"):     {http.MethodPut, "/orders/:orderNumber"},
+	fullMethod("TransitionOrder"): {http.MethodPatch, "/orders/:orderNumber/status"},
+	fullMethod("DeleteOrder"):     {http.MethodDelete, "/orders/:orderNumber"},
+}
+
+type RateLimitInterceptor struct {
+	store    middlewares.RateLimitStore
+	ruleOf   func(method, route string) models.RateLimitRule
+	clientOf func(ctx context.Context) string
+}
+
+// NewRateLimitInterceptor limits each peer ip address per method by the rule
+// multiplied by ipLimitFactor. It runs before authentication, as the REST
+// middleware does.
+func NewRateLimitInterceptor(config models.RateLimitConfig, store middlewares.RateLimitStore) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		store:  store,
+		ruleOf: config.IpRuleOf,
+		clientOf: func(ctx context.Context) string {
+			return "ip:" + peerIp(ctx)
+		},
+	}
+}
+
+// NewClientRateLimitInterceptor limits each api key or user per method. It runs after
+// authentication; anonymous calls are limited per peer ip address.
+func NewClientRateLimitInterceptor(config models.RateLimitConfig, store middlewares.RateLimitStore) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		store:  store,
+		ruleOf: config.RuleOf,
+		clientOf: func(ctx context.Context) string {
+			return middlewares.RateLimitClientOf(helpers.GetPrincipal(ctx), peerIp(ctx))
+		},
+	}
+}
+
+func (interceptor *RateLimitInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if errorResp := interceptor.take(ctx, info.FullMethod); errorResp != nil {
+		return nil, toStatusError(errorResp)
+	}
+
+	return handler(ctx, req)
+}
+
+func (interceptor *RateLimitInterceptor) Stream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if errorResp := interceptor.take(stream.Context(), info.FullMethod); errorResp != nil {
+		return toStatusError(errorResp)
+	}
+
+	return handler(srv, stream)
+}
+
+func (interceptor *RateLimitInterceptor) take(ctx context.Context, method string) *response.ErrorResponse {
+	route, ok := methodRoutes[method]
+	if !ok {
+		return nil
+	}
+
+	rule := interceptor.ruleOf(route[0], route[1])
+	if rule.Limit <= 0 || rule.Period <= 0 {
+		return nil
+	}
+
+	key := strings.Join([]string{interceptor.clientOf(ctx), route[0], route[1]}, "|")
+	result, err := interceptor.store.Take(ctx, key, rule)
+	if err != nil || result.Allowed {
+		// An unavailable store must not take the api down, so the call is let through.
+		return nil
+	}
+
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusTooManyRequests, constants.RateLimitExceeded).
+		Build()
+	return &errorResponse
+}
+
+func peerIp(ctx context.Context) string {
+	callPeer, ok := peer.FromContext(ctx)
+	if !ok || callPeer.Addr == nil {
+		return ""
+	}
+
+	address := callPeer.Addr.String()
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
@@ -0,0 +1,37 @@
+package grpcserver
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"simple-order-api/cmd/grpcserver/orderpb"
+	"simple-order-api/cmd/middlewares"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/services"
+)
+
+// NewGrpcServer creates the traced, rate limited and authenticated grpc server of the
+// order api. Calls are rate limited per ip address before and per client after
+// authentication when the config is enabled, in the store shared with the REST api.
+func NewGrpcServer(orderService services.OrderService, authenticator middlewares.Authenticator, rateLimitConfig models.RateLimitConfig, rateLimitStore middlewares.RateLimitStore) *grpc.Server {
+	unaryInterceptors := []grpc.UnaryServerInterceptor{otelgrpc.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{otelgrpc.StreamServerInterceptor()}
+	if rateLimitConfig.Enabled {
+		rateLimitInterceptor := NewRateLimitInterceptor(rateLimitConfig, rateLimitStore)
+		unaryInterceptors = append(unaryInterceptors, rateLimitInterceptor.Unary)
+		streamInterceptors = append(streamInterceptors, rateLimitInterceptor.Stream)
+	}
+	authInterceptor := NewAuthInterceptor(authenticator)
+	unaryInterceptors = append(unaryInterceptors, authInterceptor.Unary)
+	streamInterceptors = append(streamInterceptors, authInterceptor.Stream)
+	if rateLimitConfig.Enabled {
+		clientRateLimitInterceptor := NewClientRateLimitInterceptor(rateLimitConfig, rateLimitStore)
+		unaryInterceptors = append(unaryInterceptors, clientRateLimitInterceptor.Unary)
+		streamInterceptors = append(streamInterceptors, clientRateLimitInterceptor.Stream)
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	orderpb.RegisterOrderServiceServer(server, NewOrderServer(orderService))
+	return server
+}
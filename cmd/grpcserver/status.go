@@ -0,0 +1,67 @@
+package grpcserver
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/grpcserver/orderpb"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+)
+
+// toStatusError maps the http status of an error response to its grpc code and
+// attaches the error body as orderpb.Error detail.
+func toStatusError(errorResp *response.ErrorResponse) error {
+	errorStatus := status.New(toCode(errorResp), errorResp.Message)
+	detailedStatus, err := errorStatus.WithDetails(&orderpb.Error{
+		Message:    errorResp.Message,
+		StatusCode: int32(errorResp.StatusCode),
+	})
+	if err != nil {
+		return errorStatus.Err()
+	}
+
+	return detailedStatus.Err()
+}
+
+func toCode(errorResp *response.ErrorResponse) codes.Code {
+	switch errorResp.StatusCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		if errorResp.Message == constants.RequestCancelled {
+			return codes.Canceled
+		}
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+func validateOrderNumber(orderNumber string) *response.ErrorResponse {
+	if !helpers.IsValidString(orderNumber, nil) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.OrderNumberIsNotValid).
+			Build()
+		return &errorResponse
+	}
+
+	return nil
+}
@@ -1,7 +1,69 @@
 package helpers
 
-import "strings"
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+type principalContextKey struct{}
+
+type requestIdContextKey struct{}
 
 func IsValidString(value string, err error) bool {
 	return err == nil && len(strings.TrimSpace(value)) > 0
 }
+
+// ContextError converts a cancelled or expired context into an error response
+// so that layers can stop working once the caller is gone.
+func ContextError(ctx context.Context) *response.ErrorResponse {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+
+	errorBuilder := response.NewErrorBuilder()
+	if errors.Is(err, context.DeadlineExceeded) {
+		errorBuilder.SetError(http.StatusGatewayTimeout, constants.RequestTimedOut)
+	} else {
+		errorBuilder.SetError(http.StatusServiceUnavailable, constants.RequestCancelled)
+	}
+
+	errorResp := errorBuilder.Build()
+	return &errorResp
+}
+
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// GetPrincipal returns the authenticated caller, or nil when the request was not authenticated.
+func GetPrincipal(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalContextKey{}).(*models.Principal)
+	return principal
+}
+
+// DetachedContext keeps the span, principal and request id of ctx but is never cancelled.
+// It is meant for work that must still happen once a change is committed, even when
+// the caller is gone.
+func DetachedContext(ctx context.Context) context.Context {
+	detached := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	if principal := GetPrincipal(ctx); principal != nil {
+		detached = WithPrincipal(detached, principal)
+	}
+	return WithRequestId(detached, GetRequestId(ctx))
+}
+
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdContextKey{}, requestId)
+}
+
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdContextKey{}).(string)
+	return requestId
+}
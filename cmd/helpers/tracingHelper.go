@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"simple-order-api/cmd/models/response"
+)
+
+// EndSpan marks the span as failed when an error response is produced and ends it.
+func EndSpan(span trace.Span, errorResp *response.ErrorResponse) {
+	if errorResp != nil {
+		span.SetStatus(codes.Error, errorResp.Message)
+	}
+	span.End()
+}
+
+// RecordError adds an error response with the given attributes to the span of ctx
+// without failing it, for failures that must not fail an operation which already took
+// effect.
+func RecordError(ctx context.Context, errorResp *response.ErrorResponse, attributes ...attribute.KeyValue) {
+	if errorResp != nil {
+		trace.SpanFromContext(ctx).RecordError(errors.New(errorResp.Message), trace.WithAttributes(attributes...))
+	}
+}
@@ -0,0 +1,289 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strconv"
+	"strings"
+)
+
+const (
+	CsvFormat    = "csv"
+	NdjsonFormat = "ndjson"
+	// maxNdjsonLine bounds a single order of an ndjson file.
+	maxNdjsonLine = 1 << 20
+)
+
+var utf8Bom = []byte("\uFEFF")
+
+// Row is an order read from an import file. Error is set when the row could not be
+// read into a request; the request itself is validated by the importer.
+type Row struct {
+	Line    int
+	Request request.CreateOrderRequest
+	Error   *response.ErrorResponse
+}
+
+// csvField fills a request field from its csv value; amounts of files separated with
+// semicolons are written with a decimal comma. A value that can not be read is reported
+// with the error of the row.
+type csvField func(createOrderRequest *request.CreateOrderRequest, value string, decimalComma bool) *response.ErrorResponse
+
+// csvFields maps the csv header, lower cased and without spaces, to the request field it
+// fills. The headers of order exports are recognized too. Items are written as sku and
+// quantity pairs, e.g. "MUG-WHT:2|TSHIRT-BLK-M:1". The customer, postal code, country
+// code and billing columns are skipped when blank, so that rows of a file can leave them
+// out; the postal and country code complete the shipping address of the flat fields.
+var csvFields = map[string]csvField{
+	"ordernumber":  textField(func(r *request.CreateOrderRequest, value string) { r.OrderNumber = value }),
+	"firstname":    textField(func(r *request.CreateOrderRequest, value string) { r.FirstName = value }),
+	"lastname":     textField(func(r *request.CreateOrderRequest, value string) { r.LastName = value }),
+	"address":      textField(func(r *request.CreateOrderRequest, value string) { r.Address = value }),
+	"city":         textField(func(r *request.CreateOrderRequest, value string) { r.City = value }),
+	"district":     textField(func(r *request.CreateOrderRequest, value string) { r.District = value }),
+	"currencycode": textField(func(r *request.CreateOrderRequest, value string) { r.CurrencyCode = value }),
+	"currency":     textField(func(r *request.CreateOrderRequest, value string) { r.CurrencyCode = value }),
+	"customerid":   textField(func(r *request.CreateOrderRequest, value string) { r.CustomerId = value }),
+	"couponcode":   textField(func(r *request.CreateOrderRequest, value string) { r.CouponCode = value }),
+	"totalamount": func(r *request.CreateOrderRequest, value string, decimalComma bool) *response.ErrorResponse {
+		if len(strings.TrimSpace(value)) == 0 {
+			return nil
+		}
+		totalAmount, ok := parseAmount(value, decimalComma)
+		if !ok {
+			return newRowError(constants.TotalAmountIsNotValid)
+		}
+		r.TotalAmount = totalAmount
+		return nil
+	},
+	"items": func(r *request.CreateOrderRequest, value string, _ bool) *response.ErrorResponse {
+		items, ok := parseItems(value)
+		if !ok {
+			return newRowError(constants.OrderItemsAreNotValid)
+		}
+		r.Items = items
+		return nil
+	},
+	"customerfirstname":  optionalField(func(r *request.CreateOrderRequest, value string) { inlineCustomer(r).FirstName = value }),
+	"customerlastname":   optionalField(func(r *request.CreateOrderRequest, value string) { inlineCustomer(r).LastName = value }),
+	"customeremail":      optionalField(func(r *request.CreateOrderRequest, value string) { inlineCustomer(r).Email = value }),
+	"customerphone":      optionalField(func(r *request.CreateOrderRequest, value string) { inlineCustomer(r).Phone = value }),
+	"postalcode":         optionalField(func(r *request.CreateOrderRequest, value string) { shippingAddress(r).PostalCode = value }),
+	"countrycode":        optionalField(func(r *request.CreateOrderRequest, value string) { shippingAddress(r).CountryCode = value }),
+	"billingaddress":     optionalField(func(r *request.CreateOrderRequest, value string) { billingAddress(r).Lines = []string{value} }),
+	"billingcity":        optionalField(func(r *request.CreateOrderRequest, value string) { billingAddress(r).City = value }),
+	"billingdistrict":    optionalField(func(r *request.CreateOrderRequest, value string) { billingAddress(r).District = value }),
+	"billingpostalcode":  optionalField(func(r *request.CreateOrderRequest, value string) { billingAddress(r).PostalCode = value }),
+	"billingcountrycode": optionalField(func(r *request.CreateOrderRequest, value string) { billingAddress(r).CountryCode = value }),
+	// new orders always start in the first status, so the status of an export is skipped
+	"status":   func(*request.CreateOrderRequest, string, bool) *response.ErrorResponse { return nil },
+	"statusid": func(*request.CreateOrderRequest, string, bool) *response.ErrorResponse { return nil },
+}
+
+func textField(set func(r *request.CreateOrderRequest, value string)) csvField {
+	return func(r *request.CreateOrderRequest, value string, _ bool) *response.ErrorResponse {
+		set(r, value)
+		return nil
+	}
+}
+
+func optionalField(set func(r *request.CreateOrderRequest, value string)) csvField {
+	return func(r *request.CreateOrderRequest, value string, _ bool) *response.ErrorResponse {
+		if len(strings.TrimSpace(value)) > 0 {
+			set(r, value)
+		}
+		return nil
+	}
+}
+
+func inlineCustomer(r *request.CreateOrderRequest) *request.CustomerRequest {
+	if r.Customer == nil {
+		r.Customer = &request.CustomerRequest{}
+	}
+	return r.Customer
+}
+
+func shippingAddress(r *request.CreateOrderRequest) *response.Address {
+	if r.ShippingAddress == nil {
+		r.ShippingAddress = &response.Address{}
+	}
+	return r.ShippingAddress
+}
+
+func billingAddress(r *request.CreateOrderRequest) *response.Address {
+	if r.BillingAddress == nil {
+		r.BillingAddress = &response.Address{}
+	}
+	return r.BillingAddress
+}
+
+// completeShippingAddress takes the street, city and district of a shipping address
+// started by the postal or country code from the flat fields, which are read in any order.
+func completeShippingAddress(r *request.CreateOrderRequest) {
+	if r.ShippingAddress != nil {
+		r.ShippingAddress.Lines = []string{r.Address}
+		r.ShippingAddress.City = r.City
+		r.ShippingAddress.District = r.District
+	}
+}
+
+// parseItems reads the items of a row, e.g. "MUG-WHT:2|TSHIRT-BLK-M:1"; a blank value
+// has no items.
+func parseItems(value string) ([]response.OrderItem, bool) {
+	var items []response.OrderItem
+	if len(strings.TrimSpace(value)) == 0 {
+		return items, true
+	}
+
+	for _, pair := range strings.Split(value, "|") {
+		sku, quantity, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, false
+		}
+		itemQuantity, err := strconv.Atoi(strings.TrimSpace(quantity))
+		if err != nil {
+			return nil, false
+		}
+		items = append(items, response.OrderItem{Sku: strings.TrimSpace(sku), Quantity: itemQuantity})
+	}
+	return items, true
+}
+
+// IsValidFormat reports whether orders can be read from files of the format.
+func IsValidFormat(format string) bool {
+	return format == CsvFormat || format == NdjsonFormat
+}
+
+// ReadOrders reads every order of the file. An error is returned only when the file as
+// a whole can not be read, e.g. a csv header naming an unknown field; malformed rows are
+// returned with their error so that they can be reported.
+func ReadOrders(reader io.Reader, format string) ([]Row, error) {
+	bufferedReader := bufio.NewReader(reader)
+	if prefix, err := bufferedReader.Peek(len(utf8Bom)); err == nil && bytes.Equal(prefix, utf8Bom) {
+		_, _ = bufferedReader.Discard(len(utf8Bom))
+	}
+
+	switch format {
+	case CsvFormat:
+		return readCsv(bufferedReader)
+	case NdjsonFormat:
+		return readNdjson(bufferedReader)
+	default:
+		return nil, fmt.Errorf("unknown import format %q", format)
+	}
+}
+
+// readCsv expects a header row naming the CreateOrderRequest fields, or the columns of an
+// order export, in any order and case. Files separated with semicolons, as exported for
+// decimal comma locales, are recognized by their header and read with decimal commas.
+func readCsv(reader *bufio.Reader) ([]Row, error) {
+	// a shorter file is peeked as a whole
+	peeked, _ := reader.Peek(4096)
+	headerLine, _, _ := bytes.Cut(peeked, []byte("\n"))
+
+	csvReader := csv.NewReader(reader)
+	decimalComma := bytes.Count(headerLine, []byte(";")) > bytes.Count(headerLine, []byte(","))
+	if decimalComma {
+		csvReader.Comma = ';'
+	}
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csv header can not be read: %w", err)
+	}
+
+	setters := make([]csvField, len(header))
+	for i, column := range header {
+		setter, ok := csvFields[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(column), " ", ""))]
+		if !ok {
+			return nil, fmt.Errorf("csv column %q is not an order field", column)
+		}
+		setters[i] = setter
+	}
+
+	rows := make([]Row, 0)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		row := Row{}
+		row.Line, _ = csvReader.FieldPos(0)
+		var parseError *csv.ParseError
+		if err != nil && !errors.As(err, &parseError) {
+			return nil, err
+		}
+		if parseError != nil {
+			row.Line = parseError.StartLine
+			row.Error = newRowError(constants.ImportRowIsNotValid)
+			rows = append(rows, row)
+			continue
+		}
+
+		for i, value := range record {
+			if errorResp := setters[i](&row.Request, value, decimalComma); errorResp != nil {
+				row.Error = errorResp
+			}
+		}
+		completeShippingAddress(&row.Request)
+		rows = append(rows, row)
+	}
+}
+
+// parseAmount reads amounts as they are exported, e.g. "1,234.50" or, with a decimal
+// comma, "1.234,50" and "1 234,50"; digit group separators are dropped.
+func parseAmount(value string, decimalComma bool) (float32, bool) {
+	groupSeparator := ","
+	if decimalComma {
+		groupSeparator = "."
+	}
+	value = strings.NewReplacer(groupSeparator, "", " ", "", "\u00A0", "", "\u202F", "", "'", "", "’", "").
+		Replace(strings.TrimSpace(value))
+	if decimalComma {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	amount, err := strconv.ParseFloat(value, 32)
+	return float32(amount), err == nil
+}
+
+// readNdjson reads one order json object per line; blank lines are skipped.
+func readNdjson(reader *bufio.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLine)
+
+	rows := make([]Row, 0)
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		row := Row{Line: line}
+		if err := json.Unmarshal(content, &row.Request); err != nil {
+			row.Error = newRowError(constants.ImportRowIsNotValid)
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ndjson line can not be read: %w", err)
+	}
+	return rows, nil
+}
+
+func newRowError(message string) *response.ErrorResponse {
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusBadRequest, message).
+		Build()
+	return &errorResponse
+}
@@ -0,0 +1,146 @@
+package imports
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+)
+
+func TestReadOrders_ReadsCsvByHeaderNames(t *testing.T) {
+	//Given
+	content := "\uFEFFOrderNumber,totalAmount,firstName,lastName,address,city,district,currencyCode\n" +
+		"1,12.5,Ahmet,Ata,\"Atatürk Cd. No: 5, Daire 3\",İstanbul,Silivri,TRY\n" +
+		"2,ten,Ayşe,Ata,Lorem,Ankara,Çankaya,TRY\n"
+
+	//When
+	rows, err := ReadOrders(strings.NewReader(content), CsvFormat)
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Nil(t, rows[0].Error)
+	assert.Equal(t, request.CreateOrderRequest{
+		OrderNumber:  "1",
+		FirstName:    "Ahmet",
+		LastName:     "Ata",
+		TotalAmount:  12.5,
+		Address:      "Atatürk Cd. No: 5, Daire 3",
+		City:         "İstanbul",
+		District:     "Silivri",
+		CurrencyCode: "TRY",
+	}, rows[0].Request)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, constants.TotalAmountIsNotValid, rows[1].Error.Message)
+}
+
+func TestReadOrders_ReadsItemsCouponCustomerAndAddressesOfCsv(t *testing.T) {
+	//Given
+	content := "orderNumber,items,couponCode,address,city,postalCode,countryCode,customerEmail,customerFirstName,customerLastName,billingAddress,billingCity,billingPostalCode,billingCountryCode\n" +
+		"1,MUG-WHT:2|TSHIRT-BLK-M:1,WELCOME10,Unter den Linden 1,Berlin,10117,DE,jane@example.com,Jane,Doe,Friedrichstraße 2,Berlin,10117,DE\n" +
+		"2,,,Lorem,Ankara,,,,,,,,,\n" +
+		"3,MUG-WHT,,Lorem,Ankara,,,,,,,,,\n"
+
+	//When
+	rows, err := ReadOrders(strings.NewReader(content), CsvFormat)
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Nil(t, rows[0].Error)
+	assert.Equal(t, request.CreateOrderRequest{
+		OrderNumber: "1",
+		Address:     "Unter den Linden 1",
+		City:        "Berlin",
+		CouponCode:  "WELCOME10",
+		Items:       []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}, {Sku: "TSHIRT-BLK-M", Quantity: 1}},
+		Customer:    &request.CustomerRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"},
+		ShippingAddress: &response.Address{
+			Lines: []string{"Unter den Linden 1"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE",
+		},
+		BillingAddress: &response.Address{
+			Lines: []string{"Friedrichstraße 2"}, City: "Berlin", PostalCode: "10117", CountryCode: "DE",
+		},
+	}, rows[0].Request)
+	assert.Nil(t, rows[1].Error)
+	assert.Equal(t, request.CreateOrderRequest{OrderNumber: "2", Address: "Lorem", City: "Ankara"}, rows[1].Request)
+	assert.Equal(t, constants.OrderItemsAreNotValid, rows[2].Error.Message)
+}
+
+func TestReadOrders_WhenCsvIsSeparatedWithSemicolons_ReadsFieldsContainingCommas(t *testing.T) {
+	//Given
+	content := "orderNumber;address\n1;Kızılay, No: 1\n2;\"a\";b\n3;Lorem\n"
+
+	//When
+	rows, err := ReadOrders(strings.NewReader(content), CsvFormat)
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "Kızılay, No: 1", rows[0].Request.Address)
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, constants.ImportRowIsNotValid, rows[1].Error.Message)
+	assert.Equal(t, "3", rows[2].Request.OrderNumber)
+}
+
+func TestReadOrders_ReadsCsvExportedForDecimalCommaLocale(t *testing.T) {
+	//Given
+	content := "\uFEFFOrder Number;Total Amount;Currency;Address;Status;Customer Id\n" +
+		"1;1.234,50;EUR;Kızılay, No: 1;2;customer-1\n" +
+		"2;10,00;EUR;Lorem;1;\n" +
+		"3;1,2,3;EUR;Lorem;1;\n"
+
+	//When
+	rows, err := ReadOrders(strings.NewReader(content), CsvFormat)
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Nil(t, rows[0].Error)
+	assert.Equal(t, request.CreateOrderRequest{
+		OrderNumber:  "1",
+		TotalAmount:  1234.5,
+		CurrencyCode: "EUR",
+		Address:      "Kızılay, No: 1",
+		CustomerId:   "customer-1",
+	}, rows[0].Request)
+	assert.Equal(t, float32(10), rows[1].Request.TotalAmount)
+	assert.Equal(t, constants.TotalAmountIsNotValid, rows[2].Error.Message)
+}
+
+func TestReadOrders_ReadsAmountsWithDigitGroupsOfCommaSeparatedCsv(t *testing.T) {
+	rows, err := ReadOrders(strings.NewReader("orderNumber,totalAmount\n1,\"1,234.50\"\n"), CsvFormat)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Nil(t, rows[0].Error)
+	assert.Equal(t, float32(1234.5), rows[0].Request.TotalAmount)
+}
+
+func TestReadOrders_WhenCsvColumnIsUnknown_ReturnsError(t *testing.T) {
+	_, err := ReadOrders(strings.NewReader("orderNumber,password\n1,secret\n"), CsvFormat)
+
+	assert.Error(t, err)
+}
+
+func TestReadOrders_ReadsNdjsonLinesAndReportsMalformedOnes(t *testing.T) {
+	//Given
+	content := `{"orderNumber": "1", "totalAmount": 12.5, "city": "İzmir"}` + "\n\n" +
+		`{"orderNumber": "2", "totalAmount": "12"}` + "\n" +
+		`{"orderNumber": "3"`
+
+	//When
+	rows, err := ReadOrders(strings.NewReader(content), NdjsonFormat)
+
+	//Then
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, request.CreateOrderRequest{OrderNumber: "1", TotalAmount: 12.5, City: "İzmir"}, rows[0].Request)
+	assert.Equal(t, []int{1, 3, 4}, []int{rows[0].Line, rows[1].Line, rows[2].Line})
+	assert.Equal(t, constants.ImportRowIsNotValid, rows[1].Error.Message)
+	assert.Equal(t, constants.ImportRowIsNotValid, rows[2].Error.Message)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"simple-order-api/cmd/app"
 )
 
@@ -16,6 +17,19 @@ import (
 // @license.name Apache 2.0
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description With the bearer started
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(app.RunImportCommand(os.Args[2:], os.Stdout))
+	}
+
 	app.StartServer()
 }
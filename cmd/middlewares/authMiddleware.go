@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+type AuthMiddleware struct {
+	config        models.AuthConfig
+	authenticator Authenticator
+}
+
+// NewAuthMiddleware authenticates bearer tokens, or api keys sent in the X-API-Key
+// header, and stores the caller as a models.Principal in both the gin and the request
+// context.
+func NewAuthMiddleware(config models.AuthConfig, authenticator Authenticator) gin.HandlerFunc {
+	middleware := &AuthMiddleware{
+		config:        config,
+		authenticator: authenticator,
+	}
+	return middleware.handle
+}
+
+func (middleware *AuthMiddleware) handle(c *gin.Context) {
+	if isReadRequest(c) && isPublicPath(c.Request.URL.Path, middleware.config.PublicPaths) {
+		c.Next()
+		return
+	}
+
+	authorization := c.GetHeader("Authorization")
+	if len(authorization) == 0 && isWebSocketHandshake(c) {
+		// browsers can not set headers on a WebSocket handshake, so the token may come as a query parameter
+		if accessToken := c.Query(constants.AccessTokenQuery); len(accessToken) > 0 {
+			authorization = bearerPrefix + accessToken
+		}
+	}
+
+	principal, errorResp := middleware.authenticator.Authenticate(c.Request.Context(), authorization, c.GetHeader(constants.ApiKeyHeader))
+	if errorResp != nil {
+		c.AbortWithStatusJSON(errorResp.StatusCode, errorResp)
+		return
+	}
+
+	if principal != nil {
+		setPrincipal(c, principal)
+	}
+	c.Next()
+}
+
+func setPrincipal(c *gin.Context, principal *models.Principal) {
+	c.Set(constants.Principal, principal)
+	c.Request = c.Request.WithContext(helpers.WithPrincipal(c.Request.Context(), principal))
+}
+
+func isWebSocketHandshake(c *gin.Context) bool {
+	return c.Request.Method == http.MethodGet && strings.EqualFold(c.GetHeader("Upgrade"), "websocket")
+}
+
+// isReadRequest reports whether the request only reads. Public paths are public for reads
+// alone, so that e.g. making the GraphiQL page on GET /graphql public never lets anonymous
+// callers run mutations through POST /graphql.
+func isReadRequest(c *gin.Context) bool {
+	return c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead
+}
+
+// isPublicPath matches a path against exact patterns or prefix patterns ending with "*".
+func isPublicPath(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(path, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if path == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func abortWithError(c *gin.Context, statusCode int, message string) {
+	errorResponse := response.NewErrorBuilder().
+		SetError(statusCode, message).
+		Build()
+	c.AbortWithStatusJSON(errorResponse.StatusCode, errorResponse)
+}
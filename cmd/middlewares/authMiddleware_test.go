@@ -0,0 +1,315 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/services"
+	"testing"
+	"time"
+)
+
+const testHmacSecret = "test-secret"
+
+func newAuthEngine(t *testing.T, config models.AuthConfig, apiKeyService services.ApiKeyService) *gin.Engine {
+	authenticator, err := NewAuthenticator(config, apiKeyService)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(NewAuthMiddleware(config, authenticator))
+	engine.GET("/orders", func(c *gin.Context) {
+		principal := helpers.GetPrincipal(c.Request.Context())
+		if principal == nil {
+			c.JSON(http.StatusOK, "anonymous")
+			return
+		}
+		c.JSON(http.StatusOK, principal.Subject)
+	})
+	engine.GET("/admin", RequireAuthentication(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.GET("/swagger/*any", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+func signHmacToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testHmacSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func sendAuthRequest(engine *gin.Engine, uri, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", uri, nil)
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func readErrorMessage(w *httptest.ResponseRecorder) string {
+	errResponse := response.ErrorResponse{}
+	_ = json.Unmarshal(w.Body.Bytes(), &errResponse)
+	return errResponse.Message
+}
+
+func sendApiKeyRequest(engine *gin.Engine, uri, apiKey string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", uri, nil)
+	req.Header.Set(constants.ApiKeyHeader, apiKey)
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthMiddleware_WhenNoJwtKeyIsConfigured_AcceptsOnlyApiKeys(t *testing.T) {
+	//Given
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	principal := &models.Principal{Subject: "key-1", Scopes: []string{"orders:read"}}
+	mockApiKeyService.On("Authenticate", mock.Anything, "soa_secret").Return(principal, nil)
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true}, mockApiKeyService)
+	token := signHmacToken(t, jwt.MapClaims{"sub": "customer-1", "exp": time.Now().Add(time.Minute).Unix()})
+
+	//When
+	withToken := sendAuthRequest(engine, "/orders", token)
+	withApiKey := sendApiKeyRequest(engine, "/orders", "soa_secret")
+
+	//Then
+	assert.Equal(t, http.StatusUnauthorized, withToken.Code)
+	assert.Equal(t, http.StatusOK, withApiKey.Code)
+	assert.Equal(t, `"key-1"`, withApiKey.Body.String())
+}
+
+func TestAuthMiddleware_WhenAuthIsDisabled_ChecksOnlyApiKeys(t *testing.T) {
+	//Given
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusUnauthorized, constants.ApiKeyIsNotValid).
+		Build()
+	mockApiKeyService.On("Authenticate", mock.Anything, "soa_unknown").Return(nil, &serviceErr)
+	engine := newAuthEngine(t, models.AuthConfig{HmacSecret: testHmacSecret}, mockApiKeyService)
+
+	//When
+	anonymous := sendAuthRequest(engine, "/orders", "")
+	withInvalidApiKey := sendApiKeyRequest(engine, "/orders", "soa_unknown")
+	anonymousAdmin := sendAuthRequest(engine, "/admin", "")
+
+	//Then
+	assert.Equal(t, http.StatusOK, anonymous.Code)
+	assert.Equal(t, `"anonymous"`, anonymous.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, withInvalidApiKey.Code)
+	assert.Equal(t, http.StatusUnauthorized, anonymousAdmin.Code)
+	assert.Equal(t, constants.AuthorizationTokenIsMissing, readErrorMessage(anonymousAdmin))
+}
+
+func TestAuthMiddleware_WithValidHmacToken_ExposesPrincipal(t *testing.T) {
+	//Given
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, HmacSecret: testHmacSecret, Issuer: "order-api"}, &mocks.MockApiKeyService{})
+	token := signHmacToken(t, jwt.MapClaims{
+		"sub": "customer-1",
+		"iss": "order-api",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+
+	//When
+	w := sendAuthRequest(engine, "/orders", token)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"customer-1"`, w.Body.String())
+}
+
+func TestAuthMiddleware_WithInvalidTokens_ReturnsUnauthorized(t *testing.T) {
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, HmacSecret: testHmacSecret, Issuer: "order-api"}, &mocks.MockApiKeyService{})
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "customer-1", "iss": "order-api", "exp": time.Now().Add(time.Minute).Unix()}
+	}
+	expiredClaims := validClaims()
+	expiredClaims["exp"] = time.Now().Add(-time.Minute).Unix()
+	otherIssuerClaims := validClaims()
+	otherIssuerClaims["iss"] = "someone-else"
+	noSubjectClaims := validClaims()
+	delete(noSubjectClaims, "sub")
+	noExpiryClaims := validClaims()
+	delete(noExpiryClaims, "exp")
+	wrongSecretToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("wrong"))
+	noneToken, _ := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	tests := []struct {
+		name            string
+		token           string
+		expectedMessage string
+	}{
+		{"missing token", "", constants.AuthorizationTokenIsMissing},
+		{"malformed token", "not-a-jwt", constants.AuthorizationTokenIsNotValid},
+		{"expired token", signHmacToken(t, expiredClaims), constants.AuthorizationTokenIsNotValid},
+		{"other issuer", signHmacToken(t, otherIssuerClaims), constants.AuthorizationTokenIsNotValid},
+		{"no subject", signHmacToken(t, noSubjectClaims), constants.AuthorizationTokenIsNotValid},
+		{"no expiry", signHmacToken(t, noExpiryClaims), constants.AuthorizationTokenIsNotValid},
+		{"wrong secret", wrongSecretToken, constants.AuthorizationTokenIsNotValid},
+		{"none algorithm", noneToken, constants.AuthorizationTokenIsNotValid},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//When
+			w := sendAuthRequest(engine, "/orders", test.token)
+
+			//Then
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, test.expectedMessage, readErrorMessage(w))
+		})
+	}
+}
+
+func TestAuthMiddleware_WithRsaTokenSignedByJwksKey_ExposesPrincipal(t *testing.T) {
+	//Given
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}},
+	}
+	jwksBytes, _ := json.Marshal(jwks)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksFile, jwksBytes, 0600))
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, JwksFile: jwksFile}, &mocks.MockApiKeyService{})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "operator-1",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "key-1"
+	signedToken, err := token.SignedString(privateKey)
+	require.NoError(t, err)
+
+	//When
+	w := sendAuthRequest(engine, "/orders", signedToken)
+	hmacResp := sendAuthRequest(engine, "/orders", signHmacToken(t, jwt.MapClaims{"sub": "customer-1"}))
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"operator-1"`, w.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, hmacResp.Code)
+}
+
+func TestAuthMiddleware_WhenPathIsPublic_SkipsAuthentication(t *testing.T) {
+	//Given
+	engine := newAuthEngine(t, models.AuthConfig{
+		Enabled:     true,
+		HmacSecret:  testHmacSecret,
+		PublicPaths: []string{"/swagger/*"},
+	}, &mocks.MockApiKeyService{})
+
+	//When
+	w := sendAuthRequest(engine, "/swagger/index.html", "")
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthMiddleware_WhenPublicPathIsWrittenTo_RequiresAuthentication(t *testing.T) {
+	//Given
+	engine := newAuthEngine(t, models.AuthConfig{
+		Enabled:     true,
+		HmacSecret:  testHmacSecret,
+		PublicPaths: []string{"/graphql"},
+	}, &mocks.MockApiKeyService{})
+	engine.GET("/graphql", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.POST("/graphql", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/graphql", nil)
+
+	//When
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, http.StatusOK, sendAuthRequest(engine, "/graphql", "").Code)
+}
+
+func TestAuthMiddleware_WithValidApiKey_ExposesPrincipal(t *testing.T) {
+	//Given
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	principal := &models.Principal{Subject: "key-1", Scopes: []string{"orders:read"}}
+	mockApiKeyService.On("Authenticate", mock.Anything, "soa_secret").Return(principal, nil)
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, HmacSecret: testHmacSecret}, mockApiKeyService)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set(constants.ApiKeyHeader, "soa_secret")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"key-1"`, w.Body.String())
+}
+
+func TestAuthMiddleware_WithInvalidApiKey_ReturnsUnauthorized(t *testing.T) {
+	//Given
+	mockApiKeyService := &mocks.MockApiKeyService{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusUnauthorized, constants.ApiKeyIsNotValid).
+		Build()
+	mockApiKeyService.On("Authenticate", mock.Anything, mock.Anything).Return(nil, &serviceErr)
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, HmacSecret: testHmacSecret}, mockApiKeyService)
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set(constants.ApiKeyHeader, "soa_unknown")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, constants.ApiKeyIsNotValid, readErrorMessage(w))
+}
+
+func TestAuthMiddleware_WithAccessTokenQuery_AuthenticatesOnlyWebSocketHandshakes(t *testing.T) {
+	//Given
+	engine := newAuthEngine(t, models.AuthConfig{Enabled: true, HmacSecret: testHmacSecret}, &mocks.MockApiKeyService{})
+	token := signHmacToken(t, jwt.MapClaims{"sub": "customer-1", "exp": time.Now().Add(time.Minute).Unix()})
+	handshake := httptest.NewRecorder()
+	plainRequest := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders?"+constants.AccessTokenQuery+"="+token, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	engine.ServeHTTP(handshake, req)
+	req, _ = http.NewRequest("GET", "/orders?"+constants.AccessTokenQuery+"="+token, nil)
+	engine.ServeHTTP(plainRequest, req)
+
+	//Then
+	assert.Equal(t, http.StatusOK, handshake.Code)
+	assert.Equal(t, `"customer-1"`, handshake.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, plainRequest.Code)
+}
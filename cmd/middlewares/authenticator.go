@@ -0,0 +1,132 @@
+package middlewares
+
+import (
+	"context"
+	"github.com/golang-jwt/jwt/v4"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/services"
+	"strings"
+	"time"
+)
+
+const bearerPrefix = "Bearer "
+
+// Authenticator resolves the caller of a request from its api key or, when no api key
+// is sent, from its "Bearer <jwt>" authorization value. It is shared by the http and
+// the grpc servers.
+//
+//go:generate mockery --name=Authenticator --structname=MockAuthenticator --output=../mocks --filename=fakeAuthenticatorWithMockery.go
+type Authenticator interface {
+	// Authenticate returns a nil principal for anonymous callers.
+	Authenticate(ctx context.Context, authorization string, apiKey string) (*models.Principal, *response.ErrorResponse)
+}
+
+type JwtAuthenticator struct {
+	config        models.AuthConfig
+	keySet        *jwtKeySet
+	parser        *jwt.Parser
+	apiKeyService services.ApiKeyService
+}
+
+// NewAuthenticator validates HS256 and RS256 signed bearer tokens with the configured
+// keys and delegates api keys to the api key service. When authentication is disabled
+// only api keys are checked and requests without one stay anonymous.
+func NewAuthenticator(config models.AuthConfig, apiKeyService services.ApiKeyService) (Authenticator, error) {
+	keySet, err := loadJwtKeySet(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JwtAuthenticator{
+		config:        config,
+		keySet:        keySet,
+		apiKeyService: apiKeyService,
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodRS256.Alg(),
+		})),
+	}, nil
+}
+
+func (authenticator *JwtAuthenticator) Authenticate(ctx context.Context, authorization string, apiKey string) (*models.Principal, *response.ErrorResponse) {
+	if len(apiKey) > 0 {
+		return authenticator.apiKeyService.Authenticate(ctx, apiKey)
+	}
+
+	if !authenticator.config.Enabled {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusUnauthorized, constants.AuthorizationTokenIsMissing).
+			Build()
+		return nil, &errorResponse
+	}
+
+	principal, ok := authenticator.authenticateToken(strings.TrimPrefix(authorization, bearerPrefix))
+	if !ok {
+		errorResponse := response.NewErrorBuilder().
+			SetError(http.StatusUnauthorized, constants.AuthorizationTokenIsNotValid).
+			Build()
+		return nil, &errorResponse
+	}
+
+	return principal, nil
+}
+
+func (authenticator *JwtAuthenticator) authenticateToken(tokenString string) (*models.Principal, bool) {
+	claims := jwt.MapClaims{}
+	token, err := authenticator.parser.ParseWithClaims(tokenString, claims, authenticator.keySet.keyFunc)
+	if err != nil || !token.Valid {
+		return nil, false
+	}
+
+	// the parser checks exp only when it is present, and tokens must not live forever
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, false
+	}
+
+	hasIssuer := len(authenticator.config.Issuer) > 0
+	if hasIssuer && !claims.VerifyIssuer(authenticator.config.Issuer, true) {
+		return nil, false
+	}
+
+	hasAudience := len(authenticator.config.Audience) > 0
+	if hasAudience && !claims.VerifyAudience(authenticator.config.Audience, true) {
+		return nil, false
+	}
+
+	subject, _ := claims["sub"].(string)
+	if len(strings.TrimSpace(subject)) == 0 {
+		return nil, false
+	}
+
+	return &models.Principal{
+		Type:    models.UserPrincipal,
+		Subject: subject,
+		Roles:   getRoles(claims),
+		Claims:  claims,
+	}, true
+}
+
+// getRoles reads the "roles" claim, which may be a list or a single space separated string.
+func getRoles(claims jwt.MapClaims) []string {
+	switch roles := claims["roles"].(type) {
+	case string:
+		return strings.Fields(roles)
+	case []interface{}:
+		result := make([]string, 0, len(roles))
+		for _, role := range roles {
+			if roleName, ok := role.(string); ok {
+				result = append(result, roleName)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
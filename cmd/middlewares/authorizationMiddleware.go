@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/policies"
+)
+
+// RequirePermission rejects the request with 403 unless the authenticated principal is granted the permission.
+func RequirePermission(permission policies.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := helpers.GetPrincipal(c.Request.Context())
+		if !policies.HasPermission(principal, permission) {
+			abortWithError(c, http.StatusForbidden, constants.PermissionDenied)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAuthentication rejects anonymous requests with 401, even when authentication is
+// disabled and the other routes are open.
+func RequireAuthentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if helpers.GetPrincipal(c.Request.Context()) == nil {
+			abortWithError(c, http.StatusUnauthorized, constants.AuthorizationTokenIsMissing)
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"simple-order-api/cmd/models"
+	"strconv"
+	"strings"
+)
+
+const anyOrigin = "*"
+
+type CorsMiddleware struct {
+	config         models.CorsConfig
+	allowedMethods string
+	allowedHeaders string
+	exposedHeaders string
+	maxAge         string
+}
+
+// NewCorsMiddleware answers preflight requests and adds the CORS headers for origins
+// matching the configured list. Origins may contain a single "*" wildcard such as
+// "https://*.example.com", while a lone "*" allows every origin without credentials.
+func NewCorsMiddleware(config models.CorsConfig) (gin.HandlerFunc, error) {
+	if config.AllowCredentials && containsFold(config.AllowedOrigins, anyOrigin) {
+		return nil, errors.New("cors credentials can not be allowed for every origin")
+	}
+
+	middleware := &CorsMiddleware{
+		config:         config,
+		allowedMethods: strings.Join(config.AllowedMethods, ", "),
+		allowedHeaders: strings.Join(config.AllowedHeaders, ", "),
+		exposedHeaders: strings.Join(config.ExposedHeaders, ", "),
+		maxAge:         strconv.Itoa(int(config.MaxAge.Seconds())),
+	}
+	return middleware.handle, nil
+}
+
+func (middleware *CorsMiddleware) handle(c *gin.Context) {
+	origin := c.GetHeader("Origin")
+	if len(origin) == 0 {
+		c.Next()
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Add("Vary", "Origin")
+	isPreflight := c.Request.Method == http.MethodOptions && len(c.GetHeader("Access-Control-Request-Method")) > 0
+	if isPreflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if !middleware.isAllowedOrigin(origin) {
+		if isPreflight {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+		return
+	}
+
+	if containsFold(middleware.config.AllowedOrigins, anyOrigin) {
+		header.Set("Access-Control-Allow-Origin", anyOrigin)
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if middleware.config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+
+	if isPreflight {
+		middleware.handlePreflight(c)
+		return
+	}
+
+	if len(middleware.exposedHeaders) > 0 {
+		header.Set("Access-Control-Expose-Headers", middleware.exposedHeaders)
+	}
+	c.Next()
+}
+
+func (middleware *CorsMiddleware) handlePreflight(c *gin.Context) {
+	if !containsFold(middleware.config.AllowedMethods, c.GetHeader("Access-Control-Request-Method")) {
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	for _, requestedHeader := range strings.Split(c.GetHeader("Access-Control-Request-Headers"), ",") {
+		requestedHeader = strings.TrimSpace(requestedHeader)
+		if len(requestedHeader) > 0 && !containsFold(middleware.config.AllowedHeaders, requestedHeader) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+
+	header := c.Writer.Header()
+	header.Set("Access-Control-Allow-Methods", middleware.allowedMethods)
+	header.Set("Access-Control-Allow-Headers", middleware.allowedHeaders)
+	if middleware.config.MaxAge > 0 {
+		header.Set("Access-Control-Max-Age", middleware.maxAge)
+	}
+	c.AbortWithStatus(http.StatusNoContent)
+}
+
+func (middleware *CorsMiddleware) isAllowedOrigin(origin string) bool {
+	return IsAllowedOrigin(middleware.config.AllowedOrigins, origin)
+}
+
+// IsAllowedOrigin reports whether origin matches one of the allowed origin patterns.
+func IsAllowedOrigin(allowedOrigins []string, origin string) bool {
+	for _, pattern := range allowedOrigins {
+		if pattern == anyOrigin || strings.EqualFold(pattern, origin) {
+			return true
+		}
+
+		prefix, suffix, hasWildcard := strings.Cut(strings.ToLower(pattern), "*")
+		lowerOrigin := strings.ToLower(origin)
+		if hasWildcard && len(lowerOrigin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(lowerOrigin, prefix) && strings.HasSuffix(lowerOrigin, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,145 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/models"
+	"testing"
+	"time"
+)
+
+func newCorsConfig() models.CorsConfig {
+	return models.CorsConfig{
+		AllowedOrigins:   []string{"https://shop.example.com", "https://*.partner.com"},
+		AllowedMethods:   []string{"GET", "POST", "PATCH"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"RateLimit-Remaining"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+}
+
+func newCorsEngine(t *testing.T, config models.CorsConfig) *gin.Engine {
+	corsMiddleware, err := NewCorsMiddleware(config)
+	require.NoError(t, err)
+
+	engine := gin.New()
+	engine.Use(corsMiddleware)
+	engine.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return engine
+}
+
+func sendCorsRequest(engine *gin.Engine, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/orders", nil)
+	req.Header.Set("Origin", origin)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestNewCorsMiddleware_WhenCredentialsAreAllowedForEveryOrigin_ReturnsError(t *testing.T) {
+	//Given
+	config := models.CorsConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}
+
+	//When
+	_, err := NewCorsMiddleware(config)
+
+	//Then
+	assert.Error(t, err)
+}
+
+func TestCorsMiddleware_WithAllowedOrigin_SetsCorsHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+	}{
+		{"exact origin", "https://shop.example.com"},
+		{"wildcard origin", "https://eu.partner.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := newCorsEngine(t, newCorsConfig())
+
+			//When
+			w := sendCorsRequest(engine, "GET", test.origin, nil)
+
+			//Then
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, test.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, "RateLimit-Remaining", w.Header().Get("Access-Control-Expose-Headers"))
+			assert.Contains(t, w.Header().Values("Vary"), "Origin")
+		})
+	}
+}
+
+func TestCorsMiddleware_WithUnknownOrigin_OmitsCorsHeaders(t *testing.T) {
+	//Given
+	engine := newCorsEngine(t, newCorsConfig())
+
+	//When
+	w := sendCorsRequest(engine, "GET", "https://partner.com.evil.org", nil)
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCorsMiddleware_WithEveryOriginAllowed_ReturnsWildcard(t *testing.T) {
+	//Given
+	config := newCorsConfig()
+	config.AllowedOrigins = []string{"*"}
+	config.AllowCredentials = false
+	engine := newCorsEngine(t, config)
+
+	//When
+	w := sendCorsRequest(engine, "GET", "https://anything.org", nil)
+
+	//Then
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCorsMiddleware_Preflight(t *testing.T) {
+	tests := []struct {
+		name           string
+		origin         string
+		method         string
+		headers        string
+		expectedStatus int
+	}{
+		{"allowed request", "https://shop.example.com", "PATCH", "authorization, content-type", http.StatusNoContent},
+		{"unknown origin", "https://evil.org", "PATCH", "", http.StatusForbidden},
+		{"method not allowed", "https://shop.example.com", "DELETE", "", http.StatusForbidden},
+		{"header not allowed", "https://shop.example.com", "GET", "X-Custom", http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			engine := newCorsEngine(t, newCorsConfig())
+
+			//When
+			w := sendCorsRequest(engine, "OPTIONS", test.origin, map[string]string{
+				"Access-Control-Request-Method":  test.method,
+				"Access-Control-Request-Headers": test.headers,
+			})
+
+			//Then
+			assert.Equal(t, test.expectedStatus, w.Code)
+			if test.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, "GET, POST, PATCH", w.Header().Get("Access-Control-Allow-Methods"))
+				assert.Equal(t, "Content-Type, Authorization", w.Header().Get("Access-Control-Allow-Headers"))
+				assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+			}
+		})
+	}
+}
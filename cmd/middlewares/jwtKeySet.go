@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"math/big"
+	"os"
+	"simple-order-api/cmd/models"
+)
+
+type jwtKeySet struct {
+	hmacSecret    []byte
+	rsaKey        *rsa.PublicKey
+	rsaKeysByKeId map[string]*rsa.PublicKey
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	KeyType  string `json:"kty"`
+	KeyId    string `json:"kid"`
+	Use      string `json:"use"`
+	Modulus  string `json:"n"`
+	Exponent string `json:"e"`
+}
+
+// loadJwtKeySet reads the configured verification keys. Without any key every bearer
+// token is refused and callers can only authenticate with api keys.
+func loadJwtKeySet(config models.AuthConfig) (*jwtKeySet, error) {
+	keySet := &jwtKeySet{rsaKeysByKeId: map[string]*rsa.PublicKey{}}
+	if len(config.HmacSecret) > 0 {
+		keySet.hmacSecret = []byte(config.HmacSecret)
+	}
+
+	if len(config.RsaPublicKeyFile) > 0 {
+		pemBytes, err := os.ReadFile(config.RsaPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		keySet.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.JwksFile) > 0 {
+		if err := keySet.loadJwksFile(config.JwksFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return keySet, nil
+}
+
+func (keySet *jwtKeySet) loadJwksFile(path string) error {
+	jwksBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	jwks := jsonWebKeySet{}
+	if err := json.Unmarshal(jwksBytes, &jwks); err != nil {
+		return err
+	}
+
+	for _, key := range jwks.Keys {
+		if key.KeyType != "RSA" || (len(key.Use) > 0 && key.Use != "sig") {
+			continue
+		}
+
+		publicKey, err := key.rsaPublicKey()
+		if err != nil {
+			return fmt.Errorf("jwks key %q: %w", key.KeyId, err)
+		}
+		keySet.rsaKeysByKeId[key.KeyId] = publicKey
+	}
+
+	return nil
+}
+
+func (key jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(key.Modulus)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent, err := base64.RawURLEncoding.DecodeString(key.Exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+// keyFunc picks the verification key that matches the signing method of the token.
+func (keySet *jwtKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if keySet.hmacSecret == nil {
+			return nil, errors.New("hmac signed tokens are not accepted")
+		}
+		return keySet.hmacSecret, nil
+	case *jwt.SigningMethodRSA:
+		keyId, _ := token.Header["kid"].(string)
+		if publicKey, ok := keySet.rsaKeysByKeId[keyId]; ok {
+			return publicKey, nil
+		}
+		if keySet.rsaKey != nil {
+			return keySet.rsaKey, nil
+		}
+		return nil, fmt.Errorf("no rsa key found for kid %q", keyId)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+}
@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RateLimitMiddleware struct {
+	store    RateLimitStore
+	ruleOf   func(method, route string) models.RateLimitRule
+	clientOf func(c *gin.Context) string
+}
+
+// NewRateLimitMiddleware limits each client ip address per route by the rule
+// multiplied by ipLimitFactor. It runs before authentication, so that
+// unauthenticated requests and guessed credentials are limited too.
+func NewRateLimitMiddleware(config models.RateLimitConfig, store RateLimitStore) gin.HandlerFunc {
+	middleware := &RateLimitMiddleware{
+		store:  store,
+		ruleOf: config.IpRuleOf,
+		clientOf: func(c *gin.Context) string {
+			return "ip:" + c.ClientIP()
+		},
+	}
+	return middleware.handle
+}
+
+// NewClientRateLimitMiddleware limits each api key or user per route. It runs after
+// authentication; anonymous requests are limited per ip address.
+func NewClientRateLimitMiddleware(config models.RateLimitConfig, store RateLimitStore) gin.HandlerFunc {
+	middleware := &RateLimitMiddleware{
+		store:  store,
+		ruleOf: config.RuleOf,
+		clientOf: func(c *gin.Context) string {
+			return RateLimitClientOf(helpers.GetPrincipal(c.Request.Context()), c.ClientIP())
+		},
+	}
+	return middleware.handle
+}
+
+// RateLimitClientOf returns the rate limit key of the principal, or of the ip address
+// when the caller is anonymous.
+func RateLimitClientOf(principal *models.Principal, ip string) string {
+	if principal == nil || len(principal.Subject) == 0 {
+		return "anonymous:" + ip
+	}
+
+	return principal.Type + ":" + principal.Subject
+}
+
+func (middleware *RateLimitMiddleware) handle(c *gin.Context) {
+	route := c.FullPath()
+	if len(route) == 0 {
+		c.Next()
+		return
+	}
+
+	rule := middleware.ruleOf(c.Request.Method, route)
+	if rule.Limit <= 0 || rule.Period <= 0 {
+		c.Next()
+		return
+	}
+
+	key := strings.Join([]string{middleware.clientOf(c), c.Request.Method, route}, "|")
+	result, err := middleware.store.Take(c.Request.Context(), key, rule)
+	if err != nil {
+		// An unavailable store must not take the api down, so the request is let through.
+		c.Next()
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+	if !result.Allowed {
+		header.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+		abortWithError(c, http.StatusTooManyRequests, constants.RateLimitExceeded)
+		return
+	}
+
+	c.Next()
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,211 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models"
+	"testing"
+	"time"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, models.RateLimitRule) (RateLimitResult, error) {
+	return RateLimitResult{}, errors.New("store is unavailable")
+}
+
+func newRateLimitEngine(config models.RateLimitConfig, store RateLimitStore) *gin.Engine {
+	engine := gin.New()
+	engine.Use(NewRateLimitMiddleware(config, store))
+	engine.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.POST("/orders", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return engine
+}
+
+// newClientRateLimitEngine authenticates the api key header as the principal's subject
+// between the ip and the client rate limits.
+func newClientRateLimitEngine(config models.RateLimitConfig, store RateLimitStore) *gin.Engine {
+	engine := gin.New()
+	engine.Use(NewRateLimitMiddleware(config, store))
+	engine.Use(func(c *gin.Context) {
+		if apiKey := c.GetHeader(constants.ApiKeyHeader); len(apiKey) > 0 {
+			setPrincipal(c, &models.Principal{Type: models.ApiKeyPrincipal, Subject: apiKey})
+		}
+		c.Next()
+	})
+	engine.Use(NewClientRateLimitMiddleware(config, store))
+	engine.GET("/orders", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return engine
+}
+
+func sendRateLimitedRequestWithApiKey(engine *gin.Engine, remoteAddr, apiKey string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.RemoteAddr = remoteAddr
+	if len(apiKey) > 0 {
+		req.Header.Set(constants.ApiKeyHeader, apiKey)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func sendRateLimitedRequest(engine *gin.Engine, method, remoteAddr string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, "/orders", nil)
+	req.RemoteAddr = remoteAddr
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddleware_WhenLimitIsExceeded_ReturnsTooManyRequests(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 2, Period: time.Minute}}
+	engine := newRateLimitEngine(config, NewMemoryRateLimitStore())
+
+	//When
+	first := sendRateLimitedRequest(engine, "GET", "10.0.0.1:1234")
+	second := sendRateLimitedRequest(engine, "GET", "10.0.0.1:1234")
+	third := sendRateLimitedRequest(engine, "GET", "10.0.0.1:1234")
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "0", second.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+	assert.Equal(t, "30", third.Header().Get("Retry-After"))
+	assert.Equal(t, "60", third.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, constants.RateLimitExceeded, readErrorMessage(third))
+}
+
+func TestRateLimitMiddleware_LimitsClientsAndRoutesSeparately(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{
+		Default: models.RateLimitRule{Limit: 1, Period: time.Minute},
+		Routes: []models.RouteRateLimitConfig{
+			{Method: "POST", Path: "/orders", RateLimitRule: models.RateLimitRule{Limit: 3, Period: time.Minute}},
+		},
+	}
+	engine := newRateLimitEngine(config, NewMemoryRateLimitStore())
+
+	//When
+	firstClient := sendRateLimitedRequest(engine, "GET", "10.0.0.1:1234")
+	secondClient := sendRateLimitedRequest(engine, "GET", "10.0.0.2:1234")
+	otherRoute := sendRateLimitedRequest(engine, "POST", "10.0.0.1:1234")
+
+	//Then
+	assert.Equal(t, http.StatusOK, firstClient.Code)
+	assert.Equal(t, http.StatusOK, secondClient.Code)
+	assert.Equal(t, http.StatusCreated, otherRoute.Code)
+	assert.Equal(t, "3", otherRoute.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_LimitsIpAddressWhateverCredentialsAreSent(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 1, Period: time.Minute}}
+	engine := newRateLimitEngine(config, NewMemoryRateLimitStore())
+	sendWithApiKey := func(apiKey string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/orders", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set(constants.ApiKeyHeader, apiKey)
+		engine.ServeHTTP(w, req)
+		return w
+	}
+
+	//When
+	first := sendWithApiKey("soa_first")
+	second := sendWithApiKey("soa_second")
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+}
+
+func TestRateLimitMiddleware_LimitsIpAddressToFactorOfRule(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 1, Period: time.Minute}, IpLimitFactor: 2}
+	engine := newClientRateLimitEngine(config, NewMemoryRateLimitStore())
+
+	//When
+	first := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "soa_first")
+	second := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "soa_second")
+	third := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "soa_third")
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, http.StatusTooManyRequests, third.Code)
+}
+
+func TestClientRateLimitMiddleware_LimitsApiKeysWhateverIpAddressTheyAreSentFrom(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 1, Period: time.Minute}, IpLimitFactor: 10}
+	engine := newClientRateLimitEngine(config, NewMemoryRateLimitStore())
+
+	//When
+	first := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "soa_first")
+	otherKeyOnSameIp := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "soa_second")
+	sameKeyOnOtherIp := sendRateLimitedRequestWithApiKey(engine, "10.0.0.2:1234", "soa_first")
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, otherKeyOnSameIp.Code)
+	assert.Equal(t, http.StatusTooManyRequests, sameKeyOnOtherIp.Code)
+	assert.Equal(t, constants.RateLimitExceeded, readErrorMessage(sameKeyOnOtherIp))
+}
+
+func TestClientRateLimitMiddleware_WhenRequestIsAnonymous_LimitsIpAddress(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 1, Period: time.Minute}, IpLimitFactor: 10}
+	engine := newClientRateLimitEngine(config, NewMemoryRateLimitStore())
+
+	//When
+	first := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "")
+	second := sendRateLimitedRequestWithApiKey(engine, "10.0.0.1:1234", "")
+	otherIp := sendRateLimitedRequestWithApiKey(engine, "10.0.0.2:1234", "")
+
+	//Then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, http.StatusOK, otherIp.Code)
+}
+
+func TestRateLimitMiddleware_WhenStoreFails_LetsRequestThrough(t *testing.T) {
+	//Given
+	config := models.RateLimitConfig{Default: models.RateLimitRule{Limit: 1, Period: time.Minute}}
+	engine := newRateLimitEngine(config, failingRateLimitStore{})
+
+	//When
+	w := sendRateLimitedRequest(engine, "GET", "10.0.0.1:1234")
+
+	//Then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestMemoryRateLimitStore_RefillsTokensOverTime(t *testing.T) {
+	//Given
+	now := time.Now()
+	store := &MemoryRateLimitStore{buckets: map[string]*tokenBucket{}, now: func() time.Time { return now }}
+	rule := models.RateLimitRule{Limit: 2, Period: 2 * time.Second}
+	_, _ = store.Take(context.Background(), "client", rule)
+	_, _ = store.Take(context.Background(), "client", rule)
+	denied, _ := store.Take(context.Background(), "client", rule)
+
+	//When
+	now = now.Add(time.Second)
+	refilled, _ := store.Take(context.Background(), "client", rule)
+
+	//Then
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, time.Second, denied.RetryAfter)
+	assert.True(t, refilled.Allowed)
+	assert.Equal(t, 0, refilled.Remaining)
+}
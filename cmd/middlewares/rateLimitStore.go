@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"context"
+	"math"
+	"simple-order-api/cmd/models"
+	"sync"
+	"time"
+)
+
+const bucketSweepInterval = time.Minute
+
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// RateLimitStore takes a token from the bucket identified by key. The in-memory
+// store limits a single instance; implement it on a shared store to limit a cluster.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, rule models.RateLimitRule) (RateLimitResult, error)
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+type MemoryRateLimitStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+	now     func() time.Time
+}
+
+func (store *MemoryRateLimitStore) Take(_ context.Context, key string, rule models.RateLimitRule) (RateLimitResult, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	capacity := float64(rule.Limit)
+	refillPerSecond := capacity / rule.Period.Seconds()
+	bucket, ok := store.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		store.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.updatedAt).Seconds()
+	bucket.tokens = math.Min(capacity, bucket.tokens+elapsed*refillPerSecond)
+	bucket.updatedAt = now
+
+	result := RateLimitResult{Limit: rule.Limit}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - bucket.tokens) / refillPerSecond)
+	}
+
+	result.Remaining = int(bucket.tokens)
+	result.Reset = secondsToDuration((capacity - bucket.tokens) / refillPerSecond)
+	bucket.fullAt = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely since they behave like new ones.
+func (store *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(store.sweptAt) < bucketSweepInterval {
+		return
+	}
+
+	for key, bucket := range store.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(store.buckets, key)
+		}
+	}
+	store.sweptAt = now
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*tokenBucket{},
+		now:     time.Now,
+	}
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+)
+
+const maxRequestIdLength = 128
+
+// RequestIdMiddleware keeps the X-Request-ID sent by the caller, or generates one, and
+// exposes it in the request context and the response.
+func RequestIdMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(constants.RequestIdHeader)
+		if len(requestId) == 0 || len(requestId) > maxRequestIdLength {
+			requestId = newRequestId()
+		}
+
+		c.Set(constants.RequestId, requestId)
+		c.Request = c.Request.WithContext(helpers.WithRequestId(c.Request.Context(), requestId))
+		c.Writer.Header().Set(constants.RequestIdHeader, requestId)
+		c.Next()
+	}
+}
+
+func newRequestId() string {
+	randomBytes := make([]byte, 16)
+	_, _ = rand.Read(randomBytes)
+	return hex.EncodeToString(randomBytes)
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"testing"
+)
+
+func newRequestIdEngine() *gin.Engine {
+	engine := gin.New()
+	engine.Use(RequestIdMiddleware())
+	engine.GET("/orders", func(c *gin.Context) {
+		c.String(http.StatusOK, helpers.GetRequestId(c.Request.Context()))
+	})
+	return engine
+}
+
+func TestRequestIdMiddleware_WhenHeaderIsSent_KeepsRequestId(t *testing.T) {
+	//Given
+	engine := newRequestIdEngine()
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	req.Header.Set(constants.RequestIdHeader, "request-1")
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Equal(t, "request-1", w.Body.String())
+	assert.Equal(t, "request-1", w.Header().Get(constants.RequestIdHeader))
+}
+
+func TestRequestIdMiddleware_WhenHeaderIsMissing_GeneratesRequestId(t *testing.T) {
+	//Given
+	engine := newRequestIdEngine()
+	w := httptest.NewRecorder()
+
+	//When
+	req, _ := http.NewRequest("GET", "/orders", nil)
+	engine.ServeHTTP(w, req)
+
+	//Then
+	assert.Len(t, w.Body.String(), 32)
+	assert.Equal(t, w.Body.String(), w.Header().Get(constants.RequestIdHeader))
+}
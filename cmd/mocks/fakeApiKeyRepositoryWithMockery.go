@@ -0,0 +1,139 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+	time "time"
+)
+
+// MockApiKeyRepository is an autogenerated mock type for the ApiKeyRepository type
+type MockApiKeyRepository struct {
+	mock.Mock
+}
+
+// FetchApiKeyByHash provides a mock function with given fields: ctx, keyHash
+func (_m *MockApiKeyRepository) FetchApiKeyByHash(ctx context.Context, keyHash string) (*response.ApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx, keyHash)
+
+	var r0 *response.ApiKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ApiKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchApiKeyById provides a mock function with given fields: ctx, id
+func (_m *MockApiKeyRepository) FetchApiKeyById(ctx context.Context, id string) (*response.ApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ApiKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ApiKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchApiKeys provides a mock function with given fields: ctx
+func (_m *MockApiKeyRepository) FetchApiKeys(ctx context.Context) ([]response.ApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.ApiKey
+	if rf, ok := ret.Get(0).(func(context.Context) []response.ApiKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.ApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// RecordApiKeyUsage provides a mock function with given fields: ctx, id, usedAt
+func (_m *MockApiKeyRepository) RecordApiKeyUsage(ctx context.Context, id string, usedAt time.Time) *response.ErrorResponse {
+	ret := _m.Called(ctx, id, usedAt)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// SaveApiKey provides a mock function with given fields: ctx, apiKey
+func (_m *MockApiKeyRepository) SaveApiKey(ctx context.Context, apiKey response.ApiKey) *response.ErrorResponse {
+	ret := _m.Called(ctx, apiKey)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.ApiKey) *response.ErrorResponse); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockApiKeyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockApiKeyRepository creates a new instance of MockApiKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockApiKeyRepository(t mockConstructorTestingTNewMockApiKeyRepository) *MockApiKeyRepository {
+	mock := &MockApiKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
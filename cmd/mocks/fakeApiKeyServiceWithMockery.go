@@ -0,0 +1,164 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "simple-order-api/cmd/models"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockApiKeyService is an autogenerated mock type for the ApiKeyService type
+type MockApiKeyService struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, key
+func (_m *MockApiKeyService) Authenticate(ctx context.Context, key string) (*models.Principal, *response.ErrorResponse) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *models.Principal
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Principal); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Principal)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateApiKey provides a mock function with given fields: ctx, createApiKeyRequest
+func (_m *MockApiKeyService) CreateApiKey(ctx context.Context, createApiKeyRequest request.CreateApiKeyRequest) (*response.CreatedApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx, createApiKeyRequest)
+
+	var r0 *response.CreatedApiKey
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateApiKeyRequest) *response.CreatedApiKey); ok {
+		r0 = rf(ctx, createApiKeyRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.CreatedApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, request.CreateApiKeyRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, createApiKeyRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetApiKeys provides a mock function with given fields: ctx
+func (_m *MockApiKeyService) GetApiKeys(ctx context.Context) ([]response.ApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.ApiKey
+	if rf, ok := ret.Get(0).(func(context.Context) []response.ApiKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.ApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// RegisterApiKey provides a mock function with given fields: ctx, name, key, scopes
+func (_m *MockApiKeyService) RegisterApiKey(ctx context.Context, name string, key string, scopes []string) *response.ErrorResponse {
+	ret := _m.Called(ctx, name, key, scopes)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, name, key, scopes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, id
+func (_m *MockApiKeyService) RevokeApiKey(ctx context.Context, id string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// RotateApiKey provides a mock function with given fields: ctx, id
+func (_m *MockApiKeyService) RotateApiKey(ctx context.Context, id string) (*response.CreatedApiKey, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.CreatedApiKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.CreatedApiKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.CreatedApiKey)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockApiKeyService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockApiKeyService creates a new instance of MockApiKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockApiKeyService(t mockConstructorTestingTNewMockApiKeyService) *MockApiKeyService {
+	mock := &MockApiKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
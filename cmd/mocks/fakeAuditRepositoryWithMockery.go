@@ -0,0 +1,72 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockAuditRepository is an autogenerated mock type for the AuditRepository type
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+// FetchAuditEntries provides a mock function with given fields: ctx, orderNumber
+func (_m *MockAuditRepository) FetchAuditEntries(ctx context.Context, orderNumber string) ([]response.AuditEntry, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 []response.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.AuditEntry); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.AuditEntry)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveAuditEntry provides a mock function with given fields: ctx, auditEntry
+func (_m *MockAuditRepository) SaveAuditEntry(ctx context.Context, auditEntry response.AuditEntry) *response.ErrorResponse {
+	ret := _m.Called(ctx, auditEntry)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.AuditEntry) *response.ErrorResponse); ok {
+		r0 = rf(ctx, auditEntry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockAuditRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAuditRepository creates a new instance of MockAuditRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAuditRepository(t mockConstructorTestingTNewMockAuditRepository) *MockAuditRepository {
+	mock := &MockAuditRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,57 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "simple-order-api/cmd/models"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAuthenticator is an autogenerated mock type for the Authenticator type
+type MockAuthenticator struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, authorization, apiKey
+func (_m *MockAuthenticator) Authenticate(ctx context.Context, authorization string, apiKey string) (*models.Principal, *response.ErrorResponse) {
+	ret := _m.Called(ctx, authorization, apiKey)
+
+	var r0 *models.Principal
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Principal); ok {
+		r0 = rf(ctx, authorization, apiKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Principal)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, authorization, apiKey)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockAuthenticator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockAuthenticator creates a new instance of MockAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockAuthenticator(t mockConstructorTestingTNewMockAuthenticator) *MockAuthenticator {
+	mock := &MockAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
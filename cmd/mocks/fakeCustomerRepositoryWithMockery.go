@@ -0,0 +1,129 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockCustomerRepository is an autogenerated mock type for the CustomerRepository type
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+// CreateCustomer provides a mock function with given fields: ctx, customer
+func (_m *MockCustomerRepository) CreateCustomer(ctx context.Context, customer response.Customer) *response.ErrorResponse {
+	ret := _m.Called(ctx, customer)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Customer) *response.ErrorResponse); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// DeleteCustomer provides a mock function with given fields: ctx, id
+func (_m *MockCustomerRepository) DeleteCustomer(ctx context.Context, id string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchCustomerById provides a mock function with given fields: ctx, id
+func (_m *MockCustomerRepository) FetchCustomerById(ctx context.Context, id string) (*response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Customer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchCustomers provides a mock function with given fields: ctx
+func (_m *MockCustomerRepository) FetchCustomers(ctx context.Context) ([]response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Customer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateCustomer provides a mock function with given fields: ctx, customer
+func (_m *MockCustomerRepository) UpdateCustomer(ctx context.Context, customer response.Customer) *response.ErrorResponse {
+	ret := _m.Called(ctx, customer)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Customer) *response.ErrorResponse); ok {
+		r0 = rf(ctx, customer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockCustomerRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockCustomerRepository creates a new instance of MockCustomerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockCustomerRepository(t mockConstructorTestingTNewMockCustomerRepository) *MockCustomerRepository {
+	mock := &MockCustomerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCustomerService is an autogenerated mock type for the CustomerService type
+type MockCustomerService struct {
+	mock.Mock
+}
+
+// CreateCustomer provides a mock function with given fields: ctx, customerRequest
+func (_m *MockCustomerService) CreateCustomer(ctx context.Context, customerRequest request.CustomerRequest) (*response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx, customerRequest)
+
+	var r0 *response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, request.CustomerRequest) *response.Customer); ok {
+		r0 = rf(ctx, customerRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, request.CustomerRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, customerRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteCustomer provides a mock function with given fields: ctx, id
+func (_m *MockCustomerService) DeleteCustomer(ctx context.Context, id string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// GetCustomer provides a mock function with given fields: ctx, id
+func (_m *MockCustomerService) GetCustomer(ctx context.Context, id string) (*response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Customer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetCustomerOrders provides a mock function with given fields: ctx, id, filter
+func (_m *MockCustomerService) GetCustomerOrders(ctx context.Context, id string, filter request.OrderFilter) ([]response.Order, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id, filter)
+
+	var r0 []response.Order
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.OrderFilter) []response.Order); ok {
+		r0 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Order)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.OrderFilter) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetCustomers provides a mock function with given fields: ctx
+func (_m *MockCustomerService) GetCustomers(ctx context.Context) ([]response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Customer); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateCustomer provides a mock function with given fields: ctx, id, customerRequest
+func (_m *MockCustomerService) UpdateCustomer(ctx context.Context, id string, customerRequest request.CustomerRequest) (*response.Customer, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id, customerRequest)
+
+	var r0 *response.Customer
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.CustomerRequest) *response.Customer); ok {
+		r0 = rf(ctx, id, customerRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Customer)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.CustomerRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id, customerRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockCustomerService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockCustomerService creates a new instance of MockCustomerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockCustomerService(t mockConstructorTestingTNewMockCustomerService) *MockCustomerService {
+	mock := &MockCustomerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	events "simple-order-api/cmd/events"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEventBus is an autogenerated mock type for the EventBus type
+type MockEventBus struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockEventBus) Close() {
+	_m.Called()
+}
+
+// Publish provides a mock function with given fields: ctx, event
+func (_m *MockEventBus) Publish(ctx context.Context, event events.OrderEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.OrderEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Subscribe provides a mock function with given fields: handler
+func (_m *MockEventBus) Subscribe(handler events.EventHandler) {
+	_m.Called(handler)
+}
+
+// SubscribeAsync provides a mock function with given fields: handler
+func (_m *MockEventBus) SubscribeAsync(handler events.EventHandler) {
+	_m.Called(handler)
+}
+
+type mockConstructorTestingTNewMockEventBus interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockEventBus creates a new instance of MockEventBus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockEventBus(t mockConstructorTestingTNewMockEventBus) *MockEventBus {
+	mock := &MockEventBus{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,181 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockGeoRepository is an autogenerated mock type for the GeoRepository type
+type MockGeoRepository struct {
+	mock.Mock
+}
+
+// FetchCities provides a mock function with given fields: ctx, countryCode, namePrefix
+func (_m *MockGeoRepository) FetchCities(ctx context.Context, countryCode string, namePrefix string) ([]response.City, *response.ErrorResponse) {
+	ret := _m.Called(ctx, countryCode, namePrefix)
+
+	var r0 []response.City
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []response.City); ok {
+		r0 = rf(ctx, countryCode, namePrefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.City)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, countryCode, namePrefix)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchCitiesByName provides a mock function with given fields: ctx, countryCode, name
+func (_m *MockGeoRepository) FetchCitiesByName(ctx context.Context, countryCode string, name string) ([]response.City, *response.ErrorResponse) {
+	ret := _m.Called(ctx, countryCode, name)
+
+	var r0 []response.City
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []response.City); ok {
+		r0 = rf(ctx, countryCode, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.City)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, countryCode, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchCityById provides a mock function with given fields: ctx, id
+func (_m *MockGeoRepository) FetchCityById(ctx context.Context, id string) (*response.City, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.City
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.City); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.City)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchCountries provides a mock function with given fields: ctx
+func (_m *MockGeoRepository) FetchCountries(ctx context.Context) ([]response.Country, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Country
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Country); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Country)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchDistrictByName provides a mock function with given fields: ctx, cityId, name
+func (_m *MockGeoRepository) FetchDistrictByName(ctx context.Context, cityId string, name string) (*response.District, *response.ErrorResponse) {
+	ret := _m.Called(ctx, cityId, name)
+
+	var r0 *response.District
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *response.District); ok {
+		r0 = rf(ctx, cityId, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.District)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, cityId, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchDistricts provides a mock function with given fields: ctx, cityId
+func (_m *MockGeoRepository) FetchDistricts(ctx context.Context, cityId string) ([]response.District, *response.ErrorResponse) {
+	ret := _m.Called(ctx, cityId)
+
+	var r0 []response.District
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.District); ok {
+		r0 = rf(ctx, cityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.District)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, cityId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockGeoRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockGeoRepository creates a new instance of MockGeoRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockGeoRepository(t mockConstructorTestingTNewMockGeoRepository) *MockGeoRepository {
+	mock := &MockGeoRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGeoService is an autogenerated mock type for the GeoService type
+type MockGeoService struct {
+	mock.Mock
+}
+
+// CheckAddress provides a mock function with given fields: ctx, address
+func (_m *MockGeoService) CheckAddress(ctx context.Context, address response.Address) *response.ErrorResponse {
+	ret := _m.Called(ctx, address)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Address) *response.ErrorResponse); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// GetCities provides a mock function with given fields: ctx, countryCode, query
+func (_m *MockGeoService) GetCities(ctx context.Context, countryCode string, query string) ([]response.City, *response.ErrorResponse) {
+	ret := _m.Called(ctx, countryCode, query)
+
+	var r0 []response.City
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []response.City); ok {
+		r0 = rf(ctx, countryCode, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.City)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, countryCode, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetCountries provides a mock function with given fields: ctx
+func (_m *MockGeoService) GetCountries(ctx context.Context) ([]response.Country, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Country
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Country); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Country)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetDistricts provides a mock function with given fields: ctx, cityId
+func (_m *MockGeoService) GetDistricts(ctx context.Context, cityId string) ([]response.District, *response.ErrorResponse) {
+	ret := _m.Called(ctx, cityId)
+
+	var r0 []response.District
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.District); ok {
+		r0 = rf(ctx, cityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.District)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, cityId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockGeoService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockGeoService creates a new instance of MockGeoService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockGeoService(t mockConstructorTestingTNewMockGeoService) *MockGeoService {
+	mock := &MockGeoService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,72 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockImportJobRepository is an autogenerated mock type for the ImportJobRepository type
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+// FetchImportJobById provides a mock function with given fields: ctx, id
+func (_m *MockImportJobRepository) FetchImportJobById(ctx context.Context, id string) (*response.ImportJob, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ImportJob)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveImportJob provides a mock function with given fields: ctx, importJob
+func (_m *MockImportJobRepository) SaveImportJob(ctx context.Context, importJob response.ImportJob) *response.ErrorResponse {
+	ret := _m.Called(ctx, importJob)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.ImportJob) *response.ErrorResponse); ok {
+		r0 = rf(ctx, importJob)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockImportJobRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockImportJobRepository creates a new instance of MockImportJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockImportJobRepository(t mockConstructorTestingTNewMockImportJobRepository) *MockImportJobRepository {
+	mock := &MockImportJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
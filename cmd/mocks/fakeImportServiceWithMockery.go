@@ -0,0 +1,86 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	imports "simple-order-api/cmd/imports"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockImportService is an autogenerated mock type for the ImportService type
+type MockImportService struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockImportService) Close() {
+	_m.Called()
+}
+
+// GetImportJob provides a mock function with given fields: ctx, id
+func (_m *MockImportService) GetImportJob(ctx context.Context, id string) (*response.ImportJob, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ImportJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ImportJob)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// ImportOrders provides a mock function with given fields: ctx, rows, format, dryRun
+func (_m *MockImportService) ImportOrders(ctx context.Context, rows []imports.Row, format string, dryRun bool) (*response.ImportJob, *response.ErrorResponse) {
+	ret := _m.Called(ctx, rows, format, dryRun)
+
+	var r0 *response.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, []imports.Row, string, bool) *response.ImportJob); ok {
+		r0 = rf(ctx, rows, format, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ImportJob)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, []imports.Row, string, bool) *response.ErrorResponse); ok {
+		r1 = rf(ctx, rows, format, dryRun)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockImportService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockImportService creates a new instance of MockImportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockImportService(t mockConstructorTestingTNewMockImportService) *MockImportService {
+	mock := &MockImportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
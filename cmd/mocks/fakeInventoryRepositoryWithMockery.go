@@ -0,0 +1,186 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockInventoryRepository is an autogenerated mock type for the InventoryRepository type
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+// CheckStock provides a mock function with given fields: ctx, items
+func (_m *MockInventoryRepository) CheckStock(ctx context.Context, items []response.OrderItem) *response.ErrorResponse {
+	ret := _m.Called(ctx, items)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, []response.OrderItem) *response.ErrorResponse); ok {
+		r0 = rf(ctx, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// CommitReservation provides a mock function with given fields: ctx, orderNumber
+func (_m *MockInventoryRepository) CommitReservation(ctx context.Context, orderNumber string) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchReservation provides a mock function with given fields: ctx, orderNumber
+func (_m *MockInventoryRepository) FetchReservation(ctx context.Context, orderNumber string) ([]response.ReservationLine, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 []response.ReservationLine
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.ReservationLine); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.ReservationLine)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchStockLevels provides a mock function with given fields: ctx, sku
+func (_m *MockInventoryRepository) FetchStockLevels(ctx context.Context, sku string) ([]response.StockLevel, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku)
+
+	var r0 []response.StockLevel
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.StockLevel); ok {
+		r0 = rf(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.StockLevel)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// ReleaseReservation provides a mock function with given fields: ctx, orderNumber
+func (_m *MockInventoryRepository) ReleaseReservation(ctx context.Context, orderNumber string) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// ReplaceReservation provides a mock function with given fields: ctx, orderNumber, items
+func (_m *MockInventoryRepository) ReplaceReservation(ctx context.Context, orderNumber string, items []response.OrderItem) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, items)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, []response.OrderItem) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// ReserveStock provides a mock function with given fields: ctx, orderNumber, items
+func (_m *MockInventoryRepository) ReserveStock(ctx context.Context, orderNumber string, items []response.OrderItem) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, items)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, []response.OrderItem) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, items)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// SaveStockLevel provides a mock function with given fields: ctx, sku, warehouseId, onHand
+func (_m *MockInventoryRepository) SaveStockLevel(ctx context.Context, sku string, warehouseId string, onHand int) (*response.StockLevel, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku, warehouseId, onHand)
+
+	var r0 *response.StockLevel
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *response.StockLevel); ok {
+		r0 = rf(ctx, sku, warehouseId, onHand)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.StockLevel)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku, warehouseId, onHand)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockInventoryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockInventoryRepository creates a new instance of MockInventoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockInventoryRepository(t mockConstructorTestingTNewMockInventoryRepository) *MockInventoryRepository {
+	mock := &MockInventoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInventoryService is an autogenerated mock type for the InventoryService type
+type MockInventoryService struct {
+	mock.Mock
+}
+
+// GetReservation provides a mock function with given fields: ctx, orderNumber
+func (_m *MockInventoryService) GetReservation(ctx context.Context, orderNumber string) ([]response.ReservationLine, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 []response.ReservationLine
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.ReservationLine); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.ReservationLine)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetStockLevels provides a mock function with given fields: ctx, sku
+func (_m *MockInventoryService) GetStockLevels(ctx context.Context, sku string) ([]response.StockLevel, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku)
+
+	var r0 []response.StockLevel
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.StockLevel); ok {
+		r0 = rf(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.StockLevel)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// SetStockLevel provides a mock function with given fields: ctx, sku, warehouseId, stockLevelRequest
+func (_m *MockInventoryService) SetStockLevel(ctx context.Context, sku string, warehouseId string, stockLevelRequest request.StockLevelRequest) (*response.StockLevel, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku, warehouseId, stockLevelRequest)
+
+	var r0 *response.StockLevel
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.StockLevelRequest) *response.StockLevel); ok {
+		r0 = rf(ctx, sku, warehouseId, stockLevelRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.StockLevel)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.StockLevelRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku, warehouseId, stockLevelRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockInventoryService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockInventoryService creates a new instance of MockInventoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockInventoryService(t mockConstructorTestingTNewMockInventoryService) *MockInventoryService {
+	mock := &MockInventoryService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,7 +1,10 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
 )
@@ -10,8 +13,8 @@ type FakeOrderRepository struct {
 	mock.Mock
 }
 
-func (service *FakeOrderRepository) FetchOrders() ([]response.Order, *response.ErrorResponse) {
-	result := service.Called()
+func (service *FakeOrderRepository) FetchOrders(ctx context.Context) ([]response.Order, *response.ErrorResponse) {
+	result := service.Called(ctx)
 	if result.Get(0) != nil {
 		return result.Get(0).([]response.Order), nil
 	}
@@ -19,8 +22,17 @@ func (service *FakeOrderRepository) FetchOrders() ([]response.Order, *response.E
 	return nil, result.Get(1).(*response.ErrorResponse)
 }
 
-func (service *FakeOrderRepository) FetchOrderByOrderNumber(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	result := service.Called(orderNumber)
+func (service *FakeOrderRepository) IterateOrders(ctx context.Context, visit func(order response.Order) bool) *response.ErrorResponse {
+	result := service.Called(ctx, visit)
+	if result.Get(0) != nil {
+		return result.Get(0).(*response.ErrorResponse)
+	}
+
+	return nil
+}
+
+func (service *FakeOrderRepository) FetchOrderByOrderNumber(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse) {
+	result := service.Called(ctx, orderNumber)
 	if result.Get(0) == nil && result.Get(1) == nil {
 		return nil, nil
 	}
@@ -32,8 +44,17 @@ func (service *FakeOrderRepository) FetchOrderByOrderNumber(orderNumber string)
 	return nil, result.Get(1).(*response.ErrorResponse)
 }
 
-func (service *FakeOrderRepository) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	result := service.Called(createOrderRequest)
+func (service *FakeOrderRepository) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest, event events.OrderEvent) *response.ErrorResponse {
+	result := service.Called(ctx, createOrderRequest, event)
+	if result.Get(0) != nil {
+		return result.Get(0).(*response.ErrorResponse)
+	}
+
+	return nil
+}
+
+func (service *FakeOrderRepository) UpdateOrder(ctx context.Context, orderNumber string, expectedStatus enum.OrderStatus, updateOrderRequest request.UpdateOrderRequest, event events.OrderEvent) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber, expectedStatus, updateOrderRequest, event)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
@@ -41,8 +62,8 @@ func (service *FakeOrderRepository) CreateOrder(createOrderRequest request.Creat
 	return nil
 }
 
-func (service *FakeOrderRepository) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	result := service.Called(orderNumber, updateOrderRequest)
+func (service *FakeOrderRepository) DeleteOrder(ctx context.Context, orderNumber string, event events.OrderEvent) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber, event)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
@@ -50,8 +71,8 @@ func (service *FakeOrderRepository) UpdateOrder(orderNumber string, updateOrderR
 	return nil
 }
 
-func (service *FakeOrderRepository) DeleteOrder(orderNumber string) *response.ErrorResponse {
-	result := service.Called(orderNumber)
+func (service *FakeOrderRepository) UpdateOrderStatus(ctx context.Context, orderNumber string, status enum.OrderStatus, event events.OrderEvent) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber, status, event)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
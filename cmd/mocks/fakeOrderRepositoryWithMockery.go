@@ -3,10 +3,13 @@
 package mocks
 
 import (
+	context "context"
+	enum "simple-order-api/cmd/enums"
+	events "simple-order-api/cmd/events"
+
 	mock "github.com/stretchr/testify/mock"
 
 	request "simple-order-api/cmd/models/request"
-
 	response "simple-order-api/cmd/models/response"
 )
 
@@ -15,13 +18,13 @@ type MockOrderRepository struct {
 	mock.Mock
 }
 
-// CreateOrder provides a mock function with given fields: createOrderRequest
-func (_m *MockOrderRepository) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	ret := _m.Called(createOrderRequest)
+// CreateOrder provides a mock function with given fields: ctx, createOrderRequest, event
+func (_m *MockOrderRepository) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest, event events.OrderEvent) *response.ErrorResponse {
+	ret := _m.Called(ctx, createOrderRequest, event)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(request.CreateOrderRequest) *response.ErrorResponse); ok {
-		r0 = rf(createOrderRequest)
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateOrderRequest, events.OrderEvent) *response.ErrorResponse); ok {
+		r0 = rf(ctx, createOrderRequest, event)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
@@ -31,13 +34,13 @@ func (_m *MockOrderRepository) CreateOrder(createOrderRequest request.CreateOrde
 	return r0
 }
 
-// DeleteOrder provides a mock function with given fields: orderNumber
-func (_m *MockOrderRepository) DeleteOrder(orderNumber string) *response.ErrorResponse {
-	ret := _m.Called(orderNumber)
+// DeleteOrder provides a mock function with given fields: ctx, orderNumber, event
+func (_m *MockOrderRepository) DeleteOrder(ctx context.Context, orderNumber string, event events.OrderEvent) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, event)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(string) *response.ErrorResponse); ok {
-		r0 = rf(orderNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string, events.OrderEvent) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, event)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
@@ -47,13 +50,13 @@ func (_m *MockOrderRepository) DeleteOrder(orderNumber string) *response.ErrorRe
 	return r0
 }
 
-// FetchOrderByOrderNumber provides a mock function with given fields: orderNumber
-func (_m *MockOrderRepository) FetchOrderByOrderNumber(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	ret := _m.Called(orderNumber)
+// FetchOrderByOrderNumber provides a mock function with given fields: ctx, orderNumber
+func (_m *MockOrderRepository) FetchOrderByOrderNumber(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
 
 	var r0 *response.Order
-	if rf, ok := ret.Get(0).(func(string) *response.Order); ok {
-		r0 = rf(orderNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Order); ok {
+		r0 = rf(ctx, orderNumber)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.Order)
@@ -61,8 +64,8 @@ func (_m *MockOrderRepository) FetchOrderByOrderNumber(orderNumber string) (*res
 	}
 
 	var r1 *response.ErrorResponse
-	if rf, ok := ret.Get(1).(func(string) *response.ErrorResponse); ok {
-		r1 = rf(orderNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*response.ErrorResponse)
@@ -72,13 +75,13 @@ func (_m *MockOrderRepository) FetchOrderByOrderNumber(orderNumber string) (*res
 	return r0, r1
 }
 
-// FetchOrders provides a mock function with given fields:
-func (_m *MockOrderRepository) FetchOrders() ([]response.Order, *response.ErrorResponse) {
-	ret := _m.Called()
+// FetchOrders provides a mock function with given fields: ctx
+func (_m *MockOrderRepository) FetchOrders(ctx context.Context) ([]response.Order, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
 
 	var r0 []response.Order
-	if rf, ok := ret.Get(0).(func() []response.Order); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Order); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]response.Order)
@@ -86,8 +89,8 @@ func (_m *MockOrderRepository) FetchOrders() ([]response.Order, *response.ErrorR
 	}
 
 	var r1 *response.ErrorResponse
-	if rf, ok := ret.Get(1).(func() *response.ErrorResponse); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*response.ErrorResponse)
@@ -97,13 +100,45 @@ func (_m *MockOrderRepository) FetchOrders() ([]response.Order, *response.ErrorR
 	return r0, r1
 }
 
-// UpdateOrder provides a mock function with given fields: orderNumber, updateOrderRequest
-func (_m *MockOrderRepository) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	ret := _m.Called(orderNumber, updateOrderRequest)
+// IterateOrders provides a mock function with given fields: ctx, visit
+func (_m *MockOrderRepository) IterateOrders(ctx context.Context, visit func(response.Order) bool) *response.ErrorResponse {
+	ret := _m.Called(ctx, visit)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, func(response.Order) bool) *response.ErrorResponse); ok {
+		r0 = rf(ctx, visit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOrder provides a mock function with given fields: ctx, orderNumber, expectedStatus, updateOrderRequest, event
+func (_m *MockOrderRepository) UpdateOrder(ctx context.Context, orderNumber string, expectedStatus enum.OrderStatus, updateOrderRequest request.UpdateOrderRequest, event events.OrderEvent) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, expectedStatus, updateOrderRequest, event)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, enum.OrderStatus, request.UpdateOrderRequest, events.OrderEvent) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, expectedStatus, updateOrderRequest, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, orderNumber, status, event
+func (_m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, orderNumber string, status enum.OrderStatus, event events.OrderEvent) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, status, event)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(string, request.UpdateOrderRequest) *response.ErrorResponse); ok {
-		r0 = rf(orderNumber, updateOrderRequest)
+	if rf, ok := ret.Get(0).(func(context.Context, string, enum.OrderStatus, events.OrderEvent) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, status, event)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
@@ -10,8 +11,8 @@ type FakeOrderService struct {
 	mock.Mock
 }
 
-func (service *FakeOrderService) GetOrders() ([]response.Order, *response.ErrorResponse) {
-	result := service.Called()
+func (service *FakeOrderService) GetOrders(ctx context.Context, filter request.OrderFilter) ([]response.Order, *response.ErrorResponse) {
+	result := service.Called(ctx, filter)
 	if result.Get(0) != nil {
 		return result.Get(0).([]response.Order), nil
 	}
@@ -19,8 +20,17 @@ func (service *FakeOrderService) GetOrders() ([]response.Order, *response.ErrorR
 	return nil, result.Get(1).(*response.ErrorResponse)
 }
 
-func (service *FakeOrderService) GetOrder(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	result := service.Called(orderNumber)
+func (service *FakeOrderService) StreamOrders(ctx context.Context, filter request.OrderFilter, visit func(order response.Order) bool) *response.ErrorResponse {
+	result := service.Called(ctx, filter, visit)
+	if result.Get(0) != nil {
+		return result.Get(0).(*response.ErrorResponse)
+	}
+
+	return nil
+}
+
+func (service *FakeOrderService) GetOrder(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse) {
+	result := service.Called(ctx, orderNumber)
 	if result.Get(0) == nil && result.Get(1) == nil {
 		return nil, nil
 	}
@@ -32,8 +42,17 @@ func (service *FakeOrderService) GetOrder(orderNumber string) (*response.Order,
 	return nil, result.Get(1).(*response.ErrorResponse)
 }
 
-func (service *FakeOrderService) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	result := service.Called(createOrderRequest)
+func (service *FakeOrderService) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
+	result := service.Called(ctx, createOrderRequest)
+	if result.Get(0) != nil {
+		return result.Get(0).(*response.ErrorResponse)
+	}
+
+	return nil
+}
+
+func (service *FakeOrderService) CheckOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
+	result := service.Called(ctx, createOrderRequest)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
@@ -41,8 +60,8 @@ func (service *FakeOrderService) CreateOrder(createOrderRequest request.CreateOr
 	return nil
 }
 
-func (service *FakeOrderService) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	result := service.Called(orderNumber, updateOrderRequest)
+func (service *FakeOrderService) UpdateOrder(ctx context.Context, orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber, updateOrderRequest)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
@@ -50,11 +69,29 @@ func (service *FakeOrderService) UpdateOrder(orderNumber string, updateOrderRequ
 	return nil
 }
 
-func (service *FakeOrderService) DeleteOrder(orderNumber string) *response.ErrorResponse {
-	result := service.Called(orderNumber)
+func (service *FakeOrderService) DeleteOrder(ctx context.Context, orderNumber string) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber)
 	if result.Get(0) != nil {
 		return result.Get(0).(*response.ErrorResponse)
 	}
 
 	return nil
 }
+
+func (service *FakeOrderService) TransitionOrder(ctx context.Context, orderNumber string, transitionOrderRequest request.TransitionOrderRequest) *response.ErrorResponse {
+	result := service.Called(ctx, orderNumber, transitionOrderRequest)
+	if result.Get(0) != nil {
+		return result.Get(0).(*response.ErrorResponse)
+	}
+
+	return nil
+}
+
+func (service *FakeOrderService) GetOrderAudit(ctx context.Context, orderNumber string) ([]response.AuditEntry, *response.ErrorResponse) {
+	result := service.Called(ctx, orderNumber)
+	if result.Get(0) != nil {
+		return result.Get(0).([]response.AuditEntry), nil
+	}
+
+	return nil, result.Get(1).(*response.ErrorResponse)
+}
@@ -3,11 +3,11 @@
 package mocks
 
 import (
+	context "context"
 	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
 
 	mock "github.com/stretchr/testify/mock"
-
-	response "simple-order-api/cmd/models/response"
 )
 
 // MockOrderService is an autogenerated mock type for the OrderService type
@@ -15,13 +15,29 @@ type MockOrderService struct {
 	mock.Mock
 }
 
-// CreateOrder provides a mock function with given fields: createOrderRequest
-func (_m *MockOrderService) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	ret := _m.Called(createOrderRequest)
+// CheckOrder provides a mock function with given fields: ctx, createOrderRequest
+func (_m *MockOrderService) CheckOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
+	ret := _m.Called(ctx, createOrderRequest)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateOrderRequest) *response.ErrorResponse); ok {
+		r0 = rf(ctx, createOrderRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// CreateOrder provides a mock function with given fields: ctx, createOrderRequest
+func (_m *MockOrderService) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
+	ret := _m.Called(ctx, createOrderRequest)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(request.CreateOrderRequest) *response.ErrorResponse); ok {
-		r0 = rf(createOrderRequest)
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateOrderRequest) *response.ErrorResponse); ok {
+		r0 = rf(ctx, createOrderRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
@@ -31,13 +47,13 @@ func (_m *MockOrderService) CreateOrder(createOrderRequest request.CreateOrderRe
 	return r0
 }
 
-// DeleteOrder provides a mock function with given fields: orderNumber
-func (_m *MockOrderService) DeleteOrder(orderNumber string) *response.ErrorResponse {
-	ret := _m.Called(orderNumber)
+// DeleteOrder provides a mock function with given fields: ctx, orderNumber
+func (_m *MockOrderService) DeleteOrder(ctx context.Context, orderNumber string) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(string) *response.ErrorResponse); ok {
-		r0 = rf(orderNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
@@ -47,13 +63,13 @@ func (_m *MockOrderService) DeleteOrder(orderNumber string) *response.ErrorRespo
 	return r0
 }
 
-// GetOrder provides a mock function with given fields: orderNumber
-func (_m *MockOrderService) GetOrder(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	ret := _m.Called(orderNumber)
+// GetOrder provides a mock function with given fields: ctx, orderNumber
+func (_m *MockOrderService) GetOrder(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
 
 	var r0 *response.Order
-	if rf, ok := ret.Get(0).(func(string) *response.Order); ok {
-		r0 = rf(orderNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Order); ok {
+		r0 = rf(ctx, orderNumber)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.Order)
@@ -61,8 +77,33 @@ func (_m *MockOrderService) GetOrder(orderNumber string) (*response.Order, *resp
 	}
 
 	var r1 *response.ErrorResponse
-	if rf, ok := ret.Get(1).(func(string) *response.ErrorResponse); ok {
-		r1 = rf(orderNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetOrderAudit provides a mock function with given fields: ctx, orderNumber
+func (_m *MockOrderService) GetOrderAudit(ctx context.Context, orderNumber string) ([]response.AuditEntry, *response.ErrorResponse) {
+	ret := _m.Called(ctx, orderNumber)
+
+	var r0 []response.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.AuditEntry); ok {
+		r0 = rf(ctx, orderNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.AuditEntry)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, orderNumber)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*response.ErrorResponse)
@@ -72,13 +113,13 @@ func (_m *MockOrderService) GetOrder(orderNumber string) (*response.Order, *resp
 	return r0, r1
 }
 
-// GetOrders provides a mock function with given fields:
-func (_m *MockOrderService) GetOrders() ([]response.Order, *response.ErrorResponse) {
-	ret := _m.Called()
+// GetOrders provides a mock function with given fields: ctx, filter
+func (_m *MockOrderService) GetOrders(ctx context.Context, filter request.OrderFilter) ([]response.Order, *response.ErrorResponse) {
+	ret := _m.Called(ctx, filter)
 
 	var r0 []response.Order
-	if rf, ok := ret.Get(0).(func() []response.Order); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, request.OrderFilter) []response.Order); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]response.Order)
@@ -86,8 +127,8 @@ func (_m *MockOrderService) GetOrders() ([]response.Order, *response.ErrorRespon
 	}
 
 	var r1 *response.ErrorResponse
-	if rf, ok := ret.Get(1).(func() *response.ErrorResponse); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, request.OrderFilter) *response.ErrorResponse); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*response.ErrorResponse)
@@ -97,13 +138,45 @@ func (_m *MockOrderService) GetOrders() ([]response.Order, *response.ErrorRespon
 	return r0, r1
 }
 
-// UpdateOrder provides a mock function with given fields: orderNumber, updateOrderRequest
-func (_m *MockOrderService) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	ret := _m.Called(orderNumber, updateOrderRequest)
+// StreamOrders provides a mock function with given fields: ctx, filter, visit
+func (_m *MockOrderService) StreamOrders(ctx context.Context, filter request.OrderFilter, visit func(response.Order) bool) *response.ErrorResponse {
+	ret := _m.Called(ctx, filter, visit)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, request.OrderFilter, func(response.Order) bool) *response.ErrorResponse); ok {
+		r0 = rf(ctx, filter, visit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// TransitionOrder provides a mock function with given fields: ctx, orderNumber, transitionOrderRequest
+func (_m *MockOrderService) TransitionOrder(ctx context.Context, orderNumber string, transitionOrderRequest request.TransitionOrderRequest) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, transitionOrderRequest)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.TransitionOrderRequest) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, transitionOrderRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOrder provides a mock function with given fields: ctx, orderNumber, updateOrderRequest
+func (_m *MockOrderService) UpdateOrder(ctx context.Context, orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, updateOrderRequest)
 
 	var r0 *response.ErrorResponse
-	if rf, ok := ret.Get(0).(func(string, request.UpdateOrderRequest) *response.ErrorResponse); ok {
-		r0 = rf(orderNumber, updateOrderRequest)
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.UpdateOrderRequest) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, updateOrderRequest)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*response.ErrorResponse)
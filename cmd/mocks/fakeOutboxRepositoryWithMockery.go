@@ -0,0 +1,129 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	events "simple-order-api/cmd/events"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+	time "time"
+)
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+// DeadLetterMessage provides a mock function with given fields: ctx, id, reason
+func (_m *MockOutboxRepository) DeadLetterMessage(ctx context.Context, id int64, reason string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id, reason)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchPendingMessages provides a mock function with given fields: ctx, now, limit
+func (_m *MockOutboxRepository) FetchPendingMessages(ctx context.Context, now time.Time, limit int) ([]events.OutboxMessage, *response.ErrorResponse) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []events.OutboxMessage
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []events.OutboxMessage); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]events.OutboxMessage)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) *response.ErrorResponse); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// MarkMessagePublished provides a mock function with given fields: ctx, id
+func (_m *MockOutboxRepository) MarkMessagePublished(ctx context.Context, id int64) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// PruneMessages provides a mock function with given fields: ctx, before
+func (_m *MockOutboxRepository) PruneMessages(ctx context.Context, before time.Time) (int, *response.ErrorResponse) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) *response.ErrorResponse); ok {
+		r1 = rf(ctx, before)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// RescheduleMessage provides a mock function with given fields: ctx, id, reason, nextAttemptAt
+func (_m *MockOutboxRepository) RescheduleMessage(ctx context.Context, id int64, reason string, nextAttemptAt time.Time) *response.ErrorResponse {
+	ret := _m.Called(ctx, id, reason, nextAttemptAt)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id, reason, nextAttemptAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockOutboxRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockOutboxRepository(t mockConstructorTestingTNewMockOutboxRepository) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockProductRepository is an autogenerated mock type for the ProductRepository type
+type MockProductRepository struct {
+	mock.Mock
+}
+
+// CreateProduct provides a mock function with given fields: ctx, product
+func (_m *MockProductRepository) CreateProduct(ctx context.Context, product response.Product) *response.ErrorResponse {
+	ret := _m.Called(ctx, product)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Product) *response.ErrorResponse); ok {
+		r0 = rf(ctx, product)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchProductBySku provides a mock function with given fields: ctx, sku
+func (_m *MockProductRepository) FetchProductBySku(ctx context.Context, sku string) (*response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku)
+
+	var r0 *response.Product
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Product); ok {
+		r0 = rf(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchProducts provides a mock function with given fields: ctx
+func (_m *MockProductRepository) FetchProducts(ctx context.Context) ([]response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Product
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Product); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: ctx, product
+func (_m *MockProductRepository) UpdateProduct(ctx context.Context, product response.Product) *response.ErrorResponse {
+	ret := _m.Called(ctx, product)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Product) *response.ErrorResponse); ok {
+		r0 = rf(ctx, product)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockProductRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockProductRepository creates a new instance of MockProductRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockProductRepository(t mockConstructorTestingTNewMockProductRepository) *MockProductRepository {
+	mock := &MockProductRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
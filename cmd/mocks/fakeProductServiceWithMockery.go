@@ -0,0 +1,131 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockProductService is an autogenerated mock type for the ProductService type
+type MockProductService struct {
+	mock.Mock
+}
+
+// CreateProduct provides a mock function with given fields: ctx, createProductRequest
+func (_m *MockProductService) CreateProduct(ctx context.Context, createProductRequest request.CreateProductRequest) (*response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx, createProductRequest)
+
+	var r0 *response.Product
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateProductRequest) *response.Product); ok {
+		r0 = rf(ctx, createProductRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, request.CreateProductRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, createProductRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetProduct provides a mock function with given fields: ctx, sku
+func (_m *MockProductService) GetProduct(ctx context.Context, sku string) (*response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku)
+
+	var r0 *response.Product
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Product); ok {
+		r0 = rf(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetProducts provides a mock function with given fields: ctx
+func (_m *MockProductService) GetProducts(ctx context.Context) ([]response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Product
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Product); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: ctx, sku, updateProductRequest
+func (_m *MockProductService) UpdateProduct(ctx context.Context, sku string, updateProductRequest request.UpdateProductRequest) (*response.Product, *response.ErrorResponse) {
+	ret := _m.Called(ctx, sku, updateProductRequest)
+
+	var r0 *response.Product
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.UpdateProductRequest) *response.Product); ok {
+		r0 = rf(ctx, sku, updateProductRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Product)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.UpdateProductRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, sku, updateProductRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockProductService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockProductService creates a new instance of MockProductService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockProductService(t mockConstructorTestingTNewMockProductService) *MockProductService {
+	mock := &MockProductService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,175 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockPromotionRepository is an autogenerated mock type for the PromotionRepository type
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+// CountRedemptions provides a mock function with given fields: ctx, code, customerId
+func (_m *MockPromotionRepository) CountRedemptions(ctx context.Context, code string, customerId string) (int, int, *response.ErrorResponse) {
+	ret := _m.Called(ctx, code, customerId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, code, customerId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) int); ok {
+		r1 = rf(ctx, code, customerId)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 *response.ErrorResponse
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r2 = rf(ctx, code, customerId)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// CreatePromotion provides a mock function with given fields: ctx, promotion
+func (_m *MockPromotionRepository) CreatePromotion(ctx context.Context, promotion response.Promotion) *response.ErrorResponse {
+	ret := _m.Called(ctx, promotion)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Promotion) *response.ErrorResponse); ok {
+		r0 = rf(ctx, promotion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchPromotionByCode provides a mock function with given fields: ctx, code
+func (_m *MockPromotionRepository) FetchPromotionByCode(ctx context.Context, code string) (*response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Promotion); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, code)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchPromotions provides a mock function with given fields: ctx
+func (_m *MockPromotionRepository) FetchPromotions(ctx context.Context) ([]response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Promotion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// RedeemPromotions provides a mock function with given fields: ctx, orderNumber, customerId, codes
+func (_m *MockPromotionRepository) RedeemPromotions(ctx context.Context, orderNumber string, customerId string, codes []string) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, customerId, codes)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, customerId, codes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// ReleaseRedemptions provides a mock function with given fields: ctx, orderNumber, codes
+func (_m *MockPromotionRepository) ReleaseRedemptions(ctx context.Context, orderNumber string, codes []string) *response.ErrorResponse {
+	ret := _m.Called(ctx, orderNumber, codes)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, orderNumber, codes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// UpdatePromotion provides a mock function with given fields: ctx, promotion
+func (_m *MockPromotionRepository) UpdatePromotion(ctx context.Context, promotion response.Promotion) *response.ErrorResponse {
+	ret := _m.Called(ctx, promotion)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Promotion) *response.ErrorResponse); ok {
+		r0 = rf(ctx, promotion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockPromotionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockPromotionRepository creates a new instance of MockPromotionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockPromotionRepository(t mockConstructorTestingTNewMockPromotionRepository) *MockPromotionRepository {
+	mock := &MockPromotionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPromotionService is an autogenerated mock type for the PromotionService type
+type MockPromotionService struct {
+	mock.Mock
+}
+
+// CreatePromotion provides a mock function with given fields: ctx, createPromotionRequest
+func (_m *MockPromotionService) CreatePromotion(ctx context.Context, createPromotionRequest request.CreatePromotionRequest) (*response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx, createPromotionRequest)
+
+	var r0 *response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreatePromotionRequest) *response.Promotion); ok {
+		r0 = rf(ctx, createPromotionRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, request.CreatePromotionRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, createPromotionRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetPromotion provides a mock function with given fields: ctx, code
+func (_m *MockPromotionService) GetPromotion(ctx context.Context, code string) (*response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Promotion); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, code)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetPromotions provides a mock function with given fields: ctx
+func (_m *MockPromotionService) GetPromotions(ctx context.Context) ([]response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Promotion); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdatePromotion provides a mock function with given fields: ctx, code, updatePromotionRequest
+func (_m *MockPromotionService) UpdatePromotion(ctx context.Context, code string, updatePromotionRequest request.UpdatePromotionRequest) (*response.Promotion, *response.ErrorResponse) {
+	ret := _m.Called(ctx, code, updatePromotionRequest)
+
+	var r0 *response.Promotion
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.UpdatePromotionRequest) *response.Promotion); ok {
+		r0 = rf(ctx, code, updatePromotionRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Promotion)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.UpdatePromotionRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, code, updatePromotionRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockPromotionService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockPromotionService creates a new instance of MockPromotionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockPromotionService(t mockConstructorTestingTNewMockPromotionService) *MockPromotionService {
+	mock := &MockPromotionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
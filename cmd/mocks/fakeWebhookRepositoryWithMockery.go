@@ -0,0 +1,179 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "simple-order-api/cmd/models/response"
+)
+
+// MockWebhookRepository is an autogenerated mock type for the WebhookRepository type
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// FetchDeliveries provides a mock function with given fields: ctx, webhookId
+func (_m *MockWebhookRepository) FetchDeliveries(ctx context.Context, webhookId string) ([]response.WebhookDelivery, *response.ErrorResponse) {
+	ret := _m.Called(ctx, webhookId)
+
+	var r0 []response.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.WebhookDelivery)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, webhookId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchDeliveryById provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) FetchDeliveryById(ctx context.Context, id string) (*response.WebhookDelivery, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.WebhookDelivery)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchWebhookById provides a mock function with given fields: ctx, id
+func (_m *MockWebhookRepository) FetchWebhookById(ctx context.Context, id string) (*response.Webhook, *response.ErrorResponse) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Webhook); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Webhook)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// FetchWebhooks provides a mock function with given fields: ctx
+func (_m *MockWebhookRepository) FetchWebhooks(ctx context.Context) ([]response.Webhook, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Webhook)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveDelivery provides a mock function with given fields: ctx, delivery
+func (_m *MockWebhookRepository) SaveDelivery(ctx context.Context, delivery response.WebhookDelivery) *response.ErrorResponse {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.WebhookDelivery) *response.ErrorResponse); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// SaveWebhook provides a mock function with given fields: ctx, webhook
+func (_m *MockWebhookRepository) SaveWebhook(ctx context.Context, webhook response.Webhook) *response.ErrorResponse {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, response.Webhook) *response.ErrorResponse); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMockWebhookRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockWebhookRepository creates a new instance of MockWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockWebhookRepository(t mockConstructorTestingTNewMockWebhookRepository) *MockWebhookRepository {
+	mock := &MockWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.16.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	events "simple-order-api/cmd/events"
+	request "simple-order-api/cmd/models/request"
+	response "simple-order-api/cmd/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService is an autogenerated mock type for the WebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *MockWebhookService) Close() {
+	_m.Called()
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, createWebhookRequest
+func (_m *MockWebhookService) CreateWebhook(ctx context.Context, createWebhookRequest request.CreateWebhookRequest) (*response.CreatedWebhook, *response.ErrorResponse) {
+	ret := _m.Called(ctx, createWebhookRequest)
+
+	var r0 *response.CreatedWebhook
+	if rf, ok := ret.Get(0).(func(context.Context, request.CreateWebhookRequest) *response.CreatedWebhook); ok {
+		r0 = rf(ctx, createWebhookRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.CreatedWebhook)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, request.CreateWebhookRequest) *response.ErrorResponse); ok {
+		r1 = rf(ctx, createWebhookRequest)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, id
+func (_m *MockWebhookService) DeleteWebhook(ctx context.Context, id string) *response.ErrorResponse {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.ErrorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.ErrorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ErrorResponse)
+		}
+	}
+
+	return r0
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, webhookId
+func (_m *MockWebhookService) GetDeliveries(ctx context.Context, webhookId string) ([]response.WebhookDelivery, *response.ErrorResponse) {
+	ret := _m.Called(ctx, webhookId)
+
+	var r0 []response.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.WebhookDelivery)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, webhookId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetWebhooks provides a mock function with given fields: ctx
+func (_m *MockWebhookService) GetWebhooks(ctx context.Context) ([]response.Webhook, *response.ErrorResponse) {
+	ret := _m.Called(ctx)
+
+	var r0 []response.Webhook
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Webhook); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Webhook)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context) *response.ErrorResponse); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+// HandleEvent provides a mock function with given fields: ctx, event
+func (_m *MockWebhookService) HandleEvent(ctx context.Context, event events.OrderEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, events.OrderEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedeliverDelivery provides a mock function with given fields: ctx, webhookId, deliveryId
+func (_m *MockWebhookService) RedeliverDelivery(ctx context.Context, webhookId string, deliveryId string) (*response.WebhookDelivery, *response.ErrorResponse) {
+	ret := _m.Called(ctx, webhookId, deliveryId)
+
+	var r0 *response.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *response.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookId, deliveryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.WebhookDelivery)
+		}
+	}
+
+	var r1 *response.ErrorResponse
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *response.ErrorResponse); ok {
+		r1 = rf(ctx, webhookId, deliveryId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*response.ErrorResponse)
+		}
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMockWebhookService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMockWebhookService(t mockConstructorTestingTNewMockWebhookService) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
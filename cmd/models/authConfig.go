@@ -0,0 +1,12 @@
+package models
+
+type AuthConfig struct {
+	Enabled          bool
+	HmacSecret       string
+	RsaPublicKeyFile string
+	JwksFile         string
+	Issuer           string
+	Audience         string
+	PublicPaths      []string
+	AdminApiKey      string
+}
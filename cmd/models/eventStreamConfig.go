@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+type EventStreamConfig struct {
+	ReplayBufferSize  int
+	ClientBufferSize  int
+	HeartbeatInterval time.Duration
+}
@@ -0,0 +1,6 @@
+package models
+
+type ExportConfig struct {
+	Columns []string
+	Locale  string
+}
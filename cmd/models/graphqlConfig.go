@@ -0,0 +1,5 @@
+package models
+
+type GraphqlConfig struct {
+	Playground bool
+}
@@ -0,0 +1,6 @@
+package models
+
+type GrpcConfig struct {
+	Enabled bool
+	Port    string
+}
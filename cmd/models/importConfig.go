@@ -0,0 +1,7 @@
+package models
+
+type ImportConfig struct {
+	MaxFileSize       int64
+	SyncRowLimit      int
+	MaxReportedErrors int
+}
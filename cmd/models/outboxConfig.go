@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type OutboxConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Retention      time.Duration
+}
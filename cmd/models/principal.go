@@ -0,0 +1,16 @@
+package models
+
+const (
+	UserPrincipal   = "user"
+	ApiKeyPrincipal = "apikey"
+)
+
+// Principal is the authenticated caller of a request. Users authenticated by a
+// bearer token carry roles, machine clients authenticated by an api key carry scopes.
+type Principal struct {
+	Type    string
+	Subject string
+	Roles   []string
+	Scopes  []string
+	Claims  map[string]interface{}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// RateLimitRule allows Limit requests per Period, refilled continuously, with bursts up to Limit.
+type RateLimitRule struct {
+	Limit  int
+	Period time.Duration
+}
+
+type RouteRateLimitConfig struct {
+	Method        string
+	Path          string
+	RateLimitRule `mapstructure:",squash"`
+}
+
+type RateLimitConfig struct {
+	Enabled        bool
+	Default        RateLimitRule
+	Routes         []RouteRateLimitConfig
+	IpLimitFactor  int
+	TrustedProxies []string
+}
+
+// RuleOf returns the rule configured for the route, or the default rule.
+func (config RateLimitConfig) RuleOf(method, route string) RateLimitRule {
+	for _, routeConfig := range config.Routes {
+		if strings.EqualFold(routeConfig.Method, method) && routeConfig.Path == route {
+			return routeConfig.RateLimitRule
+		}
+	}
+
+	return config.Default
+}
+
+// IpRuleOf returns the rule of the route with its limit multiplied by IpLimitFactor.
+// It is checked per ip address before authentication, so that clients sharing an
+// address are told apart by their credentials instead of sharing a single budget.
+func (config RateLimitConfig) IpRuleOf(method, route string) RateLimitRule {
+	rule := config.RuleOf(method, route)
+	if config.IpLimitFactor > 1 {
+		rule.Limit *= config.IpLimitFactor
+	}
+
+	return rule
+}
@@ -0,0 +1,83 @@
+package request
+
+import (
+	"golang.org/x/text/language"
+	"regexp"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/response"
+)
+
+const (
+	maxAddressLines      = 3
+	maxAddressLineLength = 70
+)
+
+// countryRule holds the address rules of a country beyond the common ones.
+type countryRule struct {
+	postalCode       *regexp.Regexp
+	districtRequired bool
+}
+
+// countryRules lists the countries whose postal codes are checked. They are required
+// there; elsewhere they are optional and only checked for plausible characters.
+var countryRules = map[string]countryRule{
+	"TR": {postalCode: regexp.MustCompile(`^(0[1-9]|[1-7][0-9]|8[01])[0-9]{3}$`), districtRequired: true},
+	"DE": {postalCode: regexp.MustCompile(`^[0-9]{5}$`)},
+	"FR": {postalCode: regexp.MustCompile(`^[0-9]{5}$`)},
+	"NL": {postalCode: regexp.MustCompile(`^[1-9][0-9]{3} ?[A-Z]{2}$`)},
+	"GB": {postalCode: regexp.MustCompile(`^([A-Z]{1,2}[0-9][0-9A-Z]? [0-9][A-Z]{2}|GIR 0AA)$`)},
+	"US": {postalCode: regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`)},
+}
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	postalCodePattern  = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{1,9}$`)
+)
+
+// validateAddress applies the rules of a structured address, which names its country
+// unlike the flat address fields.
+func validateAddress(address response.Address) *response.ErrorResponse {
+	address = address.Normalized()
+	if len(address.Lines) == 0 || len(address.Lines) > maxAddressLines {
+		return newValidationError(constants.AddressIsNotValid)
+	}
+
+	for _, line := range address.Lines {
+		if len(line) == 0 || len([]rune(line)) > maxAddressLineLength {
+			return newValidationError(constants.AddressIsNotValid)
+		}
+	}
+
+	if len(address.City) == 0 {
+		return newValidationError(constants.CityIsNotValid)
+	}
+
+	if !isValidCountryCode(address.CountryCode) {
+		return newValidationError(constants.CountryCodeIsNotValid)
+	}
+
+	rule, ok := countryRules[address.CountryCode]
+	if rule.districtRequired && len(address.District) == 0 {
+		return newValidationError(constants.DistrictIsNotValid)
+	}
+
+	if ok && !rule.postalCode.MatchString(address.PostalCode) {
+		return newValidationError(constants.PostalCodeIsNotValid)
+	}
+
+	if !ok && len(address.PostalCode) > 0 && !postalCodePattern.MatchString(address.PostalCode) {
+		return newValidationError(constants.PostalCodeIsNotValid)
+	}
+
+	return nil
+}
+
+// isValidCountryCode accepts the ISO 3166-1 alpha-2 codes of countries.
+func isValidCountryCode(countryCode string) bool {
+	if !countryCodePattern.MatchString(countryCode) {
+		return false
+	}
+
+	region, err := language.ParseRegion(countryCode)
+	return err == nil && region.IsCountry()
+}
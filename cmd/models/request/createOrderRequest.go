@@ -1,12 +1,76 @@
 package request
 
+import (
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+)
+
+// CreateOrderRequest references the ordering customer by CustomerId or creates it inline
+// with Customer. The names of the order default to the customer's. The shipping address
+// is given either structured or, as before, with the flat address, city and district
+// fields; the billing address defaults to it. Orders with items are priced from the
+// product catalog: only the SKU and quantity of an item are read and the total amount is
+// replaced by the sum of the items less the discounts of the promotions that apply,
+// including the one of CouponCode. The subtotal and discounts are set while pricing.
 type CreateOrderRequest struct {
-	OrderNumber  string  `json:"orderNumber"`
-	FirstName    string  `json:"firstName"`
-	LastName     string  `json:"lastName"`
-	TotalAmount  float32 `json:"totalAmount"`
-	Address      string  `json:"address"`
-	City         string  `json:"city"`
-	District     string  `json:"district"`
-	CurrencyCode string  `json:"currencyCode"`
+	OrderNumber     string                   `json:"orderNumber" yaml:"orderNumber" xml:"orderNumber"`
+	FirstName       string                   `json:"firstName" yaml:"firstName" xml:"firstName"`
+	LastName        string                   `json:"lastName" yaml:"lastName" xml:"lastName"`
+	TotalAmount     float32                  `json:"totalAmount" yaml:"totalAmount" xml:"totalAmount"`
+	Address         string                   `json:"address" yaml:"address" xml:"address"`
+	City            string                   `json:"city" yaml:"city" xml:"city"`
+	District        string                   `json:"district" yaml:"district" xml:"district"`
+	CurrencyCode    string                   `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	CustomerId      string                   `json:"customerId" yaml:"customerId" xml:"customerId"`
+	Customer        *CustomerRequest         `json:"customer,omitempty" yaml:"customer,omitempty" xml:"customer,omitempty"`
+	ShippingAddress *response.Address        `json:"shippingAddress,omitempty" yaml:"shippingAddress,omitempty" xml:"shippingAddress,omitempty"`
+	BillingAddress  *response.Address        `json:"billingAddress,omitempty" yaml:"billingAddress,omitempty" xml:"billingAddress,omitempty"`
+	Items           []response.OrderItem     `json:"items,omitempty" yaml:"items,omitempty" xml:"items>item,omitempty"`
+	CouponCode      string                   `json:"couponCode,omitempty" yaml:"couponCode,omitempty" xml:"couponCode,omitempty"`
+	SubtotalAmount  float32                  `json:"-" yaml:"-" xml:"-"`
+	Discounts       []response.OrderDiscount `json:"-" yaml:"-" xml:"-"`
+}
+
+// Order builds the order the request creates, in the created status.
+func (request CreateOrderRequest) Order() response.Order {
+	shipping, billing := request.Shipping(), request.Billing()
+	return response.Order{
+		OrderNumber:     request.OrderNumber,
+		FirstName:       request.FirstName,
+		LastName:        request.LastName,
+		TotalAmount:     request.TotalAmount,
+		Address:         shipping.Street(),
+		City:            shipping.City,
+		District:        shipping.District,
+		CurrencyCode:    request.CurrencyCode,
+		StatusId:        int(enum.Created),
+		CustomerId:      request.CustomerId,
+		ShippingAddress: &shipping,
+		BillingAddress:  &billing,
+		Items:           request.Items,
+		SubtotalAmount:  request.SubtotalAmount,
+		CouponCode:      request.CouponCode,
+		Discounts:       request.Discounts,
+	}
+}
+
+// Shipping returns the normalized shipping address, built from the flat fields when it
+// was not given structured.
+func (request CreateOrderRequest) Shipping() response.Address {
+	return shippingAddress(request.ShippingAddress, request.Address, request.City, request.District)
+}
+
+// Billing returns the normalized billing address, which defaults to the shipping address.
+func (request CreateOrderRequest) Billing() response.Address {
+	if request.BillingAddress == nil {
+		return request.Shipping()
+	}
+	return request.BillingAddress.Normalized()
+}
+
+func shippingAddress(structured *response.Address, address, city, district string) response.Address {
+	if structured != nil {
+		return structured.Normalized()
+	}
+	return response.Address{Lines: []string{address}, City: city, District: district}.Normalized()
 }
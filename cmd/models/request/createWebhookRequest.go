@@ -0,0 +1,7 @@
+package request
+
+type CreateWebhookRequest struct {
+	Url        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"`
+}
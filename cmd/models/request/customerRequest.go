@@ -0,0 +1,18 @@
+package request
+
+// CustomerRequest creates or replaces a customer, either on its own or inline with the
+// customer's first order.
+type CustomerRequest struct {
+	FirstName string            `json:"firstName" yaml:"firstName" xml:"firstName"`
+	LastName  string            `json:"lastName" yaml:"lastName" xml:"lastName"`
+	Email     string            `json:"email" yaml:"email" xml:"email"`
+	Phone     string            `json:"phone" yaml:"phone" xml:"phone"`
+	Addresses []CustomerAddress `json:"addresses" yaml:"addresses" xml:"addresses>address"`
+}
+
+type CustomerAddress struct {
+	Label    string `json:"label" yaml:"label" xml:"label"`
+	Address  string `json:"address" yaml:"address" xml:"address"`
+	City     string `json:"city" yaml:"city" xml:"city"`
+	District string `json:"district" yaml:"district" xml:"district"`
+}
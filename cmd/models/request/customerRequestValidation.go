@@ -0,0 +1,50 @@
+package request
+
+import (
+	"net/mail"
+	"regexp"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// phonePattern accepts international and local numbers with the usual separators.
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,18}[0-9]$`)
+
+// Validate applies the customer rules shared by the customer api and inline customers
+// of created orders.
+func (request CustomerRequest) Validate() *response.ErrorResponse {
+	if errorResp := validateNames(request.FirstName, request.LastName); errorResp != nil {
+		return errorResp
+	}
+
+	if !isValidEmail(request.Email) {
+		return newValidationError(constants.EmailIsNotValid)
+	}
+
+	if len(request.Phone) > 0 && !phonePattern.MatchString(request.Phone) {
+		return newValidationError(constants.PhoneIsNotValid)
+	}
+
+	for _, address := range request.Addresses {
+		if len(strings.TrimSpace(address.Address)) == 0 {
+			return newValidationError(constants.AddressIsNotValid)
+		}
+
+		if len(strings.TrimSpace(address.City)) == 0 {
+			return newValidationError(constants.CityIsNotValid)
+		}
+
+		if len(strings.TrimSpace(address.District)) == 0 {
+			return newValidationError(constants.DistrictIsNotValid)
+		}
+	}
+
+	return nil
+}
+
+// isValidEmail accepts a bare address only, not one with a display name.
+func isValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
@@ -0,0 +1,64 @@
+package request
+
+import "simple-order-api/cmd/models/response"
+
+// OrderFilter selects orders by their fields; empty fields match every order.
+type OrderFilter struct {
+	StatusIds      []int    `json:"statusIds"`
+	CustomerId     string   `json:"customerId"`
+	City           string   `json:"city"`
+	District       string   `json:"district"`
+	CurrencyCode   string   `json:"currencyCode"`
+	MinTotalAmount *float32 `json:"minTotalAmount"`
+	MaxTotalAmount *float32 `json:"maxTotalAmount"`
+}
+
+func (filter OrderFilter) Matches(order response.Order) bool {
+	if len(filter.StatusIds) > 0 && !containsStatusId(filter.StatusIds, order.StatusId) {
+		return false
+	}
+
+	if len(filter.CustomerId) > 0 && order.CustomerId != filter.CustomerId {
+		return false
+	}
+
+	if len(filter.City) > 0 && order.City != filter.City {
+		return false
+	}
+
+	if len(filter.District) > 0 && order.District != filter.District {
+		return false
+	}
+
+	if len(filter.CurrencyCode) > 0 && order.CurrencyCode != filter.CurrencyCode {
+		return false
+	}
+
+	if filter.MinTotalAmount != nil && order.TotalAmount < *filter.MinTotalAmount {
+		return false
+	}
+
+	return filter.MaxTotalAmount == nil || order.TotalAmount <= *filter.MaxTotalAmount
+}
+
+// Apply returns the matching orders in their original order.
+func (filter OrderFilter) Apply(orders []response.Order) []response.Order {
+	matchingOrders := make([]response.Order, 0, len(orders))
+	for _, order := range orders {
+		if filter.Matches(order) {
+			matchingOrders = append(matchingOrders, order)
+		}
+	}
+
+	return matchingOrders
+}
+
+func containsStatusId(statusIds []int, statusId int) bool {
+	for _, candidate := range statusIds {
+		if candidate == statusId {
+			return true
+		}
+	}
+
+	return false
+}
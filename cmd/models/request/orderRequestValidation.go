@@ -0,0 +1,150 @@
+package request
+
+import (
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+const (
+	maxOrderItems        = 100
+	maxOrderItemQuantity = 1000
+)
+
+// Validate applies the order rules shared by every api creating orders.
+func (request CreateOrderRequest) Validate() *response.ErrorResponse {
+	if len(strings.TrimSpace(request.OrderNumber)) == 0 {
+		return newValidationError(constants.OrderNumberIsNotValid)
+	}
+
+	if request.Customer != nil {
+		if len(request.CustomerId) > 0 {
+			return newValidationError(constants.CustomerReferenceIsNotValid)
+		}
+
+		if errorResp := request.Customer.Validate(); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	// orders placed for a customer may leave the names to be taken from it
+	if request.Customer == nil && len(request.CustomerId) == 0 {
+		if errorResp := validateNames(request.FirstName, request.LastName); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	if !isValidCouponCode(request.CouponCode, request.Items) {
+		return newValidationError(constants.CouponCodeIsNotValid)
+	}
+
+	return validateOrderFields(request.TotalAmount, request.Items, request.ShippingAddress, request.BillingAddress,
+		request.Address, request.City, request.District, request.CurrencyCode)
+}
+
+// Validate applies the order rules shared by every api updating orders.
+func (request UpdateOrderRequest) Validate() *response.ErrorResponse {
+	if errorResp := validateNames(request.FirstName, request.LastName); errorResp != nil {
+		return errorResp
+	}
+
+	return validateOrderFields(request.TotalAmount, request.Items, request.ShippingAddress, request.BillingAddress,
+		request.Address, request.City, request.District, request.CurrencyCode)
+}
+
+func (request TransitionOrderRequest) Validate() *response.ErrorResponse {
+	if !enum.OrderStatus(request.StatusId).IsValid() {
+		return newValidationError(constants.StatusIsNotValid)
+	}
+
+	return nil
+}
+
+func validateNames(firstName, lastName string) *response.ErrorResponse {
+	if len(strings.TrimSpace(firstName)) == 0 {
+		return newValidationError(constants.FirstNameIsNotValid)
+	}
+
+	if len(strings.TrimSpace(lastName)) == 0 {
+		return newValidationError(constants.LastNameIsNotValid)
+	}
+
+	return nil
+}
+
+// validateOrderFields checks the items when they are given, which make up the total
+// amount, and the total amount otherwise. Likewise it checks the structured shipping
+// address when it is given and the flat address fields otherwise.
+func validateOrderFields(totalAmount float32, items []response.OrderItem, shippingAddress, billingAddress *response.Address, address, city, district, currencyCode string) *response.ErrorResponse {
+	if len(items) > 0 {
+		if !areValidItems(items) {
+			return newValidationError(constants.OrderItemsAreNotValid)
+		}
+	} else if totalAmount <= 0 {
+		return newValidationError(constants.TotalAmountIsNotValid)
+	}
+
+	if shippingAddress != nil {
+		if errorResp := validateAddress(*shippingAddress); errorResp != nil {
+			return errorResp
+		}
+	} else if errorResp := validateFlatAddress(address, city, district); errorResp != nil {
+		return errorResp
+	}
+
+	if billingAddress != nil {
+		if errorResp := validateAddress(*billingAddress); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	if len(strings.TrimSpace(currencyCode)) == 0 {
+		return newValidationError(constants.CurrencyCodeIsNotValid)
+	}
+
+	return nil
+}
+
+// areValidItems accepts up to maxOrderItems items of distinct SKUs, each ordered at
+// most maxOrderItemQuantity times.
+func areValidItems(items []response.OrderItem) bool {
+	if len(items) > maxOrderItems {
+		return false
+	}
+
+	skus := make(map[string]bool, len(items))
+	for _, item := range items {
+		sku := normalizeSku(item.Sku)
+		if !skuPattern.MatchString(sku) || skus[sku] || item.Quantity < 1 || item.Quantity > maxOrderItemQuantity {
+			return false
+		}
+		skus[sku] = true
+	}
+
+	return true
+}
+
+func validateFlatAddress(address, city, district string) *response.ErrorResponse {
+	if len(strings.TrimSpace(address)) == 0 {
+		return newValidationError(constants.AddressIsNotValid)
+	}
+
+	if len(strings.TrimSpace(city)) == 0 {
+		return newValidationError(constants.CityIsNotValid)
+	}
+
+	if len(strings.TrimSpace(district)) == 0 {
+		return newValidationError(constants.DistrictIsNotValid)
+	}
+
+	return nil
+}
+
+func newValidationError(message string) *response.ErrorResponse {
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusBadRequest, message).
+		Build()
+	return &errorResponse
+}
@@ -0,0 +1,6 @@
+package request
+
+type OrderSubscriptionRequest struct {
+	Action       string   `json:"action"`
+	OrderNumbers []string `json:"orderNumbers"`
+}
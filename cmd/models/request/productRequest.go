@@ -0,0 +1,62 @@
+package request
+
+import (
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// CreateProductRequest adds a product to the catalog. Products are active unless Active
+// is false.
+type CreateProductRequest struct {
+	Sku         string           `json:"sku" yaml:"sku" xml:"sku"`
+	Name        string           `json:"name" yaml:"name" xml:"name"`
+	Description string           `json:"description" yaml:"description" xml:"description"`
+	Prices      []response.Price `json:"prices" yaml:"prices" xml:"prices>price"`
+	Active      *bool            `json:"active,omitempty" yaml:"active,omitempty" xml:"active,omitempty"`
+}
+
+// UpdateProductRequest replaces the fields of a product except its SKU. The product keeps
+// its active flag when Active is missing.
+type UpdateProductRequest struct {
+	Name        string           `json:"name" yaml:"name" xml:"name"`
+	Description string           `json:"description" yaml:"description" xml:"description"`
+	Prices      []response.Price `json:"prices" yaml:"prices" xml:"prices>price"`
+	Active      *bool            `json:"active,omitempty" yaml:"active,omitempty" xml:"active,omitempty"`
+}
+
+// Product builds the product the request creates, without its timestamps.
+func (request CreateProductRequest) Product() response.Product {
+	return response.Product{
+		Sku:         normalizeSku(request.Sku),
+		Name:        strings.TrimSpace(request.Name),
+		Description: strings.TrimSpace(request.Description),
+		Prices:      normalizePrices(request.Prices),
+		Active:      request.Active == nil || *request.Active,
+	}
+}
+
+// Apply replaces the fields of the product with those of the request.
+func (request UpdateProductRequest) Apply(product response.Product) response.Product {
+	product.Name = strings.TrimSpace(request.Name)
+	product.Description = strings.TrimSpace(request.Description)
+	product.Prices = normalizePrices(request.Prices)
+	if request.Active != nil {
+		product.Active = *request.Active
+	}
+	return product
+}
+
+func normalizeSku(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
+func normalizePrices(prices []response.Price) []response.Price {
+	normalizedPrices := make([]response.Price, 0, len(prices))
+	for _, price := range prices {
+		normalizedPrices = append(normalizedPrices, response.Price{
+			CurrencyCode: strings.ToUpper(strings.TrimSpace(price.CurrencyCode)),
+			Amount:       price.Amount,
+		})
+	}
+	return normalizedPrices
+}
@@ -0,0 +1,57 @@
+package request
+
+import (
+	"golang.org/x/text/currency"
+	"regexp"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+const (
+	maxProductNameLength        = 200
+	maxProductDescriptionLength = 2000
+)
+
+// skuPattern accepts upper case SKUs like "TSHIRT-BLK-M"; lower case input is upper
+// cased before.
+var skuPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{1,31}$`)
+
+func (request CreateProductRequest) Validate() *response.ErrorResponse {
+	if !skuPattern.MatchString(normalizeSku(request.Sku)) {
+		return newValidationError(constants.SkuIsNotValid)
+	}
+
+	return validateProductFields(request.Name, request.Description, request.Prices)
+}
+
+func (request UpdateProductRequest) Validate() *response.ErrorResponse {
+	return validateProductFields(request.Name, request.Description, request.Prices)
+}
+
+// validateProductFields requires a price in at least one currency and at most one price
+// per currency.
+func validateProductFields(name, description string, prices []response.Price) *response.ErrorResponse {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len([]rune(name)) > maxProductNameLength {
+		return newValidationError(constants.ProductNameIsNotValid)
+	}
+
+	if len([]rune(strings.TrimSpace(description))) > maxProductDescriptionLength {
+		return newValidationError(constants.ProductDescriptionIsNotValid)
+	}
+
+	if len(prices) == 0 {
+		return newValidationError(constants.ProductPricesAreNotValid)
+	}
+
+	currencyCodes := make(map[string]bool, len(prices))
+	for _, price := range normalizePrices(prices) {
+		if _, err := currency.ParseISO(price.CurrencyCode); err != nil || currencyCodes[price.CurrencyCode] || price.Amount <= 0 {
+			return newValidationError(constants.ProductPricesAreNotValid)
+		}
+		currencyCodes[price.CurrencyCode] = true
+	}
+
+	return nil
+}
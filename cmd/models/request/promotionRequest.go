@@ -0,0 +1,80 @@
+package request
+
+import (
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"time"
+)
+
+// CreatePromotionRequest adds a coupon, or an automatic promotion when Automatic is
+// true. Promotions are active unless Active is false.
+type CreatePromotionRequest struct {
+	Code                  string     `json:"code" yaml:"code" xml:"code"`
+	Name                  string     `json:"name" yaml:"name" xml:"name"`
+	Automatic             bool       `json:"automatic" yaml:"automatic" xml:"automatic"`
+	Type                  string     `json:"type" yaml:"type" xml:"type"`
+	Value                 float32    `json:"value" yaml:"value" xml:"value"`
+	CurrencyCode          string     `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	MinBasketAmount       float32    `json:"minBasketAmount" yaml:"minBasketAmount" xml:"minBasketAmount"`
+	ValidFrom             *time.Time `json:"validFrom,omitempty" yaml:"validFrom,omitempty" xml:"validFrom,omitempty"`
+	ValidUntil            *time.Time `json:"validUntil,omitempty" yaml:"validUntil,omitempty" xml:"validUntil,omitempty"`
+	UsageLimit            int        `json:"usageLimit" yaml:"usageLimit" xml:"usageLimit"`
+	UsageLimitPerCustomer int        `json:"usageLimitPerCustomer" yaml:"usageLimitPerCustomer" xml:"usageLimitPerCustomer"`
+	Active                *bool      `json:"active,omitempty" yaml:"active,omitempty" xml:"active,omitempty"`
+}
+
+// UpdatePromotionRequest replaces the fields of a promotion except its code and whether
+// it is automatic. The promotion keeps its active flag when Active is missing.
+type UpdatePromotionRequest struct {
+	Name                  string     `json:"name" yaml:"name" xml:"name"`
+	Type                  string     `json:"type" yaml:"type" xml:"type"`
+	Value                 float32    `json:"value" yaml:"value" xml:"value"`
+	CurrencyCode          string     `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	MinBasketAmount       float32    `json:"minBasketAmount" yaml:"minBasketAmount" xml:"minBasketAmount"`
+	ValidFrom             *time.Time `json:"validFrom,omitempty" yaml:"validFrom,omitempty" xml:"validFrom,omitempty"`
+	ValidUntil            *time.Time `json:"validUntil,omitempty" yaml:"validUntil,omitempty" xml:"validUntil,omitempty"`
+	UsageLimit            int        `json:"usageLimit" yaml:"usageLimit" xml:"usageLimit"`
+	UsageLimitPerCustomer int        `json:"usageLimitPerCustomer" yaml:"usageLimitPerCustomer" xml:"usageLimitPerCustomer"`
+	Active                *bool      `json:"active,omitempty" yaml:"active,omitempty" xml:"active,omitempty"`
+}
+
+// Promotion builds the promotion the request creates, without its timestamps.
+func (request CreatePromotionRequest) Promotion() response.Promotion {
+	return response.Promotion{
+		Code:                  NormalizePromotionCode(request.Code),
+		Name:                  strings.TrimSpace(request.Name),
+		Automatic:             request.Automatic,
+		Type:                  strings.ToLower(strings.TrimSpace(request.Type)),
+		Value:                 request.Value,
+		CurrencyCode:          strings.ToUpper(strings.TrimSpace(request.CurrencyCode)),
+		MinBasketAmount:       request.MinBasketAmount,
+		ValidFrom:             request.ValidFrom,
+		ValidUntil:            request.ValidUntil,
+		UsageLimit:            request.UsageLimit,
+		UsageLimitPerCustomer: request.UsageLimitPerCustomer,
+		Active:                request.Active == nil || *request.Active,
+	}
+}
+
+// Apply replaces the fields of the promotion with those of the request.
+func (request UpdatePromotionRequest) Apply(promotion response.Promotion) response.Promotion {
+	promotion.Name = strings.TrimSpace(request.Name)
+	promotion.Type = strings.ToLower(strings.TrimSpace(request.Type))
+	promotion.Value = request.Value
+	promotion.CurrencyCode = strings.ToUpper(strings.TrimSpace(request.CurrencyCode))
+	promotion.MinBasketAmount = request.MinBasketAmount
+	promotion.ValidFrom = request.ValidFrom
+	promotion.ValidUntil = request.ValidUntil
+	promotion.UsageLimit = request.UsageLimit
+	promotion.UsageLimitPerCustomer = request.UsageLimitPerCustomer
+	if request.Active != nil {
+		promotion.Active = *request.Active
+	}
+	return promotion
+}
+
+// NormalizePromotionCode upper cases promotion and coupon codes, so that "welcome10"
+// finds "WELCOME10".
+func NormalizePromotionCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
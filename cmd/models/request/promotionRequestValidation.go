@@ -0,0 +1,80 @@
+package request
+
+import (
+	"golang.org/x/text/currency"
+	"regexp"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"time"
+)
+
+const maxPromotionNameLength = 200
+
+// promotionCodePattern accepts upper case codes like "WELCOME10"; lower case input is
+// upper cased before.
+var promotionCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{2,31}$`)
+
+func (request CreatePromotionRequest) Validate() *response.ErrorResponse {
+	if !promotionCodePattern.MatchString(NormalizePromotionCode(request.Code)) {
+		return newValidationError(constants.PromotionCodeIsNotValid)
+	}
+
+	return validatePromotionFields(request.Name, request.Type, request.Value, request.CurrencyCode,
+		request.MinBasketAmount, request.ValidFrom, request.ValidUntil, request.UsageLimit, request.UsageLimitPerCustomer)
+}
+
+func (request UpdatePromotionRequest) Validate() *response.ErrorResponse {
+	return validatePromotionFields(request.Name, request.Type, request.Value, request.CurrencyCode,
+		request.MinBasketAmount, request.ValidFrom, request.ValidUntil, request.UsageLimit, request.UsageLimitPerCustomer)
+}
+
+// validatePromotionFields requires percentages up to 100 and a currency for fixed
+// discounts and minimum basket amounts, which are only comparable within one currency.
+func validatePromotionFields(name, promotionType string, value float32, currencyCode string, minBasketAmount float32,
+	validFrom, validUntil *time.Time, usageLimit, usageLimitPerCustomer int) *response.ErrorResponse {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len([]rune(name)) > maxPromotionNameLength {
+		return newValidationError(constants.PromotionNameIsNotValid)
+	}
+
+	promotionType = strings.ToLower(strings.TrimSpace(promotionType))
+	if !enum.PromotionType(promotionType).IsValid() {
+		return newValidationError(constants.PromotionTypeIsNotValid)
+	}
+
+	if value <= 0 || (enum.PromotionType(promotionType) == enum.PercentagePromotion && value > 100) {
+		return newValidationError(constants.PromotionValueIsNotValid)
+	}
+
+	currencyCode = strings.ToUpper(strings.TrimSpace(currencyCode))
+	if len(currencyCode) > 0 {
+		if _, err := currency.ParseISO(currencyCode); err != nil {
+			return newValidationError(constants.PromotionCurrencyCodeIsNotValid)
+		}
+	} else if enum.PromotionType(promotionType) == enum.FixedPromotion {
+		return newValidationError(constants.PromotionCurrencyCodeIsNotValid)
+	}
+
+	if minBasketAmount < 0 || (minBasketAmount > 0 && len(currencyCode) == 0) {
+		return newValidationError(constants.PromotionMinBasketAmountIsNotValid)
+	}
+
+	if validFrom != nil && validUntil != nil && !validUntil.After(*validFrom) {
+		return newValidationError(constants.PromotionValidityIsNotValid)
+	}
+
+	if usageLimit < 0 || usageLimitPerCustomer < 0 {
+		return newValidationError(constants.PromotionUsageLimitIsNotValid)
+	}
+
+	return nil
+}
+
+// isValidCouponCode accepts codes of the promotion code format on orders with items, as
+// orders without items are not priced from the catalog.
+func isValidCouponCode(couponCode string, items []response.OrderItem) bool {
+	return len(couponCode) == 0 ||
+		(len(items) > 0 && promotionCodePattern.MatchString(NormalizePromotionCode(couponCode)))
+}
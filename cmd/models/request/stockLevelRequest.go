@@ -0,0 +1,20 @@
+package request
+
+import (
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/response"
+)
+
+// StockLevelRequest sets the units of a product on hand in a warehouse, e.g. after a
+// stock count or a delivery.
+type StockLevelRequest struct {
+	OnHand int `json:"onHand" yaml:"onHand" xml:"onHand"`
+}
+
+func (request StockLevelRequest) Validate() *response.ErrorResponse {
+	if request.OnHand < 0 {
+		return newValidationError(constants.StockLevelIsNotValid)
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package request
+
+type TransitionOrderRequest struct {
+	StatusId int `json:"statusId" yaml:"statusId" xml:"statusId"`
+}
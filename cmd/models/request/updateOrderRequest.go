@@ -1,11 +1,69 @@
 package request
 
+import (
+	"simple-order-api/cmd/models/response"
+	"strings"
+)
+
+// UpdateOrderRequest replaces the shipping address like CreateOrderRequest gives it. The
+// billing address is kept when it is missing, since clients sending only the flat fields
+// do not know about it. Items are priced like those of CreateOrderRequest and replace the
+// items of the order; without them the order keeps its items. Its coupon can not be
+// changed, the subtotal, coupon and discounts are set while pricing.
 type UpdateOrderRequest struct {
-	FirstName    string  `json:"firstName"`
-	LastName     string  `json:"lastName"`
-	TotalAmount  float32 `json:"totalAmount"`
-	Address      string  `json:"address"`
-	City         string  `json:"city"`
-	District     string  `json:"district"`
-	CurrencyCode string  `json:"currencyCode"`
+	FirstName       string                   `json:"firstName" yaml:"firstName" xml:"firstName"`
+	LastName        string                   `json:"lastName" yaml:"lastName" xml:"lastName"`
+	TotalAmount     float32                  `json:"totalAmount" yaml:"totalAmount" xml:"totalAmount"`
+	Address         string                   `json:"address" yaml:"address" xml:"address"`
+	City            string                   `json:"city" yaml:"city" xml:"city"`
+	District        string                   `json:"district" yaml:"district" xml:"district"`
+	CurrencyCode    string                   `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	ShippingAddress *response.Address        `json:"shippingAddress,omitempty" yaml:"shippingAddress,omitempty" xml:"shippingAddress,omitempty"`
+	BillingAddress  *response.Address        `json:"billingAddress,omitempty" yaml:"billingAddress,omitempty" xml:"billingAddress,omitempty"`
+	Items           []response.OrderItem     `json:"items,omitempty" yaml:"items,omitempty" xml:"items>item,omitempty"`
+	SubtotalAmount  float32                  `json:"-" yaml:"-" xml:"-"`
+	CouponCode      string                   `json:"-" yaml:"-" xml:"-"`
+	Discounts       []response.OrderDiscount `json:"-" yaml:"-" xml:"-"`
+}
+
+// Shipping returns the normalized shipping address of the updated order. Without a
+// structured one, the flat fields replace the street, city and district of the order's
+// shipping address, which keeps its postal code and country code; an unchanged flat
+// address keeps its lines.
+func (request UpdateOrderRequest) Shipping(order response.Order) response.Address {
+	if request.ShippingAddress != nil || order.ShippingAddress == nil {
+		return shippingAddress(request.ShippingAddress, request.Address, request.City, request.District)
+	}
+
+	shipping := *order.ShippingAddress
+	if strings.TrimSpace(request.Address) != shipping.Street() {
+		shipping.Lines = []string{request.Address}
+	}
+	shipping.City = request.City
+	shipping.District = request.District
+	return shipping.Normalized()
+}
+
+// Apply replaces the fields of the order with those of the request.
+func (request UpdateOrderRequest) Apply(order response.Order) response.Order {
+	shipping := request.Shipping(order)
+	order.FirstName = request.FirstName
+	order.LastName = request.LastName
+	order.TotalAmount = request.TotalAmount
+	order.Address = shipping.Street()
+	order.City = shipping.City
+	order.District = shipping.District
+	order.CurrencyCode = request.CurrencyCode
+	order.ShippingAddress = &shipping
+	if request.BillingAddress != nil {
+		billing := request.BillingAddress.Normalized()
+		order.BillingAddress = &billing
+	}
+	if len(request.Items) > 0 {
+		order.Items = request.Items
+		order.SubtotalAmount = request.SubtotalAmount
+		order.CouponCode = request.CouponCode
+		order.Discounts = request.Discounts
+	}
+	return order
 }
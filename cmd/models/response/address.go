@@ -0,0 +1,34 @@
+package response
+
+import "strings"
+
+// Address is a postal address. Orders carry one for shipping and one for billing; the
+// flat address, city and district fields of an order mirror its shipping address.
+type Address struct {
+	Lines       []string `json:"lines" yaml:"lines" xml:"lines>line"`
+	District    string   `json:"district,omitempty" yaml:"district,omitempty" xml:"district,omitempty"`
+	City        string   `json:"city" yaml:"city" xml:"city"`
+	PostalCode  string   `json:"postalCode,omitempty" yaml:"postalCode,omitempty" xml:"postalCode,omitempty"`
+	CountryCode string   `json:"countryCode,omitempty" yaml:"countryCode,omitempty" xml:"countryCode,omitempty"`
+}
+
+// Street joins the address lines into the single line of the flat address field.
+func (address Address) Street() string {
+	return strings.Join(address.Lines, ", ")
+}
+
+// Normalized trims the fields and upper cases the codes, e.g. "sw1a 1aa" to "SW1A 1AA".
+func (address Address) Normalized() Address {
+	lines := make([]string, 0, len(address.Lines))
+	for _, line := range address.Lines {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	return Address{
+		Lines:       lines,
+		District:    strings.TrimSpace(address.District),
+		City:        strings.TrimSpace(address.City),
+		PostalCode:  strings.ToUpper(strings.TrimSpace(address.PostalCode)),
+		CountryCode: strings.ToUpper(strings.TrimSpace(address.CountryCode)),
+	}
+}
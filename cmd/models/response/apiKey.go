@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+type ApiKey struct {
+	Id         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	KeyHash    string     `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RotatedAt  *time.Time `json:"rotatedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	UsageCount int64      `json:"usageCount"`
+}
+
+// CreatedApiKey is returned only when a key is created or rotated since the
+// plain key is never stored.
+type CreatedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type FieldChange struct {
+	Field  string      `json:"field" yaml:"field" xml:"field"`
+	Before interface{} `json:"before" yaml:"before" xml:"before"`
+	After  interface{} `json:"after" yaml:"after" xml:"after"`
+}
+
+type AuditEntry struct {
+	Id          string        `json:"id" yaml:"id" xml:"id"`
+	OrderNumber string        `json:"orderNumber" yaml:"orderNumber" xml:"orderNumber"`
+	Action      string        `json:"action" yaml:"action" xml:"action"`
+	Actor       string        `json:"actor" yaml:"actor" xml:"actor"`
+	RequestId   string        `json:"requestId" yaml:"requestId" xml:"requestId"`
+	Timestamp   time.Time     `json:"timestamp" yaml:"timestamp" xml:"timestamp"`
+	Changes     []FieldChange `json:"changes" yaml:"changes" xml:"changes>change"`
+}
+
+// AuditLog is the root element of an audit log written as xml.
+type AuditLog struct {
+	XMLName xml.Name     `xml:"audit"`
+	Entries []AuditEntry `xml:"entry"`
+}
@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+// Customer is stored once and referenced by its orders through their customer id.
+type Customer struct {
+	Id        string            `json:"id"`
+	FirstName string            `json:"firstName"`
+	LastName  string            `json:"lastName"`
+	Email     string            `json:"email"`
+	Phone     string            `json:"phone,omitempty"`
+	Addresses []CustomerAddress `json:"addresses"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// CustomerAddress is an address saved by the customer, e.g. "home" or "work".
+type CustomerAddress struct {
+	Label    string `json:"label,omitempty"`
+	Address  string `json:"address"`
+	City     string `json:"city"`
+	District string `json:"district"`
+}
@@ -1,8 +1,11 @@
 package response
 
+import "encoding/xml"
+
 type ErrorResponse struct {
-	Message    string `json:"message"`
-	StatusCode int    `json:"statusCode"`
+	XMLName    xml.Name `json:"-" yaml:"-" xml:"error"`
+	Message    string   `json:"message" yaml:"message" xml:"message"`
+	StatusCode int      `json:"statusCode" yaml:"statusCode" xml:"statusCode"`
 }
 
 type ErrorBuilder interface {
@@ -0,0 +1,21 @@
+package response
+
+// Country is a country of the reference catalog, identified by its ISO 3166-1 alpha-2 code.
+type Country struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// City is a city of the reference catalog.
+type City struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	CountryCode string `json:"countryCode"`
+}
+
+// District is a district of a city of the reference catalog.
+type District struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	CityId string `json:"cityId"`
+}
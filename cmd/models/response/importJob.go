@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+// ImportJob reports the progress of an order import. Rows are counted as processed once
+// they are validated and, unless it is a dry run, stored.
+type ImportJob struct {
+	Id            string           `json:"id"`
+	Status        string           `json:"status"`
+	Format        string           `json:"format"`
+	DryRun        bool             `json:"dryRun"`
+	TotalRows     int              `json:"totalRows"`
+	ProcessedRows int              `json:"processedRows"`
+	ImportedRows  int              `json:"importedRows"`
+	FailedRows    int              `json:"failedRows"`
+	Errors        []ImportRowError `json:"errors"`
+	CreatedBy     string           `json:"createdBy,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	CompletedAt   *time.Time       `json:"completedAt,omitempty"`
+}
+
+// ImportRowError is the reason a row was not imported; Row is the line of the row in
+// the imported file.
+type ImportRowError struct {
+	Row         int           `json:"row"`
+	OrderNumber string        `json:"orderNumber,omitempty"`
+	Error       ErrorResponse `json:"error"`
+}
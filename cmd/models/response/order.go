@@ -1,13 +1,50 @@
 package response
 
+import "encoding/xml"
+
 type Order struct {
-	OrderNumber  string  `json:"orderNumber"`
-	FirstName    string  `json:"firstName"`
-	LastName     string  `json:"lastName"`
-	TotalAmount  float32 `json:"totalAmount"`
-	Address      string  `json:"string"`
-	City         string  `json:"city"`
-	District     string  `json:"district"`
-	CurrencyCode string  `json:"currencyCode"`
-	StatusId     int     `json:"statusId"`
+	XMLName      xml.Name `json:"-" yaml:"-" xml:"order"`
+	OrderNumber  string   `json:"orderNumber" yaml:"orderNumber" xml:"orderNumber"`
+	FirstName    string   `json:"firstName" yaml:"firstName" xml:"firstName"`
+	LastName     string   `json:"lastName" yaml:"lastName" xml:"lastName"`
+	TotalAmount  float32  `json:"totalAmount" yaml:"totalAmount" xml:"totalAmount"`
+	Address      string   `json:"address" yaml:"address" xml:"address"`
+	City         string   `json:"city" yaml:"city" xml:"city"`
+	District     string   `json:"district" yaml:"district" xml:"district"`
+	CurrencyCode string   `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	StatusId     int      `json:"statusId" yaml:"statusId" xml:"statusId"`
+	CustomerId   string   `json:"customerId" yaml:"customerId" xml:"customerId"`
+	// ShippingAddress and BillingAddress are omitted when unknown.
+	ShippingAddress *Address `json:"shippingAddress,omitempty" yaml:"shippingAddress,omitempty" xml:"shippingAddress,omitempty"`
+	BillingAddress  *Address `json:"billingAddress,omitempty" yaml:"billingAddress,omitempty" xml:"billingAddress,omitempty"`
+	// Items are omitted for orders placed with a total amount only.
+	Items []OrderItem `json:"items,omitempty" yaml:"items,omitempty" xml:"items>item,omitempty"`
+	// SubtotalAmount is the sum of the items before the discounts, which TotalAmount is
+	// reduced by. Both are omitted for orders without items.
+	SubtotalAmount float32         `json:"subtotalAmount,omitempty" yaml:"subtotalAmount,omitempty" xml:"subtotalAmount,omitempty"`
+	CouponCode     string          `json:"couponCode,omitempty" yaml:"couponCode,omitempty" xml:"couponCode,omitempty"`
+	Discounts      []OrderDiscount `json:"discounts,omitempty" yaml:"discounts,omitempty" xml:"discounts>discount,omitempty"`
+}
+
+// OrderItem is a line of an order. Its name and prices are taken from the product
+// catalog when the order is placed, so later price changes do not alter the order.
+type OrderItem struct {
+	Sku       string  `json:"sku" yaml:"sku" xml:"sku"`
+	Name      string  `json:"name" yaml:"name" xml:"name"`
+	Quantity  int     `json:"quantity" yaml:"quantity" xml:"quantity"`
+	UnitPrice float32 `json:"unitPrice" yaml:"unitPrice" xml:"unitPrice"`
+	Amount    float32 `json:"amount" yaml:"amount" xml:"amount"`
+}
+
+// OrderDiscount is the amount a coupon or an automatic promotion takes off an order.
+type OrderDiscount struct {
+	PromotionCode string  `json:"promotionCode" yaml:"promotionCode" xml:"promotionCode"`
+	Name          string  `json:"name" yaml:"name" xml:"name"`
+	Amount        float32 `json:"amount" yaml:"amount" xml:"amount"`
+}
+
+// OrderList is the root element of a list of orders written as xml.
+type OrderList struct {
+	XMLName xml.Name `xml:"orders"`
+	Orders  []Order  `xml:"order"`
 }
@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+type OrderTrackingMessage struct {
+	Type             string         `json:"type"`
+	OrderNumbers     []string       `json:"orderNumbers,omitempty"`
+	OrderNumber      string         `json:"orderNumber,omitempty"`
+	StatusId         int            `json:"statusId,omitempty"`
+	PreviousStatusId int            `json:"previousStatusId,omitempty"`
+	OccurredAt       *time.Time     `json:"occurredAt,omitempty"`
+	Error            *ErrorResponse `json:"error,omitempty"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+// Product is an item of the catalog, referenced by the items of orders through its SKU.
+// Inactive products are kept for the orders referencing them but can not be ordered.
+type Product struct {
+	Sku         string    `json:"sku"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Prices      []Price   `json:"prices"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Price is the price of a product in one currency.
+type Price struct {
+	CurrencyCode string  `json:"currencyCode" yaml:"currencyCode" xml:"currencyCode"`
+	Amount       float32 `json:"amount" yaml:"amount" xml:"amount"`
+}
+
+// PriceIn returns the price of the product in the currency, if it has one.
+func (product Product) PriceIn(currencyCode string) (float32, bool) {
+	for _, price := range product.Prices {
+		if price.CurrencyCode == currencyCode {
+			return price.Amount, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+// Promotion discounts orders priced from the product catalog. Coupons are applied to the
+// orders giving their code, automatic promotions to every order they apply to. Fixed
+// discounts and minimum basket amounts are in CurrencyCode and apply only to orders in
+// that currency; percentage promotions without a currency apply to orders in any.
+type Promotion struct {
+	Code            string     `json:"code"`
+	Name            string     `json:"name"`
+	Automatic       bool       `json:"automatic"`
+	Type            string     `json:"type"`
+	Value           float32    `json:"value"`
+	CurrencyCode    string     `json:"currencyCode,omitempty"`
+	MinBasketAmount float32    `json:"minBasketAmount,omitempty"`
+	ValidFrom       *time.Time `json:"validFrom,omitempty"`
+	ValidUntil      *time.Time `json:"validUntil,omitempty"`
+	// UsageLimit and UsageLimitPerCustomer bound the orders using the promotion, in total
+	// and per customer; zero means unlimited.
+	UsageLimit            int       `json:"usageLimit,omitempty"`
+	UsageLimitPerCustomer int       `json:"usageLimitPerCustomer,omitempty"`
+	TimesUsed             int       `json:"timesUsed"`
+	Active                bool      `json:"active"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// IsRunningAt reports whether the promotion is active and within its validity window,
+// which includes ValidFrom and excludes ValidUntil.
+func (promotion Promotion) IsRunningAt(now time.Time) bool {
+	return promotion.Active &&
+		(promotion.ValidFrom == nil || !now.Before(*promotion.ValidFrom)) &&
+		(promotion.ValidUntil == nil || now.Before(*promotion.ValidUntil))
+}
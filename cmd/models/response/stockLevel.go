@@ -0,0 +1,18 @@
+package response
+
+// StockLevel is the stock of a product in a warehouse. Reserved units belong to orders
+// not transferred yet; only the available ones can be reserved by new orders.
+type StockLevel struct {
+	Sku         string `json:"sku"`
+	WarehouseId string `json:"warehouseId"`
+	OnHand      int    `json:"onHand"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
+// ReservationLine is the part of an order item reserved in one warehouse.
+type ReservationLine struct {
+	Sku         string `json:"sku"`
+	WarehouseId string `json:"warehouseId"`
+	Quantity    int    `json:"quantity"`
+}
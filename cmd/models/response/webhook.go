@@ -0,0 +1,41 @@
+package response
+
+import (
+	"encoding/json"
+	"simple-order-api/cmd/models"
+	"time"
+)
+
+// Webhook is owned by the principal that registered it. Only its owner can see it, and
+// it receives only the events of orders its owner can read.
+type Webhook struct {
+	Id         string            `json:"id"`
+	Url        string            `json:"url"`
+	EventTypes []string          `json:"eventTypes"`
+	Secret     string            `json:"-"`
+	Owner      *models.Principal `json:"-"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// CreatedWebhook is returned only when a webhook is created so that a generated
+// signing secret can be handed over once.
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookDelivery logs the delivery of one event to one webhook, including every retry
+// and manual redelivery.
+type WebhookDelivery struct {
+	Id                 string          `json:"id"`
+	WebhookId          string          `json:"webhookId"`
+	EventId            string          `json:"eventId"`
+	EventType          string          `json:"eventType"`
+	Payload            json.RawMessage `json:"payload" swaggertype:"object"`
+	Status             string          `json:"status"`
+	Attempts           int             `json:"attempts"`
+	ResponseStatusCode int             `json:"responseStatusCode,omitempty"`
+	LastError          string          `json:"lastError,omitempty"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	LastAttemptAt      *time.Time      `json:"lastAttemptAt,omitempty"`
+}
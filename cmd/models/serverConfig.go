@@ -1,6 +1,18 @@
 package models
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port        string
+	Host        string
+	Grpc        GrpcConfig
+	Tracing     TracingConfig
+	Auth        AuthConfig
+	RateLimit   RateLimitConfig
+	Cors        CorsConfig
+	Outbox      OutboxConfig
+	Webhooks    WebhookConfig
+	EventStream EventStreamConfig
+	WebSocket   WebSocketConfig
+	Graphql     GraphqlConfig
+	Export      ExportConfig
+	Import      ImportConfig
 }
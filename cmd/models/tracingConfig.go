@@ -0,0 +1,10 @@
+package models
+
+type TracingConfig struct {
+	Enabled      bool
+	ServiceName  string
+	Exporter     string
+	OtlpEndpoint string
+	OtlpInsecure bool
+	SampleRatio  float64
+}
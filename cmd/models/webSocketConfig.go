@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type WebSocketConfig struct {
+	PingInterval     time.Duration
+	PongWait         time.Duration
+	WriteWait        time.Duration
+	MaxSubscriptions int
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type WebhookConfig struct {
+	Timeout        time.Duration
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// AllowInternalHosts lets webhooks target loopback and private addresses, e.g. in tests.
+	AllowInternalHosts bool
+}
@@ -0,0 +1,129 @@
+package policies
+
+import "simple-order-api/cmd/models"
+
+type Permission string
+
+const (
+	ReadOrders       Permission = "orders:read"
+	ReadAllOrders    Permission = "orders:read:all"
+	CreateOrders     Permission = "orders:create"
+	UpdateOrders     Permission = "orders:update"
+	TransitionOrders Permission = "orders:transition"
+	DeleteOrders     Permission = "orders:delete"
+	// EnterOrderTotals allows orders without items, whose total amount is taken as given
+	// instead of being priced from the catalog, e.g. for orders taken offline or imported.
+	EnterOrderTotals Permission = "orders:total:enter"
+	ReadOrderAudit   Permission = "orders:audit:read"
+	ManageApiKeys    Permission = "apikeys:manage"
+	ManageWebhooks   Permission = "webhooks:manage"
+	ReadCustomers    Permission = "customers:read"
+	ReadAllCustomers Permission = "customers:read:all"
+	WriteCustomers   Permission = "customers:write"
+	DeleteCustomers  Permission = "customers:delete"
+	ReadProducts     Permission = "products:read"
+	WriteProducts    Permission = "products:write"
+	ReadInventory    Permission = "inventory:read"
+	WriteInventory   Permission = "inventory:write"
+	ReadPromotions   Permission = "promotions:read"
+	WritePromotions  Permission = "promotions:write"
+)
+
+const (
+	CustomerRole = "customer"
+	OperatorRole = "operator"
+	AdminRole    = "admin"
+)
+
+const (
+	ReadOrdersScope  = "orders:read"
+	WriteOrdersScope = "orders:write"
+	WebhooksScope    = "webhooks:manage"
+	ProductsScope    = "products:manage"
+	InventoryScope   = "inventory:manage"
+	PromotionsScope  = "promotions:manage"
+	ApiKeysScope     = "apikeys:manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	CustomerRole: {ReadOrders, CreateOrders, ReadCustomers, WriteCustomers, ReadProducts},
+	OperatorRole: {
+		ReadOrders, ReadAllOrders, CreateOrders, UpdateOrders, TransitionOrders, ReadOrderAudit, EnterOrderTotals,
+		ReadCustomers, ReadAllCustomers, WriteCustomers, ReadProducts, WriteProducts, ReadInventory, WriteInventory,
+		ReadPromotions, WritePromotions,
+	},
+	AdminRole: {
+		ReadOrders, ReadAllOrders, CreateOrders, UpdateOrders, TransitionOrders, DeleteOrders, ReadOrderAudit, EnterOrderTotals,
+		ManageApiKeys, ManageWebhooks, ReadCustomers, ReadAllCustomers, WriteCustomers, DeleteCustomers,
+		ReadProducts, WriteProducts, ReadInventory, WriteInventory, ReadPromotions, WritePromotions,
+	},
+}
+
+// scopePermissions grants machine clients authenticated by api key access to the orders of every customer.
+// Changing an order reads it first, so the write scope includes the reads it needs.
+var scopePermissions = map[string][]Permission{
+	ReadOrdersScope: {ReadOrders, ReadAllOrders, ReadCustomers, ReadAllCustomers, ReadProducts},
+	WriteOrdersScope: {
+		ReadOrders, ReadAllOrders, CreateOrders, UpdateOrders, TransitionOrders, EnterOrderTotals,
+		ReadCustomers, ReadAllCustomers, WriteCustomers, ReadProducts,
+	},
+	WebhooksScope:   {ManageWebhooks},
+	ProductsScope:   {ReadProducts, WriteProducts},
+	InventoryScope:  {ReadInventory, WriteInventory},
+	PromotionsScope: {ReadPromotions, WritePromotions},
+	ApiKeysScope:    {ManageApiKeys},
+}
+
+// HasPermission reports whether the principal is granted the permission by one of its roles or scopes.
+// A nil principal means authentication is disabled or the route is public, so nothing is restricted.
+func HasPermission(principal *models.Principal, permission Permission) bool {
+	if principal == nil {
+		return true
+	}
+
+	return isGranted(rolePermissions, principal.Roles, permission) ||
+		isGranted(scopePermissions, principal.Scopes, permission)
+}
+
+// CanAccessOrder reports whether the principal may see an order owned by customerId.
+func CanAccessOrder(principal *models.Principal, customerId string) bool {
+	return HasPermission(principal, ReadAllOrders) || principal.Subject == customerId
+}
+
+// CanAccessCustomer reports whether the principal may see or change the customer. The
+// record of a customer principal carries the subject of its token as id.
+func CanAccessCustomer(principal *models.Principal, customerId string) bool {
+	return HasPermission(principal, ReadAllCustomers) || principal.Subject == customerId
+}
+
+// IsCustomer reports whether the principal is a user ordering for itself, whose customer
+// record carries the subject of its token as id.
+func IsCustomer(principal *models.Principal) bool {
+	if principal == nil || principal.Type != models.UserPrincipal || HasPermission(principal, ReadAllCustomers) {
+		return false
+	}
+
+	for _, role := range principal.Roles {
+		if role == CustomerRole {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidScope(scope string) bool {
+	_, ok := scopePermissions[scope]
+	return ok
+}
+
+func isGranted(grants map[string][]Permission, names []string, permission Permission) bool {
+	for _, name := range names {
+		for _, granted := range grants[name] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sync"
+	"time"
+)
+
+//go:generate mockery --name=ApiKeyRepository --structname=MockApiKeyRepository --output=../mocks --filename=fakeApiKeyRepositoryWithMockery.go
+type ApiKeyRepository interface {
+	FetchApiKeys(ctx context.Context) ([]response.ApiKey, *response.ErrorResponse)
+	FetchApiKeyById(ctx context.Context, id string) (*response.ApiKey, *response.ErrorResponse)
+	FetchApiKeyByHash(ctx context.Context, keyHash string) (*response.ApiKey, *response.ErrorResponse)
+	SaveApiKey(ctx context.Context, apiKey response.ApiKey) *response.ErrorResponse
+	RecordApiKeyUsage(ctx context.Context, id string, usedAt time.Time) *response.ErrorResponse
+}
+
+// ApiKeyRepositoryImp keeps api keys in memory. Only the hash of a key is stored.
+type ApiKeyRepositoryImp struct {
+	mutex     sync.RWMutex
+	apiKeys   map[string]response.ApiKey
+	idsByHash map[string]string
+}
+
+func (a *ApiKeyRepositoryImp) FetchApiKeys(ctx context.Context) (apiKeys []response.ApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyRepository.FetchApiKeys")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	apiKeys = make([]response.ApiKey, 0, len(a.apiKeys))
+	for _, apiKey := range a.apiKeys {
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, nil
+}
+
+func (a *ApiKeyRepositoryImp) FetchApiKeyById(ctx context.Context, id string) (_ *response.ApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyRepository.FetchApiKeyById")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	apiKey, ok := a.apiKeys[id]
+	if !ok {
+		return nil, nil
+	}
+	return &apiKey, nil
+}
+
+func (a *ApiKeyRepositoryImp) FetchApiKeyByHash(ctx context.Context, keyHash string) (_ *response.ApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyRepository.FetchApiKeyByHash")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	apiKey, ok := a.apiKeys[a.idsByHash[keyHash]]
+	if !ok {
+		return nil, nil
+	}
+	return &apiKey, nil
+}
+
+func (a *ApiKeyRepositoryImp) SaveApiKey(ctx context.Context, apiKey response.ApiKey) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyRepository.SaveApiKey")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if existing, ok := a.apiKeys[apiKey.Id]; ok {
+		delete(a.idsByHash, existing.KeyHash)
+	}
+	a.apiKeys[apiKey.Id] = apiKey
+	a.idsByHash[apiKey.KeyHash] = apiKey.Id
+	return nil
+}
+
+func (a *ApiKeyRepositoryImp) RecordApiKeyUsage(ctx context.Context, id string, usedAt time.Time) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyRepository.RecordApiKeyUsage")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	apiKey, ok := a.apiKeys[id]
+	if !ok {
+		return nil
+	}
+	apiKey.UsageCount++
+	apiKey.LastUsedAt = &usedAt
+	a.apiKeys[id] = apiKey
+	return nil
+}
+
+func NewApiKeyRepository() ApiKeyRepository {
+	return &ApiKeyRepositoryImp{
+		apiKeys:   map[string]response.ApiKey{},
+		idsByHash: map[string]string{},
+	}
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"strconv"
+	"sync"
+)
+
+//go:generate mockery --name=AuditRepository --structname=MockAuditRepository --output=../mocks --filename=fakeAuditRepositoryWithMockery.go
+type AuditRepository interface {
+	SaveAuditEntry(ctx context.Context, auditEntry response.AuditEntry) *response.ErrorResponse
+	FetchAuditEntries(ctx context.Context, orderNumber string) ([]response.AuditEntry, *response.ErrorResponse)
+}
+
+// AuditRepositoryImp is an append only in-memory log; entries can never be changed or removed.
+type AuditRepositoryImp struct {
+	mutex   sync.RWMutex
+	entries []response.AuditEntry
+}
+
+func (a *AuditRepositoryImp) SaveAuditEntry(ctx context.Context, auditEntry response.AuditEntry) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "AuditRepository.SaveAuditEntry")
+	span.SetAttributes(attribute.String(constants.OrderNumber, auditEntry.OrderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	auditEntry.Id = strconv.Itoa(len(a.entries) + 1)
+	auditEntry.Changes = append([]response.FieldChange(nil), auditEntry.Changes...)
+	a.entries = append(a.entries, auditEntry)
+	return nil
+}
+
+func (a *AuditRepositoryImp) FetchAuditEntries(ctx context.Context, orderNumber string) (auditEntries []response.AuditEntry, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "AuditRepository.FetchAuditEntries")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	auditEntries = make([]response.AuditEntry, 0)
+	for _, auditEntry := range a.entries {
+		if auditEntry.OrderNumber == orderNumber {
+			auditEntry.Changes = append([]response.FieldChange(nil), auditEntry.Changes...)
+			auditEntries = append(auditEntries, auditEntry)
+		}
+	}
+	return auditEntries, nil
+}
+
+func NewAuditRepository() AuditRepository {
+	return &AuditRepositoryImp{}
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CustomerRepository stores customers with unique ids and email addresses; both are
+// checked under the same lock as the write so that concurrent requests can not store
+// the same customer twice.
+//
+//go:generate mockery --name=CustomerRepository --structname=MockCustomerRepository --output=../mocks --filename=fakeCustomerRepositoryWithMockery.go
+type CustomerRepository interface {
+	FetchCustomers(ctx context.Context) ([]response.Customer, *response.ErrorResponse)
+	FetchCustomerById(ctx context.Context, id string) (*response.Customer, *response.ErrorResponse)
+	CreateCustomer(ctx context.Context, customer response.Customer) *response.ErrorResponse
+	UpdateCustomer(ctx context.Context, customer response.Customer) *response.ErrorResponse
+	DeleteCustomer(ctx context.Context, id string) *response.ErrorResponse
+}
+
+// CustomerRepositoryImp keeps customers in memory.
+type CustomerRepositoryImp struct {
+	mutex     sync.RWMutex
+	customers map[string]response.Customer
+}
+
+func (c *CustomerRepositoryImp) FetchCustomers(ctx context.Context) (customers []response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerRepository.FetchCustomers")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	customers = make([]response.Customer, 0, len(c.customers))
+	for _, customer := range c.customers {
+		customers = append(customers, copyCustomer(customer))
+	}
+	sort.Slice(customers, func(i, j int) bool {
+		if customers[i].CreatedAt.Equal(customers[j].CreatedAt) {
+			return customers[i].Id < customers[j].Id
+		}
+		return customers[i].CreatedAt.Before(customers[j].CreatedAt)
+	})
+	return customers, nil
+}
+
+func (c *CustomerRepositoryImp) FetchCustomerById(ctx context.Context, id string) (_ *response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerRepository.FetchCustomerById")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	customer, ok := c.customers[id]
+	if !ok {
+		return nil, nil
+	}
+
+	customer = copyCustomer(customer)
+	return &customer, nil
+}
+
+func (c *CustomerRepositoryImp) CreateCustomer(ctx context.Context, customer response.Customer) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerRepository.CreateCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, customer.Id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.customers[customer.Id]; ok {
+		return customerConflict(constants.SameCustomerFoundByUniqueId)
+	}
+
+	if c.isEmailTaken(customer.Email, customer.Id) {
+		return customerConflict(constants.SameCustomerFoundByEmail)
+	}
+
+	c.customers[customer.Id] = copyCustomer(customer)
+	return nil
+}
+
+func (c *CustomerRepositoryImp) UpdateCustomer(ctx context.Context, customer response.Customer) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerRepository.UpdateCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, customer.Id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.customers[customer.Id]; !ok {
+		return customerNotFound()
+	}
+
+	if c.isEmailTaken(customer.Email, customer.Id) {
+		return customerConflict(constants.SameCustomerFoundByEmail)
+	}
+
+	c.customers[customer.Id] = copyCustomer(customer)
+	return nil
+}
+
+func (c *CustomerRepositoryImp) DeleteCustomer(ctx context.Context, id string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerRepository.DeleteCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.customers[id]; !ok {
+		return customerNotFound()
+	}
+
+	delete(c.customers, id)
+	return nil
+}
+
+func (c *CustomerRepositoryImp) isEmailTaken(email string, id string) bool {
+	for _, customer := range c.customers {
+		if customer.Id != id && strings.EqualFold(customer.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyCustomer keeps the stored addresses apart from those of the callers.
+func copyCustomer(customer response.Customer) response.Customer {
+	customer.Addresses = append(make([]response.CustomerAddress, 0, len(customer.Addresses)), customer.Addresses...)
+	return customer
+}
+
+func customerConflict(message string) *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(http.StatusConflict, message).
+		Build()
+	return &errorResp
+}
+
+func customerNotFound() *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.CustomerNotFound).
+		Build()
+	return &errorResp
+}
+
+func NewCustomerRepository() CustomerRepository {
+	customers := make(map[string]response.Customer)
+	for _, customer := range getCustomers() {
+		customers[customer.Id] = customer
+	}
+	return &CustomerRepositoryImp{customers: customers}
+}
+
+// This function represents the customers of the orders of the external service
+func getCustomers() []response.Customer {
+	createdAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return []response.Customer{
+		{
+			Id:        "customer-1",
+			FirstName: "Ahmet",
+			LastName:  "Ata",
+			Email:     "ahmet.ata@example.com",
+			Addresses: []response.CustomerAddress{
+				{Label: "home", Address: "Lorem ipsum dolor sit amet", City: "İstanbul", District: "Silivri"},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		{
+			Id:        "customer-2",
+			FirstName: "Hans",
+			LastName:  "Schengen",
+			Email:     "hans.schengen@example.com",
+			Addresses: []response.CustomerAddress{
+				{Label: "home", Address: "Sed ut perspiciatis unde omnis iste natus", City: "Berlin", District: "Mitte"},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		{
+			Id:        "customer-3",
+			FirstName: "George",
+			LastName:  "White",
+			Email:     "george.white@example.com",
+			Addresses: []response.CustomerAddress{
+				{Label: "home", Address: "Ut enim ad minima veniam, quis nostrum", City: "London", District: "Westminster"},
+			},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"unicode"
+)
+
+//go:embed data/geo.json
+var geoData []byte
+
+// GeoRepository reads the reference catalog of countries, cities and districts. Names
+// are matched regardless of case and diacritics, so "istanbul" finds "İstanbul".
+//
+//go:generate mockery --name=GeoRepository --structname=MockGeoRepository --output=../mocks --filename=fakeGeoRepositoryWithMockery.go
+type GeoRepository interface {
+	FetchCountries(ctx context.Context) ([]response.Country, *response.ErrorResponse)
+	// FetchCities returns the cities of a country, or of all countries when the country
+	// code is empty, whose names start with the prefix.
+	FetchCities(ctx context.Context, countryCode string, namePrefix string) ([]response.City, *response.ErrorResponse)
+	FetchCityById(ctx context.Context, id string) (*response.City, *response.ErrorResponse)
+	// FetchCitiesByName returns every city with the name, as it may exist in several
+	// countries when the country code is empty.
+	FetchCitiesByName(ctx context.Context, countryCode string, name string) ([]response.City, *response.ErrorResponse)
+	FetchDistricts(ctx context.Context, cityId string) ([]response.District, *response.ErrorResponse)
+	FetchDistrictByName(ctx context.Context, cityId string, name string) (*response.District, *response.ErrorResponse)
+}
+
+// GeoRepositoryImp keeps the catalog embedded into the binary. It is never written, so
+// it needs no lock.
+type GeoRepositoryImp struct {
+	countries []response.Country
+	cities    []response.City
+	districts map[string][]response.District
+}
+
+type geoCountryData struct {
+	Code   string        `json:"code"`
+	Name   string        `json:"name"`
+	Cities []geoCityData `json:"cities"`
+}
+
+type geoCityData struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Districts []string `json:"districts"`
+}
+
+func (g *GeoRepositoryImp) FetchCountries(ctx context.Context) (countries []response.Country, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchCountries")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return append([]response.Country(nil), g.countries...), nil
+}
+
+func (g *GeoRepositoryImp) FetchCities(ctx context.Context, countryCode string, namePrefix string) (cities []response.City, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchCities")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	prefix := geoKey(namePrefix)
+	cities = make([]response.City, 0)
+	for _, city := range g.cities {
+		if isInCountry(city, countryCode) && strings.HasPrefix(geoKey(city.Name), prefix) {
+			cities = append(cities, city)
+		}
+	}
+	return cities, nil
+}
+
+func (g *GeoRepositoryImp) FetchCityById(ctx context.Context, id string) (_ *response.City, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchCityById")
+	span.SetAttributes(attribute.String(constants.CityId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	for _, city := range g.cities {
+		if city.Id == id {
+			return &city, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *GeoRepositoryImp) FetchCitiesByName(ctx context.Context, countryCode string, name string) (cities []response.City, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchCitiesByName")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	key := geoKey(name)
+	cities = make([]response.City, 0, 1)
+	for _, city := range g.cities {
+		if isInCountry(city, countryCode) && geoKey(city.Name) == key {
+			cities = append(cities, city)
+		}
+	}
+	return cities, nil
+}
+
+func (g *GeoRepositoryImp) FetchDistricts(ctx context.Context, cityId string) (districts []response.District, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchDistricts")
+	span.SetAttributes(attribute.String(constants.CityId, cityId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return append(make([]response.District, 0), g.districts[cityId]...), nil
+}
+
+func (g *GeoRepositoryImp) FetchDistrictByName(ctx context.Context, cityId string, name string) (_ *response.District, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoRepository.FetchDistrictByName")
+	span.SetAttributes(attribute.String(constants.CityId, cityId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	key := geoKey(name)
+	for _, district := range g.districts[cityId] {
+		if geoKey(district.Name) == key {
+			return &district, nil
+		}
+	}
+	return nil, nil
+}
+
+func isInCountry(city response.City, countryCode string) bool {
+	return len(countryCode) == 0 || strings.EqualFold(city.CountryCode, countryCode)
+}
+
+// geoKey reduces a name to the form it is matched by, dropping case and diacritics,
+// e.g. "Kadıköy" to "kadikoy". The dotless ı has no decomposition, so it is mapped
+// by hand.
+func geoKey(name string) string {
+	stripDiacritics := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	key, _, err := transform.String(stripDiacritics, strings.TrimSpace(name))
+	if err != nil {
+		key = strings.TrimSpace(name)
+	}
+	return strings.ToLower(strings.ReplaceAll(key, "ı", "i"))
+}
+
+// NewGeoRepository loads the embedded catalog. District ids are derived from the city id
+// and the district name, e.g. "tr-istanbul-kadikoy".
+func NewGeoRepository() GeoRepository {
+	var countries []geoCountryData
+	if err := json.Unmarshal(geoData, &countries); err != nil {
+		panic(err)
+	}
+
+	repository := &GeoRepositoryImp{districts: make(map[string][]response.District)}
+	for _, country := range countries {
+		repository.countries = append(repository.countries, response.Country{Code: country.Code, Name: country.Name})
+		for _, city := range country.Cities {
+			repository.cities = append(repository.cities, response.City{Id: city.Id, Name: city.Name, CountryCode: country.Code})
+			for _, district := range city.Districts {
+				repository.districts[city.Id] = append(repository.districts[city.Id], response.District{
+					Id:     city.Id + "-" + strings.ReplaceAll(geoKey(district), " ", "-"),
+					Name:   district,
+					CityId: city.Id,
+				})
+			}
+		}
+	}
+	return repository
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sync"
+)
+
+//go:generate mockery --name=ImportJobRepository --structname=MockImportJobRepository --output=../mocks --filename=fakeImportJobRepositoryWithMockery.go
+type ImportJobRepository interface {
+	FetchImportJobById(ctx context.Context, id string) (*response.ImportJob, *response.ErrorResponse)
+	SaveImportJob(ctx context.Context, importJob response.ImportJob) *response.ErrorResponse
+}
+
+// ImportJobRepositoryImp keeps import jobs in memory.
+type ImportJobRepositoryImp struct {
+	mutex      sync.RWMutex
+	importJobs map[string]response.ImportJob
+}
+
+func (i *ImportJobRepositoryImp) FetchImportJobById(ctx context.Context, id string) (_ *response.ImportJob, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ImportJobRepository.FetchImportJobById")
+	span.SetAttributes(attribute.String(constants.ImportJobId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	importJob, ok := i.importJobs[id]
+	if !ok {
+		return nil, nil
+	}
+	importJob.Errors = append([]response.ImportRowError{}, importJob.Errors...)
+	return &importJob, nil
+}
+
+func (i *ImportJobRepositoryImp) SaveImportJob(ctx context.Context, importJob response.ImportJob) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ImportJobRepository.SaveImportJob")
+	span.SetAttributes(attribute.String(constants.ImportJobId, importJob.Id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	// the importer keeps appending to its slice, so the stored job gets its own copy
+	importJob.Errors = append([]response.ImportRowError{}, importJob.Errors...)
+	i.importJobs[importJob.Id] = importJob
+	return nil
+}
+
+func NewImportJobRepository() ImportJobRepository {
+	return &ImportJobRepositoryImp{
+		importJobs: map[string]response.ImportJob{},
+	}
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/models/response"
+	"sync"
+)
+
+// InMemoryDatabase holds the tables shared by the order and outbox repositories. Holding
+// its mutex is the equivalent of a transaction, so an order change and the outbox
+// messages describing it are always stored together.
+type InMemoryDatabase struct {
+	mutex               sync.RWMutex
+	orders              []response.Order
+	outboxMessages      []events.OutboxMessage
+	lastOutboxMessageId int64
+}
+
+// appendOutboxMessage numbers messages sequentially, so outboxMessages stays sorted by
+// Id when finished messages are pruned.
+func (d *InMemoryDatabase) appendOutboxMessage(event events.OrderEvent) {
+	d.lastOutboxMessageId++
+	outboxMessage := events.NewOutboxMessage(event)
+	outboxMessage.Id = d.lastOutboxMessageId
+	d.outboxMessages = append(d.outboxMessages, outboxMessage)
+}
+
+func (d *InMemoryDatabase) findOrderIndex(orderNumber string) int {
+	for i, order := range d.orders {
+		if order.OrderNumber == orderNumber {
+			return i
+		}
+	}
+	return -1
+}
+
+// readOrders appends the batch of orders following lastOrderNumber to batch, up to its
+// capacity. When that order was deleted meanwhile it resumes at position instead.
+func (d *InMemoryDatabase) readOrders(batch []response.Order, position int, lastOrderNumber string) []response.Order {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if len(lastOrderNumber) > 0 {
+		if index := d.findOrderIndex(lastOrderNumber); index >= 0 {
+			position = index + 1
+		}
+	}
+	if position >= len(d.orders) {
+		return batch
+	}
+
+	end := position + cap(batch) - len(batch)
+	if end > len(d.orders) {
+		end = len(d.orders)
+	}
+	return append(batch, d.orders[position:end]...)
+}
+
+func NewInMemoryDatabase() *InMemoryDatabase {
+	return &InMemoryDatabase{orders: getOrders()}
+}
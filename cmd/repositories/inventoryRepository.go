@@ -0,0 +1,333 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"sync"
+)
+
+// InventoryRepository keeps the stock of products per warehouse and the stock reserved
+// for orders. Reservations are all or nothing and checked under the same lock as they
+// are taken, so concurrent orders can never reserve more than is available.
+//
+//go:generate mockery --name=InventoryRepository --structname=MockInventoryRepository --output=../mocks --filename=fakeInventoryRepositoryWithMockery.go
+type InventoryRepository interface {
+	// FetchStockLevels returns the stock levels of a product, or of all products when the
+	// SKU is empty.
+	FetchStockLevels(ctx context.Context, sku string) ([]response.StockLevel, *response.ErrorResponse)
+	SaveStockLevel(ctx context.Context, sku string, warehouseId string, onHand int) (*response.StockLevel, *response.ErrorResponse)
+	FetchReservation(ctx context.Context, orderNumber string) ([]response.ReservationLine, *response.ErrorResponse)
+	// ReserveStock reserves the items of a new order, taking them from the warehouses in
+	// the order of their ids and splitting items over warehouses when needed.
+	ReserveStock(ctx context.Context, orderNumber string, items []response.OrderItem) *response.ErrorResponse
+	// CheckStock reports whether the items could be reserved now, without reserving them.
+	CheckStock(ctx context.Context, items []response.OrderItem) *response.ErrorResponse
+	// ReplaceReservation releases the reservation of an order and reserves the items
+	// instead; the reservation is kept when the items can not be reserved.
+	ReplaceReservation(ctx context.Context, orderNumber string, items []response.OrderItem) *response.ErrorResponse
+	// CommitReservation takes the reserved units of an order out of the stock on hand.
+	// Committing an order without a reservation changes nothing.
+	CommitReservation(ctx context.Context, orderNumber string) *response.ErrorResponse
+	ReleaseReservation(ctx context.Context, orderNumber string) *response.ErrorResponse
+}
+
+type stockKey struct {
+	sku         string
+	warehouseId string
+}
+
+type stockCount struct {
+	onHand   int
+	reserved int
+}
+
+// InventoryRepositoryImp keeps the inventory in memory.
+type InventoryRepositoryImp struct {
+	mutex        sync.Mutex
+	stock        map[stockKey]stockCount
+	reservations map[string][]response.ReservationLine
+}
+
+func (i *InventoryRepositoryImp) FetchStockLevels(ctx context.Context, sku string) (stockLevels []response.StockLevel, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.FetchStockLevels")
+	span.SetAttributes(attribute.String(constants.Sku, sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	stockLevels = make([]response.StockLevel, 0)
+	for key, count := range i.stock {
+		if len(sku) == 0 || key.sku == sku {
+			stockLevels = append(stockLevels, newStockLevel(key, count))
+		}
+	}
+	sort.Slice(stockLevels, func(a, b int) bool {
+		if stockLevels[a].Sku != stockLevels[b].Sku {
+			return stockLevels[a].Sku < stockLevels[b].Sku
+		}
+		return stockLevels[a].WarehouseId < stockLevels[b].WarehouseId
+	})
+	return stockLevels, nil
+}
+
+func (i *InventoryRepositoryImp) SaveStockLevel(ctx context.Context, sku string, warehouseId string, onHand int) (_ *response.StockLevel, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.SaveStockLevel")
+	span.SetAttributes(attribute.String(constants.Sku, sku), attribute.String(constants.WarehouseId, warehouseId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	key := stockKey{sku: sku, warehouseId: warehouseId}
+	count := i.stock[key]
+	if onHand < count.reserved {
+		return nil, inventoryError(http.StatusConflict, constants.StockLevelIsBelowReservations)
+	}
+
+	count.onHand = onHand
+	i.stock[key] = count
+	stockLevel := newStockLevel(key, count)
+	return &stockLevel, nil
+}
+
+func (i *InventoryRepositoryImp) FetchReservation(ctx context.Context, orderNumber string) (_ []response.ReservationLine, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.FetchReservation")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	lines, ok := i.reservations[orderNumber]
+	if !ok {
+		return nil, nil
+	}
+	return append(make([]response.ReservationLine, 0, len(lines)), lines...), nil
+}
+
+func (i *InventoryRepositoryImp) ReserveStock(ctx context.Context, orderNumber string, items []response.OrderItem) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.ReserveStock")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	// a reservation is taken before its order is stored, so it is held by a concurrent
+	// request creating the same order
+	if _, ok := i.reservations[orderNumber]; ok {
+		return inventoryError(http.StatusConflict, constants.SameOrderFoundByUniqueId)
+	}
+
+	lines, ok := i.allocate(items)
+	if !ok {
+		return inventoryError(http.StatusConflict, constants.StockIsInsufficient)
+	}
+
+	i.reserve(orderNumber, lines)
+	return nil
+}
+
+func (i *InventoryRepositoryImp) CheckStock(ctx context.Context, items []response.OrderItem) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.CheckStock")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if _, ok := i.allocate(items); !ok {
+		return inventoryError(http.StatusConflict, constants.StockIsInsufficient)
+	}
+	return nil
+}
+
+func (i *InventoryRepositoryImp) ReplaceReservation(ctx context.Context, orderNumber string, items []response.OrderItem) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.ReplaceReservation")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	previousLines := i.release(orderNumber)
+	lines, ok := i.allocate(items)
+	if !ok {
+		i.reserve(orderNumber, previousLines)
+		return inventoryError(http.StatusConflict, constants.StockIsInsufficient)
+	}
+
+	i.reserve(orderNumber, lines)
+	return nil
+}
+
+func (i *InventoryRepositoryImp) CommitReservation(ctx context.Context, orderNumber string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.CommitReservation")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	for _, line := range i.release(orderNumber) {
+		key := stockKey{sku: line.Sku, warehouseId: line.WarehouseId}
+		count := i.stock[key]
+		count.onHand -= line.Quantity
+		i.stock[key] = count
+	}
+	return nil
+}
+
+func (i *InventoryRepositoryImp) ReleaseReservation(ctx context.Context, orderNumber string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.ReleaseReservation")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.release(orderNumber)
+	return nil
+}
+
+// allocate plans the reservation of the items from the available stock, or reports that
+// there is not enough of it. It must be called with the lock held.
+func (i *InventoryRepositoryImp) allocate(items []response.OrderItem) ([]response.ReservationLine, bool) {
+	lines := make([]response.ReservationLine, 0, len(items))
+	planned := make(map[stockKey]int)
+	for _, item := range items {
+		missing := item.Quantity
+		for _, warehouseId := range i.warehousesOf(item.Sku) {
+			key := stockKey{sku: item.Sku, warehouseId: warehouseId}
+			count := i.stock[key]
+			quantity := minInt(missing, count.onHand-count.reserved-planned[key])
+			if quantity <= 0 {
+				continue
+			}
+
+			planned[key] += quantity
+			missing -= quantity
+			lines = append(lines, response.ReservationLine{Sku: item.Sku, WarehouseId: warehouseId, Quantity: quantity})
+			if missing == 0 {
+				break
+			}
+		}
+
+		if missing > 0 {
+			return nil, false
+		}
+	}
+	return lines, true
+}
+
+func (i *InventoryRepositoryImp) warehousesOf(sku string) []string {
+	warehouseIds := make([]string, 0)
+	for key := range i.stock {
+		if key.sku == sku {
+			warehouseIds = append(warehouseIds, key.warehouseId)
+		}
+	}
+	sort.Strings(warehouseIds)
+	return warehouseIds
+}
+
+func (i *InventoryRepositoryImp) reserve(orderNumber string, lines []response.ReservationLine) {
+	if len(lines) == 0 {
+		return
+	}
+
+	for _, line := range lines {
+		key := stockKey{sku: line.Sku, warehouseId: line.WarehouseId}
+		count := i.stock[key]
+		count.reserved += line.Quantity
+		i.stock[key] = count
+	}
+	i.reservations[orderNumber] = lines
+}
+
+// release frees the reserved units of an order and returns its reservation lines.
+func (i *InventoryRepositoryImp) release(orderNumber string) []response.ReservationLine {
+	lines := i.reservations[orderNumber]
+	for _, line := range lines {
+		key := stockKey{sku: line.Sku, warehouseId: line.WarehouseId}
+		count := i.stock[key]
+		count.reserved -= line.Quantity
+		i.stock[key] = count
+	}
+	delete(i.reservations, orderNumber)
+	return lines
+}
+
+func newStockLevel(key stockKey, count stockCount) response.StockLevel {
+	return response.StockLevel{
+		Sku:         key.sku,
+		WarehouseId: key.warehouseId,
+		OnHand:      count.onHand,
+		Reserved:    count.reserved,
+		Available:   count.onHand - count.reserved,
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func inventoryError(statusCode int, message string) *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(statusCode, message).
+		Build()
+	return &errorResp
+}
+
+func NewInventoryRepository() InventoryRepository {
+	stock := make(map[stockKey]stockCount)
+	for _, stockLevel := range getStockLevels() {
+		stock[stockKey{sku: stockLevel.Sku, warehouseId: stockLevel.WarehouseId}] = stockCount{onHand: stockLevel.OnHand}
+	}
+	return &InventoryRepositoryImp{
+		stock:        stock,
+		reservations: make(map[string][]response.ReservationLine),
+	}
+}
+
+// This function represents the stock of the warehouses of the external service
+func getStockLevels() []response.StockLevel {
+	return []response.StockLevel{
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "BER-1", OnHand: 20},
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "IST-1", OnHand: 50},
+		{Sku: "MUG-WHT", WarehouseId: "IST-1", OnHand: 10},
+		{Sku: "POSTER-A2", WarehouseId: "BER-1", OnHand: 0},
+	}
+}
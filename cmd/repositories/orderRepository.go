@@ -1,111 +1,256 @@
 package repositories
 
 import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
 	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
 )
 
+var tracer = otel.Tracer("simple-order-api/cmd/repositories")
+
+// iterateOrdersBatchSize is the number of orders IterateOrders copies under one read lock.
+const iterateOrdersBatchSize = 100
+
+// OrderRepository stores every order change together with the event describing it in
+// the outbox, so the event is published even if the process stops right after the write.
+//
 //go:generate mockery --name=OrderRepository --structname=MockOrderRepository --output=../mocks --filename=fakeOrderRepositoryWithMockery.go
 type OrderRepository interface {
-	FetchOrders() ([]response.Order, *response.ErrorResponse)
-	FetchOrderByOrderNumber(orderNumber string) (*response.Order, *response.ErrorResponse)
-	CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse
-	UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse
-	DeleteOrder(orderNumber string) *response.ErrorResponse
+	FetchOrders(ctx context.Context) ([]response.Order, *response.ErrorResponse)
+	// IterateOrders calls visit with every order in storage order until visit returns
+	// false. The orders are read in batches so that no lock is held while visit runs;
+	// orders changed in the meantime are visited with the values of their batch.
+	IterateOrders(ctx context.Context, visit func(order response.Order) bool) *response.ErrorResponse
+	FetchOrderByOrderNumber(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse)
+	CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest, event events.OrderEvent) *response.ErrorResponse
+	// UpdateOrder changes the order only while it is still in the expected status, which
+	// the caller checked the change against, and is refused with 409 otherwise.
+	UpdateOrder(ctx context.Context, orderNumber string, expectedStatus enum.OrderStatus, updateOrderRequest request.UpdateOrderRequest, event events.OrderEvent) *response.ErrorResponse
+	DeleteOrder(ctx context.Context, orderNumber string, event events.OrderEvent) *response.ErrorResponse
+	UpdateOrderStatus(ctx context.Context, orderNumber string, status enum.OrderStatus, event events.OrderEvent) *response.ErrorResponse
+}
+
+type OrderRepositoryImp struct {
+	database *InMemoryDatabase
 }
 
-type OrderRepositoryImp struct{}
+func (o OrderRepositoryImp) FetchOrders(ctx context.Context) (orders []response.Order, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.FetchOrders")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
 
-func (o OrderRepositoryImp) FetchOrders() ([]response.Order, *response.ErrorResponse) {
-	orders := getOrders()
+	o.database.mutex.RLock()
+	defer o.database.mutex.RUnlock()
+	orders = append(make([]response.Order, 0, len(o.database.orders)), o.database.orders...)
 	return orders, nil
 }
 
-func (o OrderRepositoryImp) FetchOrderByOrderNumber(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	orders := getOrders()
-	for _, order := range orders {
-		if order.OrderNumber == orderNumber {
-			return &order, nil
+func (o OrderRepositoryImp) IterateOrders(ctx context.Context, visit func(order response.Order) bool) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.IterateOrders")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	batch := make([]response.Order, 0, iterateOrdersBatchSize)
+	position, lastOrderNumber := 0, ""
+	for {
+		if errorResp = helpers.ContextError(ctx); errorResp != nil {
+			return errorResp
+		}
+
+		batch = o.database.readOrders(batch[:0], position, lastOrderNumber)
+		if len(batch) == 0 {
+			return nil
 		}
+
+		for _, order := range batch {
+			if !visit(order) {
+				return nil
+			}
+		}
+
+		position += len(batch)
+		lastOrderNumber = batch[len(batch)-1].OrderNumber
+	}
+}
+
+func (o OrderRepositoryImp) FetchOrderByOrderNumber(ctx context.Context, orderNumber string) (_ *response.Order, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.FetchOrderByOrderNumber")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	o.database.mutex.RLock()
+	defer o.database.mutex.RUnlock()
+	index := o.database.findOrderIndex(orderNumber)
+	if index < 0 {
+		return nil, nil
 	}
 
-	return nil, nil
+	order := o.database.orders[index]
+	return &order, nil
 }
 
-func (o OrderRepositoryImp) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	orders, _ := o.FetchOrders()
-	orders = append(orders, response.Order{
-		OrderNumber:  createOrderRequest.OrderNumber,
-		FirstName:    createOrderRequest.FirstName,
-		LastName:     createOrderRequest.LastName,
-		TotalAmount:  createOrderRequest.TotalAmount,
-		Address:      createOrderRequest.Address,
-		City:         createOrderRequest.City,
-		District:     createOrderRequest.District,
-		CurrencyCode: createOrderRequest.CurrencyCode,
-		StatusId:     int(enum.Created),
-	})
+func (o OrderRepositoryImp) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest, event events.OrderEvent) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.CreateOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, createOrderRequest.OrderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	if o.database.findOrderIndex(createOrderRequest.OrderNumber) >= 0 {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
+			Build()
+		return &errorResp
+	}
+
+	o.database.orders = append(o.database.orders, createOrderRequest.Order())
+	o.database.appendOutboxMessage(event)
 	return nil
 }
 
-func (o OrderRepositoryImp) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	order, _ := o.FetchOrderByOrderNumber(orderNumber)
-	order.City = updateOrderRequest.City
-	order.District = updateOrderRequest.District
-	order.Address = updateOrderRequest.Address
-	order.CurrencyCode = updateOrderRequest.CurrencyCode
-	order.TotalAmount = updateOrderRequest.TotalAmount
-	order.FirstName = updateOrderRequest.FirstName
-	order.LastName = updateOrderRequest.LastName
+func (o OrderRepositoryImp) UpdateOrder(ctx context.Context, orderNumber string, expectedStatus enum.OrderStatus, updateOrderRequest request.UpdateOrderRequest, event events.OrderEvent) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.UpdateOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	index := o.database.findOrderIndex(orderNumber)
+	if index < 0 {
+		return orderNotFound()
+	}
+
+	if o.database.orders[index].StatusId != int(expectedStatus) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.OrderStatusHasChanged).
+			Build()
+		return &errorResp
+	}
+
+	o.database.orders[index] = updateOrderRequest.Apply(o.database.orders[index])
+	o.database.appendOutboxMessage(event)
+	return nil
+}
+
+func (o OrderRepositoryImp) UpdateOrderStatus(ctx context.Context, orderNumber string, status enum.OrderStatus, event events.OrderEvent) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.UpdateOrderStatus")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	index := o.database.findOrderIndex(orderNumber)
+	if index < 0 {
+		return orderNotFound()
+	}
+
+	o.database.orders[index].StatusId = int(status)
+	o.database.appendOutboxMessage(event)
 	return nil
 }
 
-func (o OrderRepositoryImp) DeleteOrder(_ string) *response.ErrorResponse {
-	// Think that we delete order with success
+func (o OrderRepositoryImp) DeleteOrder(ctx context.Context, orderNumber string, event events.OrderEvent) (errorResp *response.ErrorResponse) {
+	_, span := tracer.Start(ctx, "OrderRepository.DeleteOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	index := o.database.findOrderIndex(orderNumber)
+	if index < 0 {
+		return orderNotFound()
+	}
+
+	o.database.orders = append(o.database.orders[:index], o.database.orders[index+1:]...)
+	o.database.appendOutboxMessage(event)
 	return nil
 }
 
+func orderNotFound() *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
+		Build()
+	return &errorResp
+}
+
 // This function represents data for external service
 func getOrders() []response.Order {
 	orders := []response.Order{
 		{
-			OrderNumber:  "1",
-			FirstName:    "Ahmet",
-			LastName:     "Ata",
-			TotalAmount:  121.13,
-			Address:      "Lorem ipsum dolor sit amet",
-			City:         "İstanbul",
-			District:     "Silivri",
-			StatusId:     2,
-			CurrencyCode: "TR",
+			OrderNumber:     "1",
+			FirstName:       "Ahmet",
+			LastName:        "Ata",
+			TotalAmount:     121.13,
+			Address:         "Lorem ipsum dolor sit amet",
+			City:            "İstanbul",
+			District:        "Silivri",
+			StatusId:        2,
+			CurrencyCode:    "TR",
+			CustomerId:      "customer-1",
+			ShippingAddress: &response.Address{Lines: []string{"Lorem ipsum dolor sit amet"}, District: "Silivri", City: "İstanbul", PostalCode: "34570", CountryCode: "TR"},
+			BillingAddress:  &response.Address{Lines: []string{"Lorem ipsum dolor sit amet"}, District: "Silivri", City: "İstanbul", PostalCode: "34570", CountryCode: "TR"},
 		},
 		{
-			OrderNumber:  "2",
-			FirstName:    "Hans",
-			LastName:     "Schengen",
-			TotalAmount:  345.99,
-			Address:      "Sed ut perspiciatis unde omnis iste natus",
-			City:         "Berlin",
-			District:     "Berlin Square",
-			StatusId:     3,
-			CurrencyCode: "EUR",
+			OrderNumber:     "2",
+			FirstName:       "Hans",
+			LastName:        "Schengen",
+			TotalAmount:     345.99,
+			Address:         "Sed ut perspiciatis unde omnis iste natus",
+			City:            "Berlin",
+			District:        "Mitte",
+			StatusId:        3,
+			CurrencyCode:    "EUR",
+			CustomerId:      "customer-2",
+			ShippingAddress: &response.Address{Lines: []string{"Sed ut perspiciatis unde omnis iste natus"}, District: "Mitte", City: "Berlin", PostalCode: "10117", CountryCode: "DE"},
+			BillingAddress:  &response.Address{Lines: []string{"Sed ut perspiciatis unde omnis iste natus"}, District: "Mitte", City: "Berlin", PostalCode: "10117", CountryCode: "DE"},
 		},
 		{
-			OrderNumber:  "3",
-			FirstName:    "George",
-			LastName:     "White",
-			TotalAmount:  163.99,
-			Address:      "Ut enim ad minima veniam, quis nostrum",
-			City:         "London",
-			District:     "Birmingham",
-			StatusId:     4,
-			CurrencyCode: "EUR",
+			OrderNumber:     "3",
+			FirstName:       "George",
+			LastName:        "White",
+			TotalAmount:     163.99,
+			Address:         "Ut enim ad minima veniam, quis nostrum",
+			City:            "London",
+			District:        "Westminster",
+			StatusId:        4,
+			CurrencyCode:    "EUR",
+			CustomerId:      "customer-3",
+			ShippingAddress: &response.Address{Lines: []string{"Ut enim ad minima veniam, quis nostrum"}, District: "Westminster", City: "London", PostalCode: "SW1A 1AA", CountryCode: "GB"},
+			BillingAddress:  &response.Address{Lines: []string{"Ut enim ad minima veniam, quis nostrum"}, District: "Westminster", City: "London", PostalCode: "SW1A 1AA", CountryCode: "GB"},
 		},
 	}
 	return orders
 }
 
-func NewOrderRepository() OrderRepository {
-	return &OrderRepositoryImp{}
+func NewOrderRepository(database *InMemoryDatabase) OrderRepository {
+	return &OrderRepositoryImp{database: database}
 }
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"time"
+)
+
+//go:generate mockery --name=OutboxRepository --structname=MockOutboxRepository --output=../mocks --filename=fakeOutboxRepositoryWithMockery.go
+type OutboxRepository interface {
+	// FetchPendingMessages returns up to limit pending messages that are due at now, oldest
+	// first. Messages queued behind a message of the same order that is waiting for a
+	// retry are held back to keep the order's events in sequence.
+	FetchPendingMessages(ctx context.Context, now time.Time, limit int) ([]events.OutboxMessage, *response.ErrorResponse)
+	MarkMessagePublished(ctx context.Context, id int64) *response.ErrorResponse
+	RescheduleMessage(ctx context.Context, id int64, reason string, nextAttemptAt time.Time) *response.ErrorResponse
+	DeadLetterMessage(ctx context.Context, id int64, reason string) *response.ErrorResponse
+	// PruneMessages removes the messages that were published or dead-lettered before the
+	// given time and returns how many were removed.
+	PruneMessages(ctx context.Context, before time.Time) (int, *response.ErrorResponse)
+}
+
+type OutboxRepositoryImp struct {
+	database *InMemoryDatabase
+}
+
+func (o OutboxRepositoryImp) FetchPendingMessages(ctx context.Context, now time.Time, limit int) (outboxMessages []events.OutboxMessage, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.FetchPendingMessages")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	o.database.mutex.RLock()
+	defer o.database.mutex.RUnlock()
+	outboxMessages = make([]events.OutboxMessage, 0)
+	waitingOrderNumbers := make(map[string]bool)
+	for _, outboxMessage := range o.database.outboxMessages {
+		if len(outboxMessages) >= limit {
+			break
+		}
+
+		orderNumber := outboxMessage.Event.OrderNumber
+		if outboxMessage.Status != enum.PendingOutboxStatus || waitingOrderNumbers[orderNumber] {
+			continue
+		}
+
+		if outboxMessage.NextAttemptAt.After(now) {
+			waitingOrderNumbers[orderNumber] = true
+			continue
+		}
+
+		outboxMessages = append(outboxMessages, outboxMessage)
+	}
+	return outboxMessages, nil
+}
+
+func (o OutboxRepositoryImp) MarkMessagePublished(ctx context.Context, id int64) (errorResp *response.ErrorResponse) {
+	return o.updateMessage(ctx, "OutboxRepository.MarkMessagePublished", id, func(outboxMessage *events.OutboxMessage) {
+		publishedAt := time.Now().UTC()
+		outboxMessage.Attempts++
+		outboxMessage.Status = enum.PublishedOutboxStatus
+		outboxMessage.PublishedAt = &publishedAt
+	})
+}
+
+func (o OutboxRepositoryImp) RescheduleMessage(ctx context.Context, id int64, reason string, nextAttemptAt time.Time) (errorResp *response.ErrorResponse) {
+	return o.updateMessage(ctx, "OutboxRepository.RescheduleMessage", id, func(outboxMessage *events.OutboxMessage) {
+		outboxMessage.Attempts++
+		outboxMessage.LastError = reason
+		outboxMessage.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (o OutboxRepositoryImp) DeadLetterMessage(ctx context.Context, id int64, reason string) (errorResp *response.ErrorResponse) {
+	return o.updateMessage(ctx, "OutboxRepository.DeadLetterMessage", id, func(outboxMessage *events.OutboxMessage) {
+		deadLetteredAt := time.Now().UTC()
+		outboxMessage.Attempts++
+		outboxMessage.LastError = reason
+		outboxMessage.Status = enum.DeadLetteredOutboxStatus
+		outboxMessage.DeadLetteredAt = &deadLetteredAt
+	})
+}
+
+func (o OutboxRepositoryImp) PruneMessages(ctx context.Context, before time.Time) (pruned int, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OutboxRepository.PruneMessages")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return 0, errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	kept := o.database.outboxMessages[:0]
+	for _, outboxMessage := range o.database.outboxMessages {
+		if isFinishedBefore(outboxMessage, before) {
+			pruned++
+			continue
+		}
+		kept = append(kept, outboxMessage)
+	}
+	// clears the tail, so that the removed messages can be collected
+	for i := len(kept); i < len(o.database.outboxMessages); i++ {
+		o.database.outboxMessages[i] = events.OutboxMessage{}
+	}
+	o.database.outboxMessages = kept
+	span.SetAttributes(attribute.Int(constants.PrunedOutboxMessages, pruned))
+	return pruned, nil
+}
+
+func (o OutboxRepositoryImp) updateMessage(ctx context.Context, spanName string, id int64, update func(outboxMessage *events.OutboxMessage)) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, spanName)
+	span.SetAttributes(attribute.Int64(constants.OutboxMessageId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	o.database.mutex.Lock()
+	defer o.database.mutex.Unlock()
+	outboxMessages := o.database.outboxMessages
+	index := sort.Search(len(outboxMessages), func(i int) bool { return outboxMessages[i].Id >= id })
+	if index == len(outboxMessages) || outboxMessages[index].Id != id {
+		return nil
+	}
+
+	update(&outboxMessages[index])
+	return nil
+}
+
+func isFinishedBefore(outboxMessage events.OutboxMessage, before time.Time) bool {
+	switch outboxMessage.Status {
+	case enum.PublishedOutboxStatus:
+		return outboxMessage.PublishedAt.Before(before)
+	case enum.DeadLetteredOutboxStatus:
+		return outboxMessage.DeadLetteredAt.Before(before)
+	default:
+		return false
+	}
+}
+
+func NewOutboxRepository(database *InMemoryDatabase) OutboxRepository {
+	return &OutboxRepositoryImp{database: database}
+}
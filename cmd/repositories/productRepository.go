@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProductRepository stores the product catalog by SKU. Products are never deleted, since
+// orders keep referencing them; they are deactivated instead.
+//
+//go:generate mockery --name=ProductRepository --structname=MockProductRepository --output=../mocks --filename=fakeProductRepositoryWithMockery.go
+type ProductRepository interface {
+	FetchProducts(ctx context.Context) ([]response.Product, *response.ErrorResponse)
+	FetchProductBySku(ctx context.Context, sku string) (*response.Product, *response.ErrorResponse)
+	CreateProduct(ctx context.Context, product response.Product) *response.ErrorResponse
+	UpdateProduct(ctx context.Context, product response.Product) *response.ErrorResponse
+}
+
+// ProductRepositoryImp keeps products in memory.
+type ProductRepositoryImp struct {
+	mutex    sync.RWMutex
+	products map[string]response.Product
+}
+
+func (p *ProductRepositoryImp) FetchProducts(ctx context.Context) (products []response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductRepository.FetchProducts")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	products = make([]response.Product, 0, len(p.products))
+	for _, product := range p.products {
+		products = append(products, copyProduct(product))
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Sku < products[j].Sku })
+	return products, nil
+}
+
+func (p *ProductRepositoryImp) FetchProductBySku(ctx context.Context, sku string) (_ *response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductRepository.FetchProductBySku")
+	span.SetAttributes(attribute.String(constants.Sku, sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	product, ok := p.products[sku]
+	if !ok {
+		return nil, nil
+	}
+
+	product = copyProduct(product)
+	return &product, nil
+}
+
+func (p *ProductRepositoryImp) CreateProduct(ctx context.Context, product response.Product) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductRepository.CreateProduct")
+	span.SetAttributes(attribute.String(constants.Sku, product.Sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.products[product.Sku]; ok {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.SameProductFoundBySku).
+			Build()
+		return &errorResp
+	}
+
+	p.products[product.Sku] = copyProduct(product)
+	return nil
+}
+
+func (p *ProductRepositoryImp) UpdateProduct(ctx context.Context, product response.Product) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductRepository.UpdateProduct")
+	span.SetAttributes(attribute.String(constants.Sku, product.Sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.products[product.Sku]; !ok {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ProductNotFound).
+			Build()
+		return &errorResp
+	}
+
+	p.products[product.Sku] = copyProduct(product)
+	return nil
+}
+
+// copyProduct keeps the stored prices apart from those of the callers.
+func copyProduct(product response.Product) response.Product {
+	product.Prices = append(make([]response.Price, 0, len(product.Prices)), product.Prices...)
+	return product
+}
+
+func NewProductRepository() ProductRepository {
+	products := make(map[string]response.Product)
+	for _, product := range getProducts() {
+		products[product.Sku] = product
+	}
+	return &ProductRepositoryImp{products: products}
+}
+
+// This function represents the product catalog of the external service
+func getProducts() []response.Product {
+	createdAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return []response.Product{
+		{
+			Sku:         "TSHIRT-BLK-M",
+			Name:        "Black T-Shirt, M",
+			Description: "Cotton t-shirt in black, size M",
+			Prices:      []response.Price{{CurrencyCode: "TRY", Amount: 249.90}, {CurrencyCode: "EUR", Amount: 14.99}, {CurrencyCode: "GBP", Amount: 12.99}},
+			Active:      true,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			Sku:         "MUG-WHT",
+			Name:        "White Mug",
+			Description: "Ceramic mug, 330 ml",
+			Prices:      []response.Price{{CurrencyCode: "TRY", Amount: 119.50}, {CurrencyCode: "EUR", Amount: 7.50}},
+			Active:      true,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+		{
+			Sku:         "POSTER-A2",
+			Name:        "Poster, A2",
+			Description: "Discontinued",
+			Prices:      []response.Price{{CurrencyCode: "EUR", Amount: 9.90}},
+			Active:      false,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		},
+	}
+}
@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PromotionRepository stores coupons and automatic promotions by code along with the
+// orders using them. Redemptions are checked against the usage limits under the same
+// lock as they are recorded, so concurrent orders can never exceed them.
+//
+//go:generate mockery --name=PromotionRepository --structname=MockPromotionRepository --output=../mocks --filename=fakePromotionRepositoryWithMockery.go
+type PromotionRepository interface {
+	FetchPromotions(ctx context.Context) ([]response.Promotion, *response.ErrorResponse)
+	FetchPromotionByCode(ctx context.Context, code string) (*response.Promotion, *response.ErrorResponse)
+	CreatePromotion(ctx context.Context, promotion response.Promotion) *response.ErrorResponse
+	UpdatePromotion(ctx context.Context, promotion response.Promotion) *response.ErrorResponse
+	// CountRedemptions returns the number of orders using the promotion, in total and of
+	// the customer.
+	CountRedemptions(ctx context.Context, code string, customerId string) (int, int, *response.ErrorResponse)
+	// RedeemPromotions records the use of the promotions by an order, all or nothing.
+	RedeemPromotions(ctx context.Context, orderNumber string, customerId string, codes []string) *response.ErrorResponse
+	ReleaseRedemptions(ctx context.Context, orderNumber string, codes []string) *response.ErrorResponse
+}
+
+// PromotionRepositoryImp keeps promotions in memory. Redemptions map the code of a
+// promotion to the order numbers using it and their customer ids.
+type PromotionRepositoryImp struct {
+	mutex       sync.Mutex
+	promotions  map[string]response.Promotion
+	redemptions map[string]map[string]string
+}
+
+func (p *PromotionRepositoryImp) FetchPromotions(ctx context.Context) (promotions []response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.FetchPromotions")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	promotions = make([]response.Promotion, 0, len(p.promotions))
+	for code, promotion := range p.promotions {
+		promotion.TimesUsed = len(p.redemptions[code])
+		promotions = append(promotions, promotion)
+	}
+	sort.Slice(promotions, func(i, j int) bool { return promotions[i].Code < promotions[j].Code })
+	return promotions, nil
+}
+
+func (p *PromotionRepositoryImp) FetchPromotionByCode(ctx context.Context, code string) (_ *response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.FetchPromotionByCode")
+	span.SetAttributes(attribute.String(constants.PromotionCode, code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	promotion, ok := p.promotions[code]
+	if !ok {
+		return nil, nil
+	}
+
+	promotion.TimesUsed = len(p.redemptions[code])
+	return &promotion, nil
+}
+
+func (p *PromotionRepositoryImp) CreatePromotion(ctx context.Context, promotion response.Promotion) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.CreatePromotion")
+	span.SetAttributes(attribute.String(constants.PromotionCode, promotion.Code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.promotions[promotion.Code]; ok {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.SamePromotionFoundByCode).
+			Build()
+		return &errorResp
+	}
+
+	p.promotions[promotion.Code] = promotion
+	return nil
+}
+
+func (p *PromotionRepositoryImp) UpdatePromotion(ctx context.Context, promotion response.Promotion) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.UpdatePromotion")
+	span.SetAttributes(attribute.String(constants.PromotionCode, promotion.Code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.promotions[promotion.Code]; !ok {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.PromotionNotFound).
+			Build()
+		return &errorResp
+	}
+
+	p.promotions[promotion.Code] = promotion
+	return nil
+}
+
+func (p *PromotionRepositoryImp) CountRedemptions(ctx context.Context, code string, customerId string) (total int, ofCustomer int, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.CountRedemptions")
+	span.SetAttributes(attribute.String(constants.PromotionCode, code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return 0, 0, errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	total, ofCustomer = p.count(code, customerId)
+	return total, ofCustomer, nil
+}
+
+func (p *PromotionRepositoryImp) RedeemPromotions(ctx context.Context, orderNumber string, customerId string, codes []string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.RedeemPromotions")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, code := range codes {
+		promotion, ok := p.promotions[code]
+		if !ok {
+			errorResp := response.NewErrorBuilder().
+				SetError(http.StatusNotFound, constants.PromotionNotFound).
+				Build()
+			return &errorResp
+		}
+
+		total, ofCustomer := p.count(code, customerId)
+		if (promotion.UsageLimit > 0 && total >= promotion.UsageLimit) ||
+			(promotion.UsageLimitPerCustomer > 0 && ofCustomer >= promotion.UsageLimitPerCustomer) {
+			errorResp := response.NewErrorBuilder().
+				SetError(http.StatusConflict, constants.PromotionUsageLimitIsReached).
+				Build()
+			return &errorResp
+		}
+	}
+
+	for _, code := range codes {
+		if p.redemptions[code] == nil {
+			p.redemptions[code] = make(map[string]string)
+		}
+		p.redemptions[code][orderNumber] = customerId
+	}
+	return nil
+}
+
+func (p *PromotionRepositoryImp) ReleaseRedemptions(ctx context.Context, orderNumber string, codes []string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionRepository.ReleaseRedemptions")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, code := range codes {
+		delete(p.redemptions[code], orderNumber)
+	}
+	return nil
+}
+
+// count must be called with the lock held.
+func (p *PromotionRepositoryImp) count(code string, customerId string) (total int, ofCustomer int) {
+	for _, redeemingCustomerId := range p.redemptions[code] {
+		if redeemingCustomerId == customerId {
+			ofCustomer++
+		}
+	}
+	return len(p.redemptions[code]), ofCustomer
+}
+
+func NewPromotionRepository() PromotionRepository {
+	promotions := make(map[string]response.Promotion)
+	for _, promotion := range getPromotions() {
+		promotions[promotion.Code] = promotion
+	}
+	return &PromotionRepositoryImp{
+		promotions:  promotions,
+		redemptions: make(map[string]map[string]string),
+	}
+}
+
+// This function represents the campaigns of the marketing team
+func getPromotions() []response.Promotion {
+	createdAt := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return []response.Promotion{
+		{
+			Code:                  "WELCOME10",
+			Name:                  "10% off the first order",
+			Type:                  "percentage",
+			Value:                 10,
+			UsageLimitPerCustomer: 1,
+			Active:                true,
+			CreatedAt:             createdAt,
+			UpdatedAt:             createdAt,
+		},
+		{
+			Code:            "SAVE5EUR",
+			Name:            "5 EUR off baskets from 25 EUR",
+			Type:            "fixed",
+			Value:           5,
+			CurrencyCode:    "EUR",
+			MinBasketAmount: 25,
+			UsageLimit:      100,
+			Active:          true,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		},
+		{
+			Code:            "TRY1000",
+			Name:            "5% off baskets from 1000 TRY",
+			Automatic:       true,
+			Type:            "percentage",
+			Value:           5,
+			CurrencyCode:    "TRY",
+			MinBasketAmount: 1000,
+			Active:          true,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		},
+	}
+}
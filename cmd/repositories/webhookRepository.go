@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"sort"
+	"sync"
+)
+
+//go:generate mockery --name=WebhookRepository --structname=MockWebhookRepository --output=../mocks --filename=fakeWebhookRepositoryWithMockery.go
+type WebhookRepository interface {
+	FetchWebhooks(ctx context.Context) ([]response.Webhook, *response.ErrorResponse)
+	FetchWebhookById(ctx context.Context, id string) (*response.Webhook, *response.ErrorResponse)
+	SaveWebhook(ctx context.Context, webhook response.Webhook) *response.ErrorResponse
+	DeleteWebhook(ctx context.Context, id string) *response.ErrorResponse
+	FetchDeliveries(ctx context.Context, webhookId string) ([]response.WebhookDelivery, *response.ErrorResponse)
+	FetchDeliveryById(ctx context.Context, id string) (*response.WebhookDelivery, *response.ErrorResponse)
+	SaveDelivery(ctx context.Context, delivery response.WebhookDelivery) *response.ErrorResponse
+}
+
+// WebhookRepositoryImp keeps webhooks and their delivery logs in memory.
+type WebhookRepositoryImp struct {
+	mutex      sync.RWMutex
+	webhooks   map[string]response.Webhook
+	deliveries map[string]response.WebhookDelivery
+}
+
+func (w *WebhookRepositoryImp) FetchWebhooks(ctx context.Context) (webhooks []response.Webhook, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.FetchWebhooks")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	webhooks = make([]response.Webhook, 0, len(w.webhooks))
+	for _, webhook := range w.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt) })
+	return webhooks, nil
+}
+
+func (w *WebhookRepositoryImp) FetchWebhookById(ctx context.Context, id string) (_ *response.Webhook, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.FetchWebhookById")
+	span.SetAttributes(attribute.String(constants.WebhookId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	webhook, ok := w.webhooks[id]
+	if !ok {
+		return nil, nil
+	}
+	return &webhook, nil
+}
+
+func (w *WebhookRepositoryImp) SaveWebhook(ctx context.Context, webhook response.Webhook) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.SaveWebhook")
+	span.SetAttributes(attribute.String(constants.WebhookId, webhook.Id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.webhooks[webhook.Id] = webhook
+	return nil
+}
+
+func (w *WebhookRepositoryImp) DeleteWebhook(ctx context.Context, id string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.DeleteWebhook")
+	span.SetAttributes(attribute.String(constants.WebhookId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.webhooks, id)
+	return nil
+}
+
+func (w *WebhookRepositoryImp) FetchDeliveries(ctx context.Context, webhookId string) (deliveries []response.WebhookDelivery, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.FetchDeliveries")
+	span.SetAttributes(attribute.String(constants.WebhookId, webhookId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	deliveries = make([]response.WebhookDelivery, 0)
+	for _, delivery := range w.deliveries {
+		if delivery.WebhookId == webhookId {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt) })
+	return deliveries, nil
+}
+
+func (w *WebhookRepositoryImp) FetchDeliveryById(ctx context.Context, id string) (_ *response.WebhookDelivery, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.FetchDeliveryById")
+	span.SetAttributes(attribute.String(constants.DeliveryId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return nil, errorResp
+	}
+
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	delivery, ok := w.deliveries[id]
+	if !ok {
+		return nil, nil
+	}
+	return &delivery, nil
+}
+
+func (w *WebhookRepositoryImp) SaveDelivery(ctx context.Context, delivery response.WebhookDelivery) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookRepository.SaveDelivery")
+	span.SetAttributes(attribute.String(constants.DeliveryId, delivery.Id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if errorResp = helpers.ContextError(ctx); errorResp != nil {
+		return errorResp
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.deliveries[delivery.Id] = delivery
+	return nil
+}
+
+func NewWebhookRepository() WebhookRepository {
+	return &WebhookRepositoryImp{
+		webhooks:   map[string]response.Webhook{},
+		deliveries: map[string]response.WebhookDelivery{},
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"time"
+)
+
+const (
+	apiKeyPrefix       = "soa_"
+	apiKeyDisplayChars = 12
+)
+
+//go:generate mockery --name=ApiKeyService --structname=MockApiKeyService --output=../mocks --filename=fakeApiKeyServiceWithMockery.go
+type ApiKeyService interface {
+	GetApiKeys(ctx context.Context) ([]response.ApiKey, *response.ErrorResponse)
+	CreateApiKey(ctx context.Context, createApiKeyRequest request.CreateApiKeyRequest) (*response.CreatedApiKey, *response.ErrorResponse)
+	RotateApiKey(ctx context.Context, id string) (*response.CreatedApiKey, *response.ErrorResponse)
+	RevokeApiKey(ctx context.Context, id string) *response.ErrorResponse
+	RegisterApiKey(ctx context.Context, name string, key string, scopes []string) *response.ErrorResponse
+	Authenticate(ctx context.Context, key string) (*models.Principal, *response.ErrorResponse)
+}
+
+type ApiKeyServiceImp struct {
+	apiKeyRepository repositories.ApiKeyRepository
+	now              func() time.Time
+}
+
+func (a ApiKeyServiceImp) GetApiKeys(ctx context.Context) (apiKeys []response.ApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.GetApiKeys")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return a.apiKeyRepository.FetchApiKeys(ctx)
+}
+
+func (a ApiKeyServiceImp) CreateApiKey(ctx context.Context, createApiKeyRequest request.CreateApiKeyRequest) (_ *response.CreatedApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.CreateApiKey")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	id, err := randomBytes(8)
+	if err != nil {
+		return nil, internalError()
+	}
+
+	apiKey := response.ApiKey{
+		Id:        hex.EncodeToString(id),
+		Name:      createApiKeyRequest.Name,
+		Scopes:    createApiKeyRequest.Scopes,
+		CreatedAt: a.now(),
+	}
+	return a.issueKey(ctx, apiKey)
+}
+
+func (a ApiKeyServiceImp) RotateApiKey(ctx context.Context, id string) (_ *response.CreatedApiKey, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.RotateApiKey")
+	span.SetAttributes(attribute.String(constants.ApiKeyId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	apiKey, errorResp := a.getActiveApiKey(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	rotatedAt := a.now()
+	apiKey.RotatedAt = &rotatedAt
+	return a.issueKey(ctx, *apiKey)
+}
+
+func (a ApiKeyServiceImp) RevokeApiKey(ctx context.Context, id string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.RevokeApiKey")
+	span.SetAttributes(attribute.String(constants.ApiKeyId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	apiKey, errorResp := a.getActiveApiKey(ctx, id)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	revokedAt := a.now()
+	apiKey.RevokedAt = &revokedAt
+	return a.apiKeyRepository.SaveApiKey(ctx, *apiKey)
+}
+
+// RegisterApiKey stores a key that was issued outside the api, like the configured
+// admin key that bootstraps key management.
+func (a ApiKeyServiceImp) RegisterApiKey(ctx context.Context, name string, key string, scopes []string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.RegisterApiKey")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	id, err := randomBytes(8)
+	if err != nil {
+		return internalError()
+	}
+
+	prefix := key
+	if len(prefix) > apiKeyDisplayChars {
+		prefix = prefix[:apiKeyDisplayChars]
+	}
+
+	apiKey := response.ApiKey{
+		Id:        hex.EncodeToString(id),
+		Name:      name,
+		Scopes:    scopes,
+		KeyHash:   hashApiKey(key),
+		Prefix:    prefix,
+		CreatedAt: a.now(),
+	}
+	return a.apiKeyRepository.SaveApiKey(ctx, apiKey)
+}
+
+// Authenticate resolves the principal of a plain api key and counts its usage.
+func (a ApiKeyServiceImp) Authenticate(ctx context.Context, key string) (_ *models.Principal, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ApiKeyService.Authenticate")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	apiKey, errorResp := a.apiKeyRepository.FetchApiKeyByHash(ctx, hashApiKey(key))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if apiKey == nil || apiKey.RevokedAt != nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusUnauthorized, constants.ApiKeyIsNotValid).
+			Build()
+		return nil, &errorResp
+	}
+
+	if errorResp := a.apiKeyRepository.RecordApiKeyUsage(ctx, apiKey.Id, a.now()); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &models.Principal{
+		Type:    models.ApiKeyPrincipal,
+		Subject: apiKey.Id,
+		Scopes:  apiKey.Scopes,
+	}, nil
+}
+
+func (a ApiKeyServiceImp) getActiveApiKey(ctx context.Context, id string) (*response.ApiKey, *response.ErrorResponse) {
+	apiKey, errorResp := a.apiKeyRepository.FetchApiKeyById(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if apiKey == nil || apiKey.RevokedAt != nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ApiKeyNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return apiKey, nil
+}
+
+// issueKey generates a new secret for the api key and stores only its hash.
+func (a ApiKeyServiceImp) issueKey(ctx context.Context, apiKey response.ApiKey) (*response.CreatedApiKey, *response.ErrorResponse) {
+	secret, err := randomBytes(32)
+	if err != nil {
+		return nil, internalError()
+	}
+
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	apiKey.KeyHash = hashApiKey(key)
+	apiKey.Prefix = key[:apiKeyDisplayChars]
+	if errorResp := a.apiKeyRepository.SaveApiKey(ctx, apiKey); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &response.CreatedApiKey{ApiKey: apiKey, Key: key}, nil
+}
+
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomBytes(count int) ([]byte, error) {
+	result := make([]byte, count)
+	_, err := rand.Read(result)
+	return result, err
+}
+
+func internalError() *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, constants.UnexpectedErrorOccurred).
+		Build()
+	return &errorResp
+}
+
+func NewApiKeyService(apiKeyRepository repositories.ApiKeyRepository) ApiKeyService {
+	return &ApiKeyServiceImp{
+		apiKeyRepository: apiKeyRepository,
+		now:              time.Now,
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateApiKey_StoresOnlyHashOfKey(t *testing.T) {
+	//Given
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("SaveApiKey", mock.Anything, mock.Anything).Return(nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+	createReq := request.CreateApiKeyRequest{Name: "marketplace", Scopes: []string{"orders:read"}}
+
+	//When
+	resp, err := service.CreateApiKey(context.Background(), createReq)
+
+	//Then
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(resp.Key, apiKeyPrefix))
+	assert.Equal(t, resp.Key[:apiKeyDisplayChars], resp.Prefix)
+	savedApiKey := mockApiKeyRepository.Calls[0].Arguments.Get(1).(response.ApiKey)
+	assert.Equal(t, hashApiKey(resp.Key), savedApiKey.KeyHash)
+	assert.NotContains(t, savedApiKey.KeyHash, resp.Key)
+	assert.Equal(t, createReq.Scopes, savedApiKey.Scopes)
+}
+
+func TestRegisterApiKey_StoresHashOfGivenKey(t *testing.T) {
+	//Given
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("SaveApiKey", mock.Anything, mock.Anything).Return(nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+
+	//When
+	err := service.RegisterApiKey(context.Background(), "admin", "configured-admin-key", []string{"apikeys:manage"})
+
+	//Then
+	assert.Nil(t, err)
+	savedApiKey := mockApiKeyRepository.Calls[0].Arguments.Get(1).(response.ApiKey)
+	assert.Equal(t, hashApiKey("configured-admin-key"), savedApiKey.KeyHash)
+	assert.Equal(t, "configured-a", savedApiKey.Prefix)
+	assert.Equal(t, []string{"apikeys:manage"}, savedApiKey.Scopes)
+}
+
+func TestAuthenticate_WithActiveKey_ReturnsPrincipalAndRecordsUsage(t *testing.T) {
+	//Given
+	key := "soa_secret"
+	apiKey := response.ApiKey{Id: "key-1", Scopes: []string{"orders:write"}, KeyHash: hashApiKey(key)}
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("FetchApiKeyByHash", mock.Anything, hashApiKey(key)).Return(&apiKey, nil)
+	mockApiKeyRepository.On("RecordApiKeyUsage", mock.Anything, "key-1", mock.Anything).Return(nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+
+	//When
+	principal, err := service.Authenticate(context.Background(), key)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "key-1", principal.Subject)
+	assert.Equal(t, []string{"orders:write"}, principal.Scopes)
+	mockApiKeyRepository.AssertNumberOfCalls(t, "RecordApiKeyUsage", 1)
+}
+
+func TestAuthenticate_WithUnknownOrRevokedKey_ReturnsUnauthorized(t *testing.T) {
+	revokedAt := time.Now()
+	tests := []struct {
+		name   string
+		apiKey *response.ApiKey
+	}{
+		{"unknown key", nil},
+		{"revoked key", &response.ApiKey{Id: "key-1", RevokedAt: &revokedAt}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+			mockApiKeyRepository.On("FetchApiKeyByHash", mock.Anything, mock.Anything).Return(test.apiKey, nil)
+			service := NewApiKeyService(mockApiKeyRepository)
+
+			//When
+			principal, err := service.Authenticate(context.Background(), "soa_secret")
+
+			//Then
+			assert.Nil(t, principal)
+			assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+			assert.Equal(t, constants.ApiKeyIsNotValid, err.Message)
+			mockApiKeyRepository.AssertNumberOfCalls(t, "RecordApiKeyUsage", 0)
+		})
+	}
+}
+
+func TestRotateApiKey_ReplacesKeyHash(t *testing.T) {
+	//Given
+	apiKey := response.ApiKey{Id: "key-1", KeyHash: hashApiKey("soa_old")}
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("FetchApiKeyById", mock.Anything, "key-1").Return(&apiKey, nil)
+	mockApiKeyRepository.On("SaveApiKey", mock.Anything, mock.Anything).Return(nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+
+	//When
+	resp, err := service.RotateApiKey(context.Background(), "key-1")
+
+	//Then
+	assert.Nil(t, err)
+	assert.NotEqual(t, "soa_old", resp.Key)
+	assert.Equal(t, hashApiKey(resp.Key), resp.KeyHash)
+	assert.NotNil(t, resp.RotatedAt)
+}
+
+func TestRevokeApiKey_WhenApiKeyNotFound_ReturnsNotFound(t *testing.T) {
+	//Given
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("FetchApiKeyById", mock.Anything, mock.Anything).Return(nil, nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+
+	//When
+	err := service.RevokeApiKey(context.Background(), "key-1")
+
+	//Then
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.ApiKeyNotFound, err.Message)
+	mockApiKeyRepository.AssertNumberOfCalls(t, "SaveApiKey", 0)
+}
+
+func TestRevokeApiKey_MarksApiKeyAsRevoked(t *testing.T) {
+	//Given
+	apiKey := response.ApiKey{Id: "key-1"}
+	mockApiKeyRepository := &mocks.MockApiKeyRepository{}
+	mockApiKeyRepository.On("FetchApiKeyById", mock.Anything, "key-1").Return(&apiKey, nil)
+	mockApiKeyRepository.On("SaveApiKey", mock.Anything, mock.MatchedBy(func(saved response.ApiKey) bool {
+		return saved.Id == "key-1" && saved.RevokedAt != nil
+	})).Return(nil)
+	service := NewApiKeyService(mockApiKeyRepository)
+
+	//When
+	err := service.RevokeApiKey(context.Background(), "key-1")
+
+	//Then
+	assert.Nil(t, err)
+	mockApiKeyRepository.AssertNumberOfCalls(t, "SaveApiKey", 1)
+}
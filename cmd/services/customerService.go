@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"strings"
+	"time"
+)
+
+//go:generate mockery --name=CustomerService --structname=MockCustomerService --output=../mocks --filename=fakeCustomerServiceWithMockery.go
+type CustomerService interface {
+	GetCustomers(ctx context.Context) ([]response.Customer, *response.ErrorResponse)
+	GetCustomer(ctx context.Context, id string) (*response.Customer, *response.ErrorResponse)
+	CreateCustomer(ctx context.Context, customerRequest request.CustomerRequest) (*response.Customer, *response.ErrorResponse)
+	UpdateCustomer(ctx context.Context, id string, customerRequest request.CustomerRequest) (*response.Customer, *response.ErrorResponse)
+	// DeleteCustomer refuses to delete customers with orders, which would lose their owner.
+	DeleteCustomer(ctx context.Context, id string) *response.ErrorResponse
+	GetCustomerOrders(ctx context.Context, id string, filter request.OrderFilter) ([]response.Order, *response.ErrorResponse)
+}
+
+type CustomerServiceImp struct {
+	customerRepository repositories.CustomerRepository
+	orderRepository    repositories.OrderRepository
+	now                func() time.Time
+}
+
+func (c *CustomerServiceImp) GetCustomers(ctx context.Context) (customers []response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.GetCustomers")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	customers, errorResp = c.customerRepository.FetchCustomers(ctx)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	principal := helpers.GetPrincipal(ctx)
+	if policies.HasPermission(principal, policies.ReadAllCustomers) {
+		return customers, nil
+	}
+
+	ownCustomers := make([]response.Customer, 0, 1)
+	for _, customer := range customers {
+		if customer.Id == principal.Subject {
+			ownCustomers = append(ownCustomers, customer)
+		}
+	}
+	return ownCustomers, nil
+}
+
+func (c *CustomerServiceImp) GetCustomer(ctx context.Context, id string) (_ *response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.GetCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return c.getCustomer(ctx, id)
+}
+
+func (c *CustomerServiceImp) CreateCustomer(ctx context.Context, customerRequest request.CustomerRequest) (_ *response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.CreateCustomer")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	id, errorResp := newCustomerId(helpers.GetPrincipal(ctx))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+
+	customer := newCustomer(id, customerRequest, c.now())
+	if errorResp := c.customerRepository.CreateCustomer(ctx, customer); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &customer, nil
+}
+
+func (c *CustomerServiceImp) UpdateCustomer(ctx context.Context, id string, customerRequest request.CustomerRequest) (_ *response.Customer, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.UpdateCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	existingCustomer, errorResp := c.getCustomer(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	customer := newCustomer(id, customerRequest, c.now())
+	customer.CreatedAt = existingCustomer.CreatedAt
+	if errorResp := c.customerRepository.UpdateCustomer(ctx, customer); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &customer, nil
+}
+
+func (c *CustomerServiceImp) DeleteCustomer(ctx context.Context, id string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.DeleteCustomer")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if _, errorResp := c.getCustomer(ctx, id); errorResp != nil {
+		return errorResp
+	}
+
+	hasOrders := false
+	errorResp = c.orderRepository.IterateOrders(ctx, func(order response.Order) bool {
+		hasOrders = order.CustomerId == id
+		return !hasOrders
+	})
+	if errorResp != nil {
+		return errorResp
+	}
+
+	if hasOrders {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.CustomerWithOrdersCanNotBeDeleted).
+			Build()
+		return &errorResp
+	}
+
+	return c.customerRepository.DeleteCustomer(ctx, id)
+}
+
+func (c *CustomerServiceImp) GetCustomerOrders(ctx context.Context, id string, filter request.OrderFilter) (_ []response.Order, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "CustomerService.GetCustomerOrders")
+	span.SetAttributes(attribute.String(constants.CustomerId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if _, errorResp := c.getCustomer(ctx, id); errorResp != nil {
+		return nil, errorResp
+	}
+
+	orders, errorResp := c.orderRepository.FetchOrders(ctx)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	filter.CustomerId = id
+	return filter.Apply(orders), nil
+}
+
+// getCustomer checks the access before the lookup, so that callers can not probe for
+// the ids of other customers.
+func (c *CustomerServiceImp) getCustomer(ctx context.Context, id string) (*response.Customer, *response.ErrorResponse) {
+	if !policies.CanAccessCustomer(helpers.GetPrincipal(ctx), id) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusForbidden, constants.CustomerAccessDenied).
+			Build()
+		return nil, &errorResp
+	}
+
+	customer, errorResp := c.customerRepository.FetchCustomerById(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if customer == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.CustomerNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return customer, nil
+}
+
+// newCustomerId returns the subject of customer principals, whose own record is looked
+// up by it, and a random id for everyone else, including api keys.
+func newCustomerId(principal *models.Principal) (string, *response.ErrorResponse) {
+	if policies.IsCustomer(principal) {
+		return principal.Subject, nil
+	}
+
+	id, err := randomBytes(8)
+	if err != nil {
+		return "", internalError()
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func newCustomer(id string, customerRequest request.CustomerRequest, now time.Time) response.Customer {
+	addresses := make([]response.CustomerAddress, 0, len(customerRequest.Addresses))
+	for _, address := range customerRequest.Addresses {
+		addresses = append(addresses, response.CustomerAddress{
+			Label:    strings.TrimSpace(address.Label),
+			Address:  address.Address,
+			City:     address.City,
+			District: address.District,
+		})
+	}
+
+	return response.Customer{
+		Id:        id,
+		FirstName: customerRequest.FirstName,
+		LastName:  customerRequest.LastName,
+		Email:     strings.ToLower(customerRequest.Email),
+		Phone:     customerRequest.Phone,
+		Addresses: addresses,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func NewCustomerService(customerRepository repositories.CustomerRepository, orderRepository repositories.OrderRepository) CustomerService {
+	return &CustomerServiceImp{
+		customerRepository: customerRepository,
+		orderRepository:    orderRepository,
+		now:                time.Now,
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"testing"
+)
+
+func getCustomerRequest() request.CustomerRequest {
+	return request.CustomerRequest{
+		FirstName: "Ayşe",
+		LastName:  "Yılmaz",
+		Email:     "Ayse.Yilmaz@example.com",
+		Phone:     "+90 532 000 00 00",
+		Addresses: []request.CustomerAddress{
+			{Label: " home ", Address: "Lorem ipsum dolor sit amet", City: "Ankara", District: "Çankaya"},
+		},
+	}
+}
+
+func withCustomerPrincipal(subject string) context.Context {
+	return helpers.WithPrincipal(context.Background(), &models.Principal{
+		Type:    models.UserPrincipal,
+		Subject: subject,
+		Roles:   []string{policies.CustomerRole},
+	})
+}
+
+func TestCreateCustomer_StoresCustomerWithGeneratedId(t *testing.T) {
+	//Given
+	customerRepository := repositories.NewCustomerRepository()
+	service := NewCustomerService(customerRepository, &mocks.MockOrderRepository{})
+
+	//When
+	customer, err := service.CreateCustomer(context.Background(), getCustomerRequest())
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, customer.Id, 16)
+	assert.Equal(t, "ayse.yilmaz@example.com", customer.Email)
+	assert.Equal(t, []response.CustomerAddress{
+		{Label: "home", Address: "Lorem ipsum dolor sit amet", City: "Ankara", District: "Çankaya"},
+	}, customer.Addresses)
+	storedCustomer, _ := customerRepository.FetchCustomerById(context.Background(), customer.Id)
+	assert.Equal(t, customer, storedCustomer)
+}
+
+func TestCreateCustomer_WhenPrincipalIsCustomer_UsesSubjectAsId(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+
+	//When
+	customer, err := service.CreateCustomer(withCustomerPrincipal("customer-9"), getCustomerRequest())
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "customer-9", customer.Id)
+}
+
+func TestCreateCustomer_WhenPrincipalIsApiKey_GeneratesIdOfEveryCustomer(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Type:    models.ApiKeyPrincipal,
+		Subject: "key-1",
+		Scopes:  []string{policies.WebhooksScope},
+	})
+	secondRequest := getCustomerRequest()
+	secondRequest.Email = "ayse.ata@example.com"
+
+	//When
+	first, firstErr := service.CreateCustomer(ctx, getCustomerRequest())
+	second, secondErr := service.CreateCustomer(ctx, secondRequest)
+
+	//Then
+	assert.Nil(t, firstErr)
+	assert.Nil(t, secondErr)
+	assert.NotEqual(t, "key-1", first.Id)
+	assert.NotEqual(t, first.Id, second.Id)
+}
+
+func TestCreateCustomer_WhenEmailIsTaken_ReturnsConflict(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+	customerRequest := getCustomerRequest()
+	customerRequest.Email = "AHMET.ATA@example.com"
+
+	//When
+	customer, err := service.CreateCustomer(context.Background(), customerRequest)
+
+	//Then
+	assert.Nil(t, customer)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.SameCustomerFoundByEmail, err.Message)
+}
+
+func TestGetCustomers_WhenPrincipalIsCustomer_ReturnsOnlyOwnRecord(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+
+	//When
+	customers, err := service.GetCustomers(withCustomerPrincipal("customer-2"))
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, customers, 1)
+	assert.Equal(t, "customer-2", customers[0].Id)
+}
+
+func TestGetCustomer_WhenCustomerBelongsToAnotherPrincipal_ReturnsForbidden(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+
+	//When
+	customer, err := service.GetCustomer(withCustomerPrincipal("customer-1"), "customer-2")
+
+	//Then
+	assert.Nil(t, customer)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	assert.Equal(t, constants.CustomerAccessDenied, err.Message)
+}
+
+func TestUpdateCustomer_ReplacesFieldsAndKeepsCreationTime(t *testing.T) {
+	//Given
+	customerRepository := repositories.NewCustomerRepository()
+	service := NewCustomerService(customerRepository, &mocks.MockOrderRepository{})
+	existingCustomer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-1")
+
+	//When
+	customer, err := service.UpdateCustomer(context.Background(), "customer-1", getCustomerRequest())
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "Ayşe", customer.FirstName)
+	assert.Equal(t, existingCustomer.CreatedAt, customer.CreatedAt)
+	assert.True(t, customer.UpdatedAt.After(existingCustomer.UpdatedAt))
+}
+
+func TestUpdateCustomer_WhenCustomerDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewCustomerService(repositories.NewCustomerRepository(), &mocks.MockOrderRepository{})
+
+	//When
+	customer, err := service.UpdateCustomer(context.Background(), "customer-9", getCustomerRequest())
+
+	//Then
+	assert.Nil(t, customer)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+}
+
+func TestDeleteCustomer_WhenCustomerHasOrders_ReturnsConflict(t *testing.T) {
+	//Given
+	customerRepository := repositories.NewCustomerRepository()
+	service := NewCustomerService(customerRepository, repositories.NewOrderRepository(repositories.NewInMemoryDatabase()))
+
+	//When
+	err := service.DeleteCustomer(context.Background(), "customer-2")
+
+	//Then
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.CustomerWithOrdersCanNotBeDeleted, err.Message)
+	customer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-2")
+	assert.NotNil(t, customer)
+}
+
+func TestDeleteCustomer_WhenCustomerHasNoOrders_DeletesCustomer(t *testing.T) {
+	//Given
+	customerRepository := repositories.NewCustomerRepository()
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockOrderRepository.On("IterateOrders", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(1).(func(order response.Order) bool)
+			visit(response.Order{OrderNumber: "1", CustomerId: "customer-2"})
+		}).
+		Return(nil)
+	service := NewCustomerService(customerRepository, mockOrderRepository)
+
+	//When
+	err := service.DeleteCustomer(context.Background(), "customer-1")
+
+	//Then
+	assert.Nil(t, err)
+	customer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-1")
+	assert.Nil(t, customer)
+}
+
+func TestGetCustomerOrders_ReturnsMatchingOrdersOfCustomer(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	orders := []response.Order{
+		{OrderNumber: "1", CustomerId: "customer-1", City: "Ankara"},
+		{OrderNumber: "2", CustomerId: "customer-1", City: "İzmir"},
+		{OrderNumber: "3", CustomerId: "customer-2", City: "Ankara"},
+	}
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(orders, nil)
+	service := NewCustomerService(repositories.NewCustomerRepository(), mockOrderRepository)
+
+	//When
+	resp, err := service.GetCustomerOrders(context.Background(), "customer-1", request.OrderFilter{City: "Ankara", CustomerId: "customer-2"})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.Order{orders[0]}, resp)
+}
+
+func TestGetCustomerOrders_WhenCustomerDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	service := NewCustomerService(repositories.NewCustomerRepository(), mockOrderRepository)
+
+	//When
+	resp, err := service.GetCustomerOrders(context.Background(), "customer-9", request.OrderFilter{})
+
+	//Then
+	assert.Nil(t, resp)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	mockOrderRepository.AssertNotCalled(t, "FetchOrders", mock.Anything)
+}
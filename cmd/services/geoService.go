@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"strings"
+)
+
+//go:generate mockery --name=GeoService --structname=MockGeoService --output=../mocks --filename=fakeGeoServiceWithMockery.go
+type GeoService interface {
+	GetCountries(ctx context.Context) ([]response.Country, *response.ErrorResponse)
+	GetCities(ctx context.Context, countryCode string, query string) ([]response.City, *response.ErrorResponse)
+	GetDistricts(ctx context.Context, cityId string) ([]response.District, *response.ErrorResponse)
+	// CheckAddress rejects addresses in a country of the catalog whose city is missing from
+	// it or whose district does not belong to their city. Addresses in other countries can
+	// not be checked and are accepted. A district is only checked within a country, so an
+	// address with a district but no country code needs a city the catalog knows in a
+	// single country.
+	CheckAddress(ctx context.Context, address response.Address) *response.ErrorResponse
+}
+
+type GeoServiceImp struct {
+	geoRepository repositories.GeoRepository
+}
+
+func (g *GeoServiceImp) GetCountries(ctx context.Context) (_ []response.Country, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoService.GetCountries")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return g.geoRepository.FetchCountries(ctx)
+}
+
+func (g *GeoServiceImp) GetCities(ctx context.Context, countryCode string, query string) (_ []response.City, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoService.GetCities")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return g.geoRepository.FetchCities(ctx, countryCode, query)
+}
+
+func (g *GeoServiceImp) GetDistricts(ctx context.Context, cityId string) (_ []response.District, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoService.GetDistricts")
+	span.SetAttributes(attribute.String(constants.CityId, cityId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	city, errorResp := g.geoRepository.FetchCityById(ctx, cityId)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if city == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.CityNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return g.geoRepository.FetchDistricts(ctx, cityId)
+}
+
+func (g *GeoServiceImp) CheckAddress(ctx context.Context, address response.Address) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "GeoService.CheckAddress")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	address = address.Normalized()
+	countryCode := address.CountryCode
+	if len(countryCode) == 0 {
+		if len(address.District) == 0 {
+			return nil
+		}
+
+		cities, errorResp := g.geoRepository.FetchCitiesByName(ctx, "", address.City)
+		if errorResp != nil {
+			return errorResp
+		}
+
+		if !isInOneCountry(cities) {
+			return geoError(constants.CountryCodeIsRequired)
+		}
+		countryCode = cities[0].CountryCode
+	}
+
+	covered, errorResp := g.isCovered(ctx, countryCode)
+	if errorResp != nil || !covered {
+		return errorResp
+	}
+
+	cities, errorResp := g.geoRepository.FetchCitiesByName(ctx, countryCode, address.City)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	if len(cities) == 0 {
+		return geoError(constants.CityNotFound)
+	}
+
+	if len(address.District) == 0 {
+		return nil
+	}
+
+	for _, city := range cities {
+		district, errorResp := g.geoRepository.FetchDistrictByName(ctx, city.Id, address.District)
+		if errorResp != nil {
+			return errorResp
+		}
+
+		if district != nil {
+			return nil
+		}
+	}
+
+	return geoError(constants.DistrictDoesNotBelongToCity)
+}
+
+// isCovered reports whether the catalog lists the cities of the country.
+func (g *GeoServiceImp) isCovered(ctx context.Context, countryCode string) (bool, *response.ErrorResponse) {
+	countries, errorResp := g.geoRepository.FetchCountries(ctx)
+	if errorResp != nil {
+		return false, errorResp
+	}
+
+	for _, country := range countries {
+		if strings.EqualFold(country.Code, countryCode) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isInOneCountry(cities []response.City) bool {
+	if len(cities) == 0 {
+		return false
+	}
+
+	for _, city := range cities {
+		if city.CountryCode != cities[0].CountryCode {
+			return false
+		}
+	}
+	return true
+}
+
+func geoError(message string) *response.ErrorResponse {
+	errorResponse := response.NewErrorBuilder().
+		SetError(http.StatusBadRequest, message).
+		Build()
+	return &errorResponse
+}
+
+func NewGeoService(geoRepository repositories.GeoRepository) GeoService {
+	return &GeoServiceImp{
+		geoRepository: geoRepository,
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"testing"
+)
+
+func TestGetCountries_ReturnsCountriesOfTheCatalog(t *testing.T) {
+	//Given
+	service := NewGeoService(repositories.NewGeoRepository())
+
+	//When
+	countries, err := service.GetCountries(context.Background())
+
+	//Then
+	assert.Nil(t, err)
+	assert.Contains(t, countries, response.Country{Code: "TR", Name: "Türkiye"})
+}
+
+func TestGetCities_MatchesNamePrefixRegardlessOfCaseAndDiacritics(t *testing.T) {
+	//Given
+	service := NewGeoService(repositories.NewGeoRepository())
+
+	//When
+	cities, err := service.GetCities(context.Background(), "tr", "IST")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.City{{Id: "tr-istanbul", Name: "İstanbul", CountryCode: "TR"}}, cities)
+}
+
+func TestGetDistricts_ReturnsDistrictsOfCity(t *testing.T) {
+	//Given
+	service := NewGeoService(repositories.NewGeoRepository())
+
+	//When
+	districts, err := service.GetDistricts(context.Background(), "tr-istanbul")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, districts, 39)
+	assert.Contains(t, districts, response.District{Id: "tr-istanbul-kadikoy", Name: "Kadıköy", CityId: "tr-istanbul"})
+}
+
+func TestGetDistricts_WhenCityDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	mockGeoRepository := &mocks.MockGeoRepository{}
+	mockGeoRepository.On("FetchCityById", mock.Anything, "xx-nowhere").Return(nil, nil)
+	service := NewGeoService(mockGeoRepository)
+
+	//When
+	districts, err := service.GetDistricts(context.Background(), "xx-nowhere")
+
+	//Then
+	assert.Nil(t, districts)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.CityNotFound, err.Message)
+	mockGeoRepository.AssertNotCalled(t, "FetchDistricts", mock.Anything, mock.Anything)
+}
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     response.Address
+		expectedErr string
+	}{
+		{"district of city", response.Address{City: "London", District: "Westminster", CountryCode: "GB"}, ""},
+		{"names without diacritics", response.Address{City: "Istanbul", District: "Uskudar"}, ""},
+		{"city of country missing from catalog", response.Address{City: "London", District: "Mitte", CountryCode: "CA"}, ""},
+		{"without district", response.Address{City: "Paris", CountryCode: "FR"}, ""},
+		{"without district and country", response.Address{City: "Londn"}, ""},
+		{"city missing from country of catalog", response.Address{City: "Leeds", District: "Headingley", CountryCode: "GB"}, constants.CityNotFound},
+		{"misspelled city without district", response.Address{City: "Londn", CountryCode: "GB"}, constants.CityNotFound},
+		{"unknown city without country", response.Address{City: "Londn", District: "Westminster"}, constants.CountryCodeIsRequired},
+		{"city as district", response.Address{City: "London", District: "Birmingham"}, constants.DistrictDoesNotBelongToCity},
+		{"district of another city", response.Address{City: "Ankara", District: "Kadıköy", CountryCode: "TR"}, constants.DistrictDoesNotBelongToCity},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			service := NewGeoService(repositories.NewGeoRepository())
+
+			//When
+			err := service.CheckAddress(context.Background(), test.address)
+
+			//Then
+			if len(test.expectedErr) == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, test.expectedErr, err.Message)
+		})
+	}
+}
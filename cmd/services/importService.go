@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/imports"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"sync"
+	"time"
+)
+
+// importProgressInterval is the number of rows after which a background job saves its
+// progress.
+const importProgressInterval = 100
+
+//go:generate mockery --name=ImportService --structname=MockImportService --output=../mocks --filename=fakeImportServiceWithMockery.go
+type ImportService interface {
+	// ImportOrders validates every row with the rules of CreateOrderRequest and creates
+	// the valid orders. A dry run runs the checks of CreateOrder on every row instead,
+	// each against the current state rather than the rows before it. Imports of up to
+	// the sync row limit are returned finished; larger ones continue in the background
+	// and are polled with GetImportJob.
+	ImportOrders(ctx context.Context, rows []imports.Row, format string, dryRun bool) (*response.ImportJob, *response.ErrorResponse)
+	GetImportJob(ctx context.Context, id string) (*response.ImportJob, *response.ErrorResponse)
+	// Close cancels the background imports and waits for them to save their state.
+	Close()
+}
+
+type ImportServiceImp struct {
+	orderService        OrderService
+	importJobRepository repositories.ImportJobRepository
+	config              models.ImportConfig
+	now                 func() time.Time
+	stop                chan struct{}
+	stopOnce            sync.Once
+	waitGroup           sync.WaitGroup
+}
+
+func (i *ImportServiceImp) ImportOrders(ctx context.Context, rows []imports.Row, format string, dryRun bool) (_ *response.ImportJob, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ImportService.ImportOrders")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	id, err := randomBytes(8)
+	if err != nil {
+		return nil, internalError()
+	}
+
+	importJob := response.ImportJob{
+		Id:        hex.EncodeToString(id),
+		Status:    string(enum.RunningImportStatus),
+		Format:    format,
+		DryRun:    dryRun,
+		TotalRows: len(rows),
+		Errors:    make([]response.ImportRowError, 0),
+		CreatedAt: i.now(),
+	}
+	principal := helpers.GetPrincipal(ctx)
+	if principal != nil {
+		importJob.CreatedBy = principal.Subject
+	}
+	span.SetAttributes(attribute.String(constants.ImportJobId, importJob.Id))
+	if errorResp := i.importJobRepository.SaveImportJob(ctx, importJob); errorResp != nil {
+		return nil, errorResp
+	}
+
+	if len(rows) <= i.config.SyncRowLimit {
+		importJob, errorResp = i.run(ctx, importJob, rows)
+		if errorResp != nil {
+			return nil, errorResp
+		}
+		return &importJob, nil
+	}
+
+	// the job outlives the request, so it keeps only the caller and the trace of it
+	importCtx := trace.ContextWithRemoteSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	importCtx = helpers.WithRequestId(helpers.WithPrincipal(importCtx, principal), helpers.GetRequestId(ctx))
+	i.waitGroup.Add(1)
+	go func(importJob response.ImportJob) {
+		defer i.waitGroup.Done()
+		if _, errorResp := i.run(importCtx, importJob, rows); errorResp != nil {
+			fmt.Printf("Import job %s could not be saved: %s\n", importJob.Id, errorResp.Message)
+		}
+	}(importJob)
+
+	return &importJob, nil
+}
+
+func (i *ImportServiceImp) GetImportJob(ctx context.Context, id string) (_ *response.ImportJob, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ImportService.GetImportJob")
+	span.SetAttributes(attribute.String(constants.ImportJobId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	importJob, errorResp := i.importJobRepository.FetchImportJobById(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	// jobs of other callers are reported as missing so that their ids are not revealed
+	principal := helpers.GetPrincipal(ctx)
+	if importJob == nil || (!policies.HasPermission(principal, policies.ReadAllOrders) && importJob.CreatedBy != principal.Subject) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ImportJobNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return importJob, nil
+}
+
+func (i *ImportServiceImp) Close() {
+	i.stopOnce.Do(func() { close(i.stop) })
+	i.waitGroup.Wait()
+}
+
+// run imports the rows one by one, saving the progress every importProgressInterval rows
+// and the outcome once the rows are done or the service is closed.
+func (i *ImportServiceImp) run(ctx context.Context, importJob response.ImportJob, rows []imports.Row) (response.ImportJob, *response.ErrorResponse) {
+	orderNumbers := make(map[string]struct{}, len(rows))
+	importJob.Status = string(enum.CompletedImportStatus)
+	for _, row := range rows {
+		if i.isStopped() {
+			importJob.Status = string(enum.CancelledImportStatus)
+			break
+		}
+
+		importJob.ProcessedRows++
+		if errorResp := i.importRow(ctx, row, orderNumbers, importJob.DryRun); errorResp != nil {
+			importJob.FailedRows++
+			if len(importJob.Errors) < i.config.MaxReportedErrors {
+				importJob.Errors = append(importJob.Errors, response.ImportRowError{
+					Row:         row.Line,
+					OrderNumber: row.Request.OrderNumber,
+					Error:       *errorResp,
+				})
+			}
+		} else {
+			importJob.ImportedRows++
+		}
+
+		if importJob.ProcessedRows%importProgressInterval == 0 && importJob.ProcessedRows < importJob.TotalRows {
+			progress := importJob
+			progress.Status = string(enum.RunningImportStatus)
+			if errorResp := i.importJobRepository.SaveImportJob(ctx, progress); errorResp != nil {
+				return importJob, errorResp
+			}
+		}
+	}
+
+	completedAt := i.now()
+	importJob.CompletedAt = &completedAt
+	return importJob, i.importJobRepository.SaveImportJob(ctx, importJob)
+}
+
+func (i *ImportServiceImp) isStopped() bool {
+	select {
+	case <-i.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (i *ImportServiceImp) importRow(ctx context.Context, row imports.Row, orderNumbers map[string]struct{}, dryRun bool) *response.ErrorResponse {
+	if row.Error != nil {
+		return row.Error
+	}
+
+	if errorResp := row.Request.Validate(); errorResp != nil {
+		return errorResp
+	}
+
+	if _, ok := orderNumbers[row.Request.OrderNumber]; ok {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
+			Build()
+		return &errorResp
+	}
+	orderNumbers[row.Request.OrderNumber] = struct{}{}
+
+	if dryRun {
+		return i.orderService.CheckOrder(ctx, row.Request)
+	}
+	return i.orderService.CreateOrder(ctx, row.Request)
+}
+
+func NewImportService(orderService OrderService, importJobRepository repositories.ImportJobRepository, config models.ImportConfig) ImportService {
+	return &ImportServiceImp{
+		orderService:        orderService,
+		importJobRepository: importJobRepository,
+		config:              config,
+		now:                 time.Now,
+		stop:                make(chan struct{}),
+	}
+}
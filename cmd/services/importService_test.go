@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/imports"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"testing"
+	"time"
+)
+
+var testImportConfig = models.ImportConfig{
+	MaxFileSize:       1 << 20,
+	SyncRowLimit:      10,
+	MaxReportedErrors: 10,
+}
+
+func newImportRow(line int, orderNumber string) imports.Row {
+	return imports.Row{Line: line, Request: request.CreateOrderRequest{
+		OrderNumber:  orderNumber,
+		FirstName:    "Ahmet",
+		LastName:     "Ata",
+		TotalAmount:  10,
+		Address:      "Lorem ipsum",
+		City:         "İstanbul",
+		District:     "Silivri",
+		CurrencyCode: "TRY",
+	}}
+}
+
+func getImportRows() []imports.Row {
+	invalidRow := newImportRow(3, "2")
+	invalidRow.Request.FirstName = " "
+	return []imports.Row{newImportRow(2, "1"), invalidRow, newImportRow(4, "1"), newImportRow(5, "3")}
+}
+
+func TestImportOrders_WhenDryRun_ChecksRowsWithoutCreatingOrders(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	existingOrder := response.NewErrorBuilder().
+		SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
+		Build()
+	mockOrderService.On("CheckOrder", mock.Anything, mock.MatchedBy(func(createOrderRequest request.CreateOrderRequest) bool {
+		return createOrderRequest.OrderNumber == "1"
+	})).Return(nil)
+	mockOrderService.On("CheckOrder", mock.Anything, mock.MatchedBy(func(createOrderRequest request.CreateOrderRequest) bool {
+		return createOrderRequest.OrderNumber == "3"
+	})).Return(&existingOrder)
+	service := NewImportService(mockOrderService, repositories.NewImportJobRepository(), testImportConfig)
+
+	//When
+	importJob, err := service.ImportOrders(context.Background(), getImportRows(), imports.CsvFormat, true)
+
+	//Then
+	require.Nil(t, err)
+	assert.Equal(t, string(enum.CompletedImportStatus), importJob.Status)
+	assert.True(t, importJob.DryRun)
+	assert.Equal(t, 4, importJob.ProcessedRows)
+	assert.Equal(t, 1, importJob.ImportedRows)
+	assert.Equal(t, 3, importJob.FailedRows)
+	assert.Equal(t, []response.ImportRowError{
+		{Row: 3, OrderNumber: "2", Error: response.NewErrorBuilder().SetError(http.StatusBadRequest, constants.FirstNameIsNotValid).Build()},
+		{Row: 4, OrderNumber: "1", Error: response.NewErrorBuilder().SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).Build()},
+		{Row: 5, OrderNumber: "3", Error: existingOrder},
+	}, importJob.Errors)
+	assert.NotNil(t, importJob.CompletedAt)
+	mockOrderService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestImportOrders_CreatesValidRowsAndReportsRejectedOnes(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	conflict := response.NewErrorBuilder().
+		SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
+		Build()
+	mockOrderService.On("CreateOrder", mock.Anything, newImportRow(2, "1").Request).Return(nil)
+	mockOrderService.On("CreateOrder", mock.Anything, newImportRow(5, "3").Request).Return(&conflict)
+	service := NewImportService(mockOrderService, repositories.NewImportJobRepository(), testImportConfig)
+
+	//When
+	importJob, err := service.ImportOrders(context.Background(), getImportRows(), imports.CsvFormat, false)
+
+	//Then
+	require.Nil(t, err)
+	assert.Equal(t, 1, importJob.ImportedRows)
+	assert.Equal(t, 3, importJob.FailedRows)
+	assert.Equal(t, 5, importJob.Errors[2].Row)
+	assert.Equal(t, conflict, importJob.Errors[2].Error)
+	mockOrderService.AssertNumberOfCalls(t, "CreateOrder", 2)
+}
+
+func TestImportOrders_WhenRowsExceedSyncLimit_RunsInBackground(t *testing.T) {
+	//Given
+	mockOrderService := &mocks.MockOrderService{}
+	mockOrderService.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	config := testImportConfig
+	config.SyncRowLimit = 1
+	config.MaxReportedErrors = 1
+	service := NewImportService(mockOrderService, repositories.NewImportJobRepository(), config)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{Subject: "merchant-1", Roles: []string{policies.AdminRole}})
+
+	//When
+	importJob, err := service.ImportOrders(ctx, getImportRows(), imports.NdjsonFormat, false)
+
+	//Then
+	require.Nil(t, err)
+	assert.Equal(t, string(enum.RunningImportStatus), importJob.Status)
+	assert.Equal(t, 4, importJob.TotalRows)
+	var finishedJob *response.ImportJob
+	assert.Eventually(t, func() bool {
+		finishedJob, _ = service.GetImportJob(ctx, importJob.Id)
+		return finishedJob.Status != string(enum.RunningImportStatus)
+	}, 5*time.Second, time.Millisecond)
+	service.Close()
+	assert.Equal(t, string(enum.CompletedImportStatus), finishedJob.Status)
+	assert.Equal(t, "merchant-1", finishedJob.CreatedBy)
+	assert.Equal(t, 2, finishedJob.ImportedRows)
+	assert.Equal(t, 2, finishedJob.FailedRows)
+	assert.Len(t, finishedJob.Errors, 1)
+	mockOrderService.AssertCalled(t, "CreateOrder", mock.MatchedBy(func(ctx context.Context) bool {
+		return helpers.GetPrincipal(ctx).Subject == "merchant-1"
+	}), mock.Anything)
+}
+
+func TestGetImportJob_WhenJobBelongsToAnotherCaller_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewImportService(&mocks.MockOrderService{}, repositories.NewImportJobRepository(), testImportConfig)
+	ownerCtx := helpers.WithPrincipal(context.Background(), &models.Principal{Subject: "customer-1", Roles: []string{policies.CustomerRole}})
+	otherCtx := helpers.WithPrincipal(context.Background(), &models.Principal{Subject: "customer-2", Roles: []string{policies.CustomerRole}})
+	importJob, _ := service.ImportOrders(ownerCtx, []imports.Row{}, imports.CsvFormat, true)
+
+	//When
+	ownJob, ownErr := service.GetImportJob(ownerCtx, importJob.Id)
+	_, otherErr := service.GetImportJob(otherCtx, importJob.Id)
+
+	//Then
+	require.Nil(t, ownErr)
+	assert.Equal(t, importJob.Id, ownJob.Id)
+	assert.Equal(t, http.StatusNotFound, otherErr.StatusCode)
+	assert.Equal(t, constants.ImportJobNotFound, otherErr.Message)
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"strings"
+)
+
+//go:generate mockery --name=InventoryService --structname=MockInventoryService --output=../mocks --filename=fakeInventoryServiceWithMockery.go
+type InventoryService interface {
+	GetStockLevels(ctx context.Context, sku string) ([]response.StockLevel, *response.ErrorResponse)
+	// SetStockLevel refuses to set fewer units on hand than are reserved, which would
+	// leave orders without stock.
+	SetStockLevel(ctx context.Context, sku string, warehouseId string, stockLevelRequest request.StockLevelRequest) (*response.StockLevel, *response.ErrorResponse)
+	GetReservation(ctx context.Context, orderNumber string) ([]response.ReservationLine, *response.ErrorResponse)
+}
+
+type InventoryServiceImp struct {
+	inventoryRepository repositories.InventoryRepository
+	productRepository   repositories.ProductRepository
+}
+
+func (i *InventoryServiceImp) GetStockLevels(ctx context.Context, sku string) (_ []response.StockLevel, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryService.GetStockLevels")
+	span.SetAttributes(attribute.String(constants.Sku, sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return i.inventoryRepository.FetchStockLevels(ctx, strings.ToUpper(strings.TrimSpace(sku)))
+}
+
+func (i *InventoryServiceImp) SetStockLevel(ctx context.Context, sku string, warehouseId string, stockLevelRequest request.StockLevelRequest) (_ *response.StockLevel, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryService.SetStockLevel")
+	span.SetAttributes(attribute.String(constants.Sku, sku), attribute.String(constants.WarehouseId, warehouseId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	product, errorResp := i.productRepository.FetchProductBySku(ctx, strings.ToUpper(sku))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if product == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ProductNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return i.inventoryRepository.SaveStockLevel(ctx, product.Sku, strings.ToUpper(warehouseId), stockLevelRequest.OnHand)
+}
+
+func (i *InventoryServiceImp) GetReservation(ctx context.Context, orderNumber string) (_ []response.ReservationLine, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "InventoryService.GetReservation")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	lines, errorResp := i.inventoryRepository.FetchReservation(ctx, orderNumber)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if lines == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ReservationNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return lines, nil
+}
+
+func NewInventoryService(inventoryRepository repositories.InventoryRepository, productRepository repositories.ProductRepository) InventoryService {
+	return &InventoryServiceImp{
+		inventoryRepository: inventoryRepository,
+		productRepository:   productRepository,
+	}
+}
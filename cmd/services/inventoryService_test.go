@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"testing"
+)
+
+func TestGetStockLevels_ReturnsStockOfSkuPerWarehouse(t *testing.T) {
+	//Given
+	service := NewInventoryService(repositories.NewInventoryRepository(), repositories.NewProductRepository())
+
+	//When
+	stockLevels, err := service.GetStockLevels(context.Background(), "tshirt-blk-m")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.StockLevel{
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "BER-1", OnHand: 20, Available: 20},
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "IST-1", OnHand: 50, Available: 50},
+	}, stockLevels)
+}
+
+func TestSetStockLevel(t *testing.T) {
+	//Given
+	service := NewInventoryService(repositories.NewInventoryRepository(), repositories.NewProductRepository())
+
+	//When
+	stockLevel, err := service.SetStockLevel(context.Background(), "mug-wht", "ams-1", request.StockLevelRequest{OnHand: 7})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, &response.StockLevel{Sku: "MUG-WHT", WarehouseId: "AMS-1", OnHand: 7, Available: 7}, stockLevel)
+}
+
+func TestSetStockLevel_WhenProductDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewInventoryService(repositories.NewInventoryRepository(), repositories.NewProductRepository())
+
+	//When
+	stockLevel, err := service.SetStockLevel(context.Background(), "NOPE-1", "BER-1", request.StockLevelRequest{OnHand: 7})
+
+	//Then
+	assert.Nil(t, stockLevel)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.ProductNotFound, err.Message)
+}
+
+func TestSetStockLevel_WhenBelowReservations_ReturnsConflict(t *testing.T) {
+	//Given
+	inventoryRepository := repositories.NewInventoryRepository()
+	_ = inventoryRepository.ReserveStock(context.Background(), "100", []response.OrderItem{{Sku: "MUG-WHT", Quantity: 6}})
+	service := NewInventoryService(inventoryRepository, repositories.NewProductRepository())
+
+	//When
+	stockLevel, err := service.SetStockLevel(context.Background(), "MUG-WHT", "IST-1", request.StockLevelRequest{OnHand: 5})
+
+	//Then
+	assert.Nil(t, stockLevel)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.StockLevelIsBelowReservations, err.Message)
+}
+
+func TestGetReservation_WhenOrderHasNone_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewInventoryService(repositories.NewInventoryRepository(), repositories.NewProductRepository())
+
+	//When
+	lines, err := service.GetReservation(context.Background(), "100")
+
+	//Then
+	assert.Nil(t, lines)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.ReservationNotFound, err.Message)
+}
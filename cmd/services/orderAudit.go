@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"time"
+)
+
+const anonymousActor = "anonymous"
+
+// recordAudit stores who changed the order, within which request, and the value of
+// every changed field before and after the change. before is nil for a creation and
+// after is nil for a deletion. The change is already committed and published, so a
+// failure is recorded on the span instead of failing the request.
+func (o OrderServiceImp) recordAudit(ctx context.Context, orderNumber string, action enum.AuditAction, before, after *response.Order) {
+	auditEntry := response.AuditEntry{
+		OrderNumber: orderNumber,
+		Action:      string(action),
+		Actor:       getActor(ctx),
+		RequestId:   helpers.GetRequestId(ctx),
+		Timestamp:   time.Now().UTC(),
+		Changes:     diffOrders(before, after),
+	}
+	helpers.RecordError(ctx, o.auditRepository.SaveAuditEntry(helpers.DetachedContext(ctx), auditEntry))
+}
+
+func getActor(ctx context.Context) string {
+	principal := helpers.GetPrincipal(ctx)
+	if principal == nil {
+		return anonymousActor
+	}
+
+	return principal.Type + ":" + principal.Subject
+}
+
+// diffOrders compares the orders field by field, naming fields as they are serialized.
+func diffOrders(before, after *response.Order) []response.FieldChange {
+	beforeValue := reflect.ValueOf(response.Order{})
+	if before != nil {
+		beforeValue = reflect.ValueOf(*before)
+	}
+	afterValue := reflect.ValueOf(response.Order{})
+	if after != nil {
+		afterValue = reflect.ValueOf(*after)
+	}
+
+	changes := make([]response.FieldChange, 0)
+	orderType := beforeValue.Type()
+	for i := 0; i < orderType.NumField(); i++ {
+		if jsonFieldName(orderType.Field(i)) == "-" {
+			continue
+		}
+
+		beforeField := beforeValue.Field(i).Interface()
+		afterField := afterValue.Field(i).Interface()
+		if reflect.DeepEqual(beforeField, afterField) {
+			continue
+		}
+
+		change := response.FieldChange{Field: jsonFieldName(orderType.Field(i))}
+		if before != nil {
+			change.Before = beforeField
+		}
+		if after != nil {
+			change.After = afterField
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if len(name) == 0 {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"strings"
+	"time"
+)
+
+// discountOrder applies the running automatic promotions and the coupon of a new order
+// to the subtotal of its priced items. Automatic promotions the order does not qualify
+// for are left out, while a coupon that can not be applied refuses the order.
+func (o OrderServiceImp) discountOrder(ctx context.Context, createOrderRequest *request.CreateOrderRequest) *response.ErrorResponse {
+	currencyCode := strings.ToUpper(strings.TrimSpace(createOrderRequest.CurrencyCode))
+	subtotal := createOrderRequest.TotalAmount
+	now := time.Now()
+
+	promotions, errorResp := o.promotionRepository.FetchPromotions(ctx)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	appliedPromotions := make([]response.Promotion, 0, 1)
+	for _, promotion := range promotions {
+		if !promotion.Automatic || !promotion.IsRunningAt(now) || len(inapplicability(promotion, currencyCode, subtotal)) > 0 {
+			continue
+		}
+
+		usable, errorResp := o.isUsable(ctx, promotion, createOrderRequest.CustomerId)
+		if errorResp != nil {
+			return errorResp
+		}
+
+		if usable {
+			appliedPromotions = append(appliedPromotions, promotion)
+		}
+	}
+
+	if len(createOrderRequest.CouponCode) > 0 {
+		coupon, errorResp := o.checkCoupon(ctx, createOrderRequest.CouponCode, currencyCode, createOrderRequest.CustomerId, subtotal, now)
+		if errorResp != nil {
+			return errorResp
+		}
+		appliedPromotions = append(appliedPromotions, *coupon)
+		createOrderRequest.CouponCode = coupon.Code
+	}
+
+	createOrderRequest.SubtotalAmount = subtotal
+	createOrderRequest.Discounts, createOrderRequest.TotalAmount = discount(appliedPromotions, subtotal)
+	return nil
+}
+
+// checkCoupon returns the promotion of a coupon code if the order may use it.
+func (o OrderServiceImp) checkCoupon(ctx context.Context, couponCode, currencyCode, customerId string, subtotal float32, now time.Time) (*response.Promotion, *response.ErrorResponse) {
+	coupon, errorResp := o.promotionRepository.FetchPromotionByCode(ctx, request.NormalizePromotionCode(couponCode))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if coupon == nil || coupon.Automatic {
+		return nil, pricingError(constants.CouponNotFound)
+	}
+
+	if !coupon.IsRunningAt(now) {
+		return nil, pricingError(constants.CouponIsNotActive)
+	}
+
+	if reason := inapplicability(*coupon, currencyCode, subtotal); len(reason) > 0 {
+		return nil, pricingError(reason)
+	}
+
+	if coupon.UsageLimitPerCustomer > 0 && len(customerId) == 0 {
+		return nil, pricingError(constants.CouponRequiresCustomer)
+	}
+
+	usable, errorResp := o.isUsable(ctx, *coupon, customerId)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if !usable {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.PromotionUsageLimitIsReached).
+			Build()
+		return nil, &errorResp
+	}
+
+	return coupon, nil
+}
+
+// redeemPromotions records the uses of the promotions of a new order. An automatic
+// promotion whose limit a concurrent order used up since the order was priced is left
+// out and the order discounted again without it, while a used up coupon refuses the order.
+func (o OrderServiceImp) redeemPromotions(ctx context.Context, createOrderRequest *request.CreateOrderRequest) *response.ErrorResponse {
+	for {
+		errorResp := o.promotionRepository.RedeemPromotions(ctx, createOrderRequest.OrderNumber, createOrderRequest.CustomerId, promotionCodes(createOrderRequest.Discounts))
+		if errorResp == nil || errorResp.StatusCode != http.StatusConflict {
+			return errorResp
+		}
+
+		dropped, dropErr := o.dropUsedUpPromotions(ctx, createOrderRequest)
+		if dropErr != nil {
+			return dropErr
+		}
+
+		if !dropped {
+			return errorResp
+		}
+	}
+}
+
+// dropUsedUpPromotions discounts the order again without the automatic promotions that
+// have no use left and reports whether there were any.
+func (o OrderServiceImp) dropUsedUpPromotions(ctx context.Context, createOrderRequest *request.CreateOrderRequest) (bool, *response.ErrorResponse) {
+	keptPromotions := make([]response.Promotion, 0, len(createOrderRequest.Discounts))
+	for _, orderDiscount := range createOrderRequest.Discounts {
+		promotion, errorResp := o.promotionRepository.FetchPromotionByCode(ctx, orderDiscount.PromotionCode)
+		if errorResp != nil {
+			return false, errorResp
+		}
+
+		if promotion == nil {
+			continue
+		}
+
+		if promotion.Code != createOrderRequest.CouponCode {
+			usable, errorResp := o.isUsable(ctx, *promotion, createOrderRequest.CustomerId)
+			if errorResp != nil {
+				return false, errorResp
+			}
+
+			if !usable {
+				continue
+			}
+		}
+		keptPromotions = append(keptPromotions, *promotion)
+	}
+
+	if len(keptPromotions) == len(createOrderRequest.Discounts) {
+		return false, nil
+	}
+
+	createOrderRequest.Discounts, createOrderRequest.TotalAmount = discount(keptPromotions, createOrderRequest.SubtotalAmount)
+	return true, nil
+}
+
+// isUsable reports whether the usage limits of the promotion leave a use for the
+// customer. Limits per customer can only be counted for orders with a customer.
+func (o OrderServiceImp) isUsable(ctx context.Context, promotion response.Promotion, customerId string) (bool, *response.ErrorResponse) {
+	if promotion.UsageLimitPerCustomer > 0 && len(customerId) == 0 {
+		return false, nil
+	}
+
+	total, ofCustomer, errorResp := o.promotionRepository.CountRedemptions(ctx, promotion.Code, customerId)
+	if errorResp != nil {
+		return false, errorResp
+	}
+
+	return (promotion.UsageLimit == 0 || total < promotion.UsageLimit) &&
+		(promotion.UsageLimitPerCustomer == 0 || ofCustomer < promotion.UsageLimitPerCustomer), nil
+}
+
+// rediscount applies the promotions an order already uses to the new subtotal of its
+// items. They were redeemed when the order was placed, so only those the order no longer
+// qualifies for are dropped; the coupon code is returned while its discount is kept.
+func (o OrderServiceImp) rediscount(ctx context.Context, order response.Order, currencyCode string, subtotal float32) ([]response.OrderDiscount, float32, string, *response.ErrorResponse) {
+	currencyCode = strings.ToUpper(strings.TrimSpace(currencyCode))
+	keptPromotions := make([]response.Promotion, 0, len(order.Discounts))
+	couponCode := ""
+	for _, orderDiscount := range order.Discounts {
+		promotion, errorResp := o.promotionRepository.FetchPromotionByCode(ctx, orderDiscount.PromotionCode)
+		if errorResp != nil {
+			return nil, 0, "", errorResp
+		}
+
+		if promotion == nil || len(inapplicability(*promotion, currencyCode, subtotal)) > 0 {
+			continue
+		}
+
+		keptPromotions = append(keptPromotions, *promotion)
+		if promotion.Code == order.CouponCode {
+			couponCode = order.CouponCode
+		}
+	}
+
+	discounts, totalAmount := discount(keptPromotions, subtotal)
+	return discounts, totalAmount, couponCode, nil
+}
+
+// inapplicability returns why the promotion does not apply to a subtotal in the currency,
+// or nothing when it applies.
+func inapplicability(promotion response.Promotion, currencyCode string, subtotal float32) string {
+	if len(promotion.CurrencyCode) > 0 && promotion.CurrencyCode != currencyCode {
+		return constants.CouponIsNotApplicableToCurrency
+	}
+
+	if subtotal < promotion.MinBasketAmount {
+		return constants.CouponMinimumBasketIsNotReached
+	}
+
+	return ""
+}
+
+// discount takes the promotions off the subtotal in the order given and returns their
+// discounts with the total left. Percentages are of the subtotal, and the discounts
+// together never exceed it.
+func discount(promotions []response.Promotion, subtotal float32) ([]response.OrderDiscount, float32) {
+	var discounts []response.OrderDiscount
+	remaining := float64(subtotal)
+	for _, promotion := range promotions {
+		amount := float64(promotion.Value)
+		if enum.PromotionType(promotion.Type) == enum.PercentagePromotion {
+			amount = roundToCents(float64(subtotal) * float64(promotion.Value) / 100)
+		}
+		amount = math.Min(amount, remaining)
+		remaining = roundToCents(remaining - amount)
+		discounts = append(discounts, response.OrderDiscount{
+			PromotionCode: promotion.Code,
+			Name:          promotion.Name,
+			Amount:        float32(amount),
+		})
+	}
+
+	return discounts, float32(remaining)
+}
+
+func promotionCodes(discounts []response.OrderDiscount) []string {
+	codes := make([]string, 0, len(discounts))
+	for _, orderDiscount := range discounts {
+		codes = append(codes, orderDiscount.PromotionCode)
+	}
+	return codes
+}
+
+// droppedPromotionCodes returns the codes of the previous discounts missing from the
+// current ones.
+func droppedPromotionCodes(previous []response.OrderDiscount, current []response.OrderDiscount) []string {
+	kept := make(map[string]bool, len(current))
+	for _, orderDiscount := range current {
+		kept[orderDiscount.PromotionCode] = true
+	}
+
+	codes := make([]string, 0)
+	for _, orderDiscount := range previous {
+		if !kept[orderDiscount.PromotionCode] {
+			codes = append(codes, orderDiscount.PromotionCode)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/response"
+)
+
+// newEvent describes an order change made by the caller. The repository stores it in
+// the outbox together with the change and the outbox relay publishes it.
+func (o OrderServiceImp) newEvent(ctx context.Context, eventType enum.EventType, order response.Order) events.OrderEvent {
+	event := events.NewOrderEvent(eventType, order)
+	event.Actor = getActor(ctx)
+	event.RequestId = helpers.GetRequestId(ctx)
+	return event
+}
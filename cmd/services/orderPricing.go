@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"strings"
+)
+
+// priceItems takes the names and unit prices of the items from the product catalog, in
+// the currency of the order, and returns the priced items with their total. Amounts are
+// rounded to cents.
+func (o OrderServiceImp) priceItems(ctx context.Context, currencyCode string, items []response.OrderItem) ([]response.OrderItem, float32, *response.ErrorResponse) {
+	currencyCode = strings.ToUpper(strings.TrimSpace(currencyCode))
+	pricedItems := make([]response.OrderItem, 0, len(items))
+	totalAmount := 0.0
+	for _, item := range items {
+		product, errorResp := o.productRepository.FetchProductBySku(ctx, strings.ToUpper(strings.TrimSpace(item.Sku)))
+		if errorResp != nil {
+			return nil, 0, errorResp
+		}
+
+		if product == nil {
+			return nil, 0, pricingError(constants.ProductNotFound)
+		}
+
+		if !product.Active {
+			return nil, 0, pricingError(constants.ProductIsNotActive)
+		}
+
+		unitPrice, ok := product.PriceIn(currencyCode)
+		if !ok {
+			return nil, 0, pricingError(constants.ProductPriceIsNotAvailable)
+		}
+
+		amount := roundToCents(float64(unitPrice) * float64(item.Quantity))
+		totalAmount += amount
+		pricedItems = append(pricedItems, response.OrderItem{
+			Sku:       product.Sku,
+			Name:      product.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: unitPrice,
+			Amount:    float32(amount),
+		})
+	}
+
+	return pricedItems, float32(roundToCents(totalAmount)), nil
+}
+
+// priceUpdate prices the items of an update. Without items the order keeps its items,
+// at their prices unless the currency changes, and the total amount of the request is
+// ignored for them. Items priced again are discounted by the promotions of the order.
+// The total amount of an order without items is only changed by callers allowed to
+// enter order totals.
+func (o OrderServiceImp) priceUpdate(ctx context.Context, order response.Order, updateOrderRequest *request.UpdateOrderRequest) *response.ErrorResponse {
+	if len(updateOrderRequest.Items) == 0 {
+		if len(order.Items) == 0 {
+			if updateOrderRequest.TotalAmount != order.TotalAmount && !policies.HasPermission(helpers.GetPrincipal(ctx), policies.EnterOrderTotals) {
+				return pricingError(constants.OrderItemsAreRequired)
+			}
+			return nil
+		}
+
+		if strings.EqualFold(order.CurrencyCode, strings.TrimSpace(updateOrderRequest.CurrencyCode)) {
+			updateOrderRequest.Items = order.Items
+			updateOrderRequest.TotalAmount = order.TotalAmount
+			updateOrderRequest.SubtotalAmount = order.SubtotalAmount
+			updateOrderRequest.CouponCode = order.CouponCode
+			updateOrderRequest.Discounts = order.Discounts
+			return nil
+		}
+		updateOrderRequest.Items = order.Items
+	}
+
+	items, subtotal, errorResp := o.priceItems(ctx, updateOrderRequest.CurrencyCode, updateOrderRequest.Items)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	discounts, totalAmount, couponCode, errorResp := o.rediscount(ctx, order, updateOrderRequest.CurrencyCode, subtotal)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	updateOrderRequest.Items = items
+	updateOrderRequest.SubtotalAmount = subtotal
+	updateOrderRequest.TotalAmount = totalAmount
+	updateOrderRequest.CouponCode = couponCode
+	updateOrderRequest.Discounts = discounts
+	return nil
+}
+
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func pricingError(message string) *response.ErrorResponse {
+	errorResp := response.NewErrorBuilder().
+		SetError(http.StatusBadRequest, message).
+		Build()
+	return &errorResp
+}
@@ -1,54 +1,316 @@
 package services
 
 import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"net/http"
+	"reflect"
 	"simple-order-api/cmd/constants"
 	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/helpers"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
 	"simple-order-api/cmd/repositories"
+	"strings"
+	"time"
 )
 
+var tracer = otel.Tracer("simple-order-api/cmd/services")
+
 //go:generate mockery --name=OrderService --structname=MockOrderService --output=../mocks --filename=fakeOrderServiceWithMockery.go
 type OrderService interface {
-	GetOrders() ([]response.Order, *response.ErrorResponse)
-	GetOrder(orderNumber string) (*response.Order, *response.ErrorResponse)
-	CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse
-	UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse
-	DeleteOrder(orderNumber string) *response.ErrorResponse
+	GetOrders(ctx context.Context, filter request.OrderFilter) ([]response.Order, *response.ErrorResponse)
+	// StreamOrders calls visit with the orders GetOrders would return, one at a time as
+	// they are read, until visit returns false.
+	StreamOrders(ctx context.Context, filter request.OrderFilter, visit func(order response.Order) bool) *response.ErrorResponse
+	GetOrder(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse)
+	CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse
+	// CheckOrder runs the checks of CreateOrder against the current orders, customers,
+	// catalog, stock and promotions without storing or reserving anything.
+	CheckOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) *response.ErrorResponse
+	UpdateOrder(ctx context.Context, orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse
+	DeleteOrder(ctx context.Context, orderNumber string) *response.ErrorResponse
+	TransitionOrder(ctx context.Context, orderNumber string, transitionOrderRequest request.TransitionOrderRequest) *response.ErrorResponse
+	GetOrderAudit(ctx context.Context, orderNumber string) ([]response.AuditEntry, *response.ErrorResponse)
 }
 
 type OrderServiceImp struct {
-	orderRepository repositories.OrderRepository
+	orderRepository     repositories.OrderRepository
+	auditRepository     repositories.AuditRepository
+	customerRepository  repositories.CustomerRepository
+	geoService          GeoService
+	productRepository   repositories.ProductRepository
+	inventoryRepository repositories.InventoryRepository
+	promotionRepository repositories.PromotionRepository
+}
+
+func (o OrderServiceImp) GetOrders(ctx context.Context, filter request.OrderFilter) (orders []response.Order, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrders")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	orders, errorResp = o.orderRepository.FetchOrders(ctx)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	principal := helpers.GetPrincipal(ctx)
+	if !policies.HasPermission(principal, policies.ReadAllOrders) {
+		filter.CustomerId = principal.Subject
+	}
+	return filter.Apply(orders), nil
 }
 
-func (o OrderServiceImp) GetOrders() ([]response.Order, *response.ErrorResponse) {
-	return o.orderRepository.FetchOrders()
+func (o OrderServiceImp) StreamOrders(ctx context.Context, filter request.OrderFilter, visit func(order response.Order) bool) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.StreamOrders")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	principal := helpers.GetPrincipal(ctx)
+	if !policies.HasPermission(principal, policies.ReadAllOrders) {
+		filter.CustomerId = principal.Subject
+	}
+	return o.orderRepository.IterateOrders(ctx, func(order response.Order) bool {
+		return !filter.Matches(order) || visit(order)
+	})
 }
 
-func (o OrderServiceImp) GetOrder(orderNumber string) (*response.Order, *response.ErrorResponse) {
-	order, err := o.orderRepository.FetchOrderByOrderNumber(orderNumber)
-	return order, err
+func (o OrderServiceImp) GetOrder(ctx context.Context, orderNumber string) (order *response.Order, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	order, errorResp = o.orderRepository.FetchOrderByOrderNumber(ctx, orderNumber)
+	if errorResp != nil || order == nil {
+		return order, errorResp
+	}
+
+	if !policies.CanAccessOrder(helpers.GetPrincipal(ctx), order.CustomerId) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusForbidden, constants.OrderAccessDenied).
+			Build()
+		return nil, &errorResp
+	}
+
+	return order, nil
 }
 
-func (o OrderServiceImp) CreateOrder(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
-	order, errorResp := o.GetOrder(createOrderRequest.OrderNumber)
+func (o OrderServiceImp) CreateOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.CreateOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, createOrderRequest.OrderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	customer, isNewCustomer, errorResp := o.prepareOrder(ctx, &createOrderRequest)
 	if errorResp != nil {
 		return errorResp
 	}
 
+	if isNewCustomer {
+		if errorResp := o.customerRepository.CreateCustomer(ctx, *customer); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	if errorResp := o.inventoryRepository.ReserveStock(ctx, createOrderRequest.OrderNumber, createOrderRequest.Items); errorResp != nil {
+		return o.discardCustomer(ctx, customer, isNewCustomer, errorResp)
+	}
+
+	if errorResp := o.redeemPromotions(ctx, &createOrderRequest); errorResp != nil {
+		o.releaseStock(ctx, createOrderRequest.OrderNumber)
+		return o.discardCustomer(ctx, customer, isNewCustomer, errorResp)
+	}
+
+	createdOrder := createOrderRequest.Order()
+	event := o.newEvent(ctx, enum.OrderCreated, createdOrder)
+	if errorResp := o.orderRepository.CreateOrder(ctx, createOrderRequest, event); errorResp != nil {
+		o.releasePromotions(ctx, createOrderRequest.OrderNumber, promotionCodes(createOrderRequest.Discounts))
+		o.releaseStock(ctx, createOrderRequest.OrderNumber)
+		return o.discardCustomer(ctx, customer, isNewCustomer, errorResp)
+	}
+
+	o.recordAudit(ctx, createOrderRequest.OrderNumber, enum.CreateAction, nil, &createdOrder)
+	return nil
+}
+
+func (o OrderServiceImp) CheckOrder(ctx context.Context, createOrderRequest request.CreateOrderRequest) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.CheckOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, createOrderRequest.OrderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if _, _, errorResp := o.prepareOrder(ctx, &createOrderRequest); errorResp != nil {
+		return errorResp
+	}
+
+	return o.inventoryRepository.CheckStock(ctx, createOrderRequest.Items)
+}
+
+// prepareOrder checks a new order and completes it with its prices, discounts and
+// customer. The inline customer of the request is returned but not yet stored.
+func (o OrderServiceImp) prepareOrder(ctx context.Context, createOrderRequest *request.CreateOrderRequest) (customer *response.Customer, isNew bool, errorResp *response.ErrorResponse) {
+	order, errorResp := o.orderRepository.FetchOrderByOrderNumber(ctx, createOrderRequest.OrderNumber)
+	if errorResp != nil {
+		return nil, false, errorResp
+	}
+
 	if order != nil {
 		errorResp := response.NewErrorBuilder().
 			SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
 			Build()
+		return nil, false, &errorResp
+	}
+
+	if errorResp := o.checkAddresses(ctx, createOrderRequest.Shipping(), createOrderRequest.BillingAddress); errorResp != nil {
+		return nil, false, errorResp
+	}
+
+	principal := helpers.GetPrincipal(ctx)
+	if len(createOrderRequest.Items) > 0 {
+		items, totalAmount, errorResp := o.priceItems(ctx, createOrderRequest.CurrencyCode, createOrderRequest.Items)
+		if errorResp != nil {
+			return nil, false, errorResp
+		}
+		createOrderRequest.Items, createOrderRequest.TotalAmount = items, totalAmount
+	} else if !policies.HasPermission(principal, policies.EnterOrderTotals) {
+		return nil, false, pricingError(constants.OrderItemsAreRequired)
+	}
+
+	if !policies.HasPermission(principal, policies.ReadAllOrders) {
+		createOrderRequest.CustomerId = principal.Subject
+	}
+
+	customer, isNew, errorResp = o.resolveCustomer(ctx, createOrderRequest)
+	if errorResp != nil {
+		return nil, false, errorResp
+	}
+
+	if customer != nil {
+		createOrderRequest.FirstName = defaultString(createOrderRequest.FirstName, customer.FirstName)
+		createOrderRequest.LastName = defaultString(createOrderRequest.LastName, customer.LastName)
+	} else if len(createOrderRequest.CustomerId) > 0 {
+		if errorResp := checkNames(*createOrderRequest); errorResp != nil {
+			return nil, false, errorResp
+		}
+	}
+
+	if len(createOrderRequest.Items) > 0 {
+		if errorResp := o.discountOrder(ctx, createOrderRequest); errorResp != nil {
+			return nil, false, errorResp
+		}
+	}
+
+	return customer, isNew, nil
+}
+
+// resolveCustomer builds the inline customer of the request or looks up the referenced
+// one. Customer principals may order without a customer record of their own.
+func (o OrderServiceImp) resolveCustomer(ctx context.Context, createOrderRequest *request.CreateOrderRequest) (customer *response.Customer, isNew bool, errorResp *response.ErrorResponse) {
+	if createOrderRequest.Customer != nil {
+		id, errorResp := newCustomerId(helpers.GetPrincipal(ctx))
+		if errorResp != nil {
+			return nil, false, errorResp
+		}
+
+		existingCustomer, errorResp := o.customerRepository.FetchCustomerById(ctx, id)
+		if errorResp != nil {
+			return nil, false, errorResp
+		}
+
+		if existingCustomer != nil {
+			errorResp := response.NewErrorBuilder().
+				SetError(http.StatusConflict, constants.SameCustomerFoundByUniqueId).
+				Build()
+			return nil, false, &errorResp
+		}
+
+		customer := newCustomer(id, *createOrderRequest.Customer, time.Now())
+		createOrderRequest.CustomerId = id
+		return &customer, true, nil
+	}
+
+	if len(createOrderRequest.CustomerId) == 0 {
+		return nil, false, nil
+	}
+
+	customer, errorResp = o.customerRepository.FetchCustomerById(ctx, createOrderRequest.CustomerId)
+	if errorResp != nil {
+		return nil, false, errorResp
+	}
+
+	if customer == nil && policies.HasPermission(helpers.GetPrincipal(ctx), policies.ReadAllOrders) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.CustomerNotFound).
+			Build()
+		return nil, false, &errorResp
+	}
+
+	return customer, false, nil
+}
+
+// checkNames rejects orders whose names were left to a customer without a record.
+func checkNames(createOrderRequest request.CreateOrderRequest) *response.ErrorResponse {
+	if len(strings.TrimSpace(createOrderRequest.FirstName)) == 0 {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.FirstNameIsNotValid).
+			Build()
 		return &errorResp
 	}
 
-	return o.orderRepository.CreateOrder(createOrderRequest)
+	if len(strings.TrimSpace(createOrderRequest.LastName)) == 0 {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusBadRequest, constants.LastNameIsNotValid).
+			Build()
+		return &errorResp
+	}
+
+	return nil
 }
 
-func (o OrderServiceImp) UpdateOrder(orderNumber string, updateOrderRequest request.UpdateOrderRequest) *response.ErrorResponse {
-	order, errorResp := o.GetOrder(orderNumber)
+// discardCustomer deletes the customer created inline for an order that failed, so the
+// request can be repeated.
+func (o OrderServiceImp) discardCustomer(ctx context.Context, customer *response.Customer, created bool, errorResp *response.ErrorResponse) *response.ErrorResponse {
+	if created {
+		helpers.RecordError(ctx, o.customerRepository.DeleteCustomer(helpers.DetachedContext(ctx), customer.Id))
+	}
+	return errorResp
+}
+
+// releaseStock gives the reserved stock of an order back. Like the other steps undoing
+// or completing a change, it runs on a context that outlives the request, so that a
+// cancelled request does not keep the stock reserved, and a failure is recorded on the span.
+func (o OrderServiceImp) releaseStock(ctx context.Context, orderNumber string) {
+	helpers.RecordError(ctx, o.inventoryRepository.ReleaseReservation(helpers.DetachedContext(ctx), orderNumber))
+}
+
+// releasePromotions gives the uses of the promotions back, as releaseStock does the stock.
+func (o OrderServiceImp) releasePromotions(ctx context.Context, orderNumber string, promotionCodes []string) {
+	helpers.RecordError(ctx, o.promotionRepository.ReleaseRedemptions(helpers.DetachedContext(ctx), orderNumber, promotionCodes))
+}
+
+// checkAddresses rejects orders whose shipping or billing district lies outside its city.
+func (o OrderServiceImp) checkAddresses(ctx context.Context, shipping response.Address, billing *response.Address) *response.ErrorResponse {
+	if errorResp := o.geoService.CheckAddress(ctx, shipping); errorResp != nil {
+		return errorResp
+	}
+
+	if billing != nil {
+		return o.geoService.CheckAddress(ctx, *billing)
+	}
+	return nil
+}
+
+func defaultString(value string, defaultValue string) string {
+	if len(strings.TrimSpace(value)) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func (o OrderServiceImp) UpdateOrder(ctx context.Context, orderNumber string, updateOrderRequest request.UpdateOrderRequest) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.UpdateOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	order, errorResp := o.GetOrder(ctx, orderNumber)
 	if errorResp != nil {
 		return errorResp
 	}
@@ -69,11 +331,69 @@ func (o OrderServiceImp) UpdateOrder(orderNumber string, updateOrderRequest requ
 		return &errorResp
 	}
 
-	return o.orderRepository.UpdateOrder(orderNumber, updateOrderRequest)
+	if errorResp := o.checkAddresses(ctx, updateOrderRequest.Shipping(*order), updateOrderRequest.BillingAddress); errorResp != nil {
+		return errorResp
+	}
+
+	if errorResp := o.priceUpdate(ctx, *order, &updateOrderRequest); errorResp != nil {
+		return errorResp
+	}
+
+	itemsChanged := !reflect.DeepEqual(order.Items, updateOrderRequest.Items)
+	if itemsChanged {
+		if errorResp := o.inventoryRepository.ReplaceReservation(ctx, orderNumber, updateOrderRequest.Items); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	updatedOrder := updateOrderRequest.Apply(*order)
+	event := o.newEvent(ctx, enum.OrderUpdated, updatedOrder)
+	if errorResp := o.orderRepository.UpdateOrder(ctx, orderNumber, enum.OrderStatus(order.StatusId), updateOrderRequest, event); errorResp != nil {
+		if itemsChanged {
+			o.restoreReservation(ctx, *order)
+		}
+		return errorResp
+	}
+
+	// promotions the order no longer qualifies for give their use back
+	o.releasePromotions(ctx, orderNumber, droppedPromotionCodes(order.Discounts, updateOrderRequest.Discounts))
+
+	o.recordAudit(ctx, orderNumber, enum.UpdateAction, order, &updatedOrder)
+	return nil
 }
 
-func (o OrderServiceImp) DeleteOrder(orderNumber string) *response.ErrorResponse {
-	order, errorResp := o.GetOrder(orderNumber)
+// restoreReservation gives an order whose update failed its previous reservation back.
+// An order transferred or deleted meanwhile holds no stock any more, so the reservation
+// made for the update is released instead.
+func (o OrderServiceImp) restoreReservation(ctx context.Context, order response.Order) {
+	detachedCtx := helpers.DetachedContext(ctx)
+	currentOrder, errorResp := o.orderRepository.FetchOrderByOrderNumber(detachedCtx, order.OrderNumber)
+	if errorResp != nil {
+		helpers.RecordError(ctx, errorResp)
+		return
+	}
+
+	if currentOrder == nil || hasLeftWarehouse(currentOrder.StatusId) {
+		helpers.RecordError(ctx, o.inventoryRepository.ReleaseReservation(detachedCtx, order.OrderNumber))
+		return
+	}
+
+	helpers.RecordError(ctx, o.inventoryRepository.ReplaceReservation(detachedCtx, order.OrderNumber, order.Items))
+}
+
+// hasLeftWarehouse reports whether the stock of an order in the status was taken off the shelves.
+func hasLeftWarehouse(statusId int) bool {
+	return statusId == int(enum.Transferred) ||
+		statusId == int(enum.Shipped) ||
+		statusId == int(enum.Delivered)
+}
+
+func (o OrderServiceImp) DeleteOrder(ctx context.Context, orderNumber string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.DeleteOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	order, errorResp := o.GetOrder(ctx, orderNumber)
 	if errorResp != nil {
 		return errorResp
 	}
@@ -94,12 +414,109 @@ func (o OrderServiceImp) DeleteOrder(orderNumber string) *response.ErrorResponse
 		return &errorResp
 	}
 
-	deleteErr := o.orderRepository.DeleteOrder(orderNumber)
-	return deleteErr
+	deleteErr := o.orderRepository.DeleteOrder(ctx, orderNumber, o.newEvent(ctx, enum.OrderDeleted, *order))
+	if deleteErr != nil {
+		return deleteErr
+	}
+
+	// deleting is how orders are cancelled, which returns their stock and coupon uses
+	o.releaseStock(ctx, orderNumber)
+	o.releasePromotions(ctx, orderNumber, promotionCodes(order.Discounts))
+
+	o.recordAudit(ctx, orderNumber, enum.DeleteAction, order, nil)
+	return nil
+}
+
+func (o OrderServiceImp) TransitionOrder(ctx context.Context, orderNumber string, transitionOrderRequest request.TransitionOrderRequest) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.TransitionOrder")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	order, errorResp := o.GetOrder(ctx, orderNumber)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	if order == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
+			Build()
+		return &errorResp
+	}
+
+	nextStatus := enum.OrderStatus(transitionOrderRequest.StatusId)
+	if !enum.OrderStatus(order.StatusId).CanTransitionTo(nextStatus) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusConflict, constants.OrderStatusTransitionIsNotValid).
+			Build()
+		return &errorResp
+	}
+
+	// transferred orders leave the warehouse, so their reserved stock is no longer on hand.
+	// The stock is taken first, since a transferred order can not be transferred again,
+	// while committing a reservation a second time changes nothing.
+	if nextStatus == enum.Transferred {
+		if errorResp := o.inventoryRepository.CommitReservation(ctx, orderNumber); errorResp != nil {
+			return errorResp
+		}
+	}
+
+	transitionedOrder := *order
+	transitionedOrder.StatusId = int(nextStatus)
+	event := o.newEvent(ctx, enum.OrderStatusChanged, transitionedOrder)
+	event.PreviousStatusId = order.StatusId
+	if errorResp := o.orderRepository.UpdateOrderStatus(ctx, orderNumber, nextStatus, event); errorResp != nil {
+		return errorResp
+	}
+
+	o.recordAudit(ctx, orderNumber, enum.TransitionAction, order, &transitionedOrder)
+	return nil
+}
+
+func (o OrderServiceImp) GetOrderAudit(ctx context.Context, orderNumber string) (auditEntries []response.AuditEntry, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrderAudit")
+	span.SetAttributes(attribute.String(constants.OrderNumber, orderNumber))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	order, errorResp := o.orderRepository.FetchOrderByOrderNumber(ctx, orderNumber)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	// The audit log of a deleted order stays readable for those who may read every order.
+	principal := helpers.GetPrincipal(ctx)
+	if order == nil && !policies.HasPermission(principal, policies.ReadAllOrders) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.OrderNotFoundByOrderNumber).
+			Build()
+		return nil, &errorResp
+	}
+	if order != nil && !policies.CanAccessOrder(principal, order.CustomerId) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusForbidden, constants.OrderAccessDenied).
+			Build()
+		return nil, &errorResp
+	}
+
+	return o.auditRepository.FetchAuditEntries(ctx, orderNumber)
 }
 
-func NewOrderService(orderRepository repositories.OrderRepository) OrderService {
+func NewOrderService(
+	orderRepository repositories.OrderRepository,
+	auditRepository repositories.AuditRepository,
+	customerRepository repositories.CustomerRepository,
+	geoService GeoService,
+	productRepository repositories.ProductRepository,
+	inventoryRepository repositories.InventoryRepository,
+	promotionRepository repositories.PromotionRepository,
+) OrderService {
 	return &OrderServiceImp{
-		orderRepository: orderRepository,
+		orderRepository:     orderRepository,
+		auditRepository:     auditRepository,
+		customerRepository:  customerRepository,
+		geoService:          geoService,
+		productRepository:   productRepository,
+		inventoryRepository: inventoryRepository,
+		promotionRepository: promotionRepository,
 	}
 }
@@ -1,19 +1,57 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"net/http"
 	"simple-order-api/cmd/constants"
 	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
 	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
 	"simple-order-api/cmd/models/request"
 	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"sync"
 	"testing"
+	"time"
 )
 
+// newTestOrderService builds the order service on the order repository, the other given
+// repositories, and in-memory ones for the rest.
+func newTestOrderService(orderRepository repositories.OrderRepository, dependencies ...interface{}) OrderService {
+	auditRepository := repositories.NewAuditRepository()
+	customerRepository := repositories.NewCustomerRepository()
+	inventoryRepository := repositories.NewInventoryRepository()
+	promotionRepository := repositories.NewPromotionRepository()
+	for _, dependency := range dependencies {
+		switch dependency := dependency.(type) {
+		case repositories.AuditRepository:
+			auditRepository = dependency
+		case repositories.CustomerRepository:
+			customerRepository = dependency
+		case repositories.InventoryRepository:
+			inventoryRepository = dependency
+		case repositories.PromotionRepository:
+			promotionRepository = dependency
+		default:
+			panic(fmt.Sprintf("order service does not depend on %T", dependency))
+		}
+	}
+
+	return NewOrderService(orderRepository, auditRepository, customerRepository, NewGeoService(repositories.NewGeoRepository()),
+		repositories.NewProductRepository(), inventoryRepository, promotionRepository)
+}
+
 func TestGetOrders(t *testing.T) {
 	//Given
 	mockOrderRepository := &mocks.MockOrderRepository{}
@@ -30,18 +68,18 @@ func TestGetOrders(t *testing.T) {
 			CurrencyCode: "TR",
 		},
 	}
-	mockOrderRepository.On("FetchOrders").Return(orders, nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(orders, nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	resp, err := service.GetOrders()
+	resp, err := service.GetOrders(context.Background(), request.OrderFilter{})
 
 	//Then
 	assert.NotNil(t, resp)
 	assert.Nil(t, err)
 	assert.Equal(t, orders, resp)
 	mockOrderRepository.AssertNumberOfCalls(t, "FetchOrders", 1)
-	mockOrderRepository.AssertCalled(t, "FetchOrders")
+	mockOrderRepository.AssertCalled(t, "FetchOrders", mock.Anything)
 }
 
 func TestGetOrders_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
@@ -50,11 +88,11 @@ func TestGetOrders_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderRepository.On("FetchOrders").Return(nil, &serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	resp, err := service.GetOrders()
+	resp, err := service.GetOrders(context.Background(), request.OrderFilter{})
 
 	//Then
 	assert.NotNil(t, err)
@@ -62,24 +100,98 @@ func TestGetOrders_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
 	assert.Equal(t, &serviceErr, err)
 }
 
+// testSpanProcessor passes spans to the recorder of the running test. The tracer of the
+// services is bound to the first global tracer provider, so the provider is set once and
+// the recorder is swapped by recordSpans.
+type testSpanProcessor struct {
+	mutex    sync.Mutex
+	recorder *tracetest.SpanRecorder
+}
+
+var (
+	testSpans        = &testSpanProcessor{}
+	testTracerSetter sync.Once
+)
+
+func (processor *testSpanProcessor) current() *tracetest.SpanRecorder {
+	processor.mutex.Lock()
+	defer processor.mutex.Unlock()
+	return processor.recorder
+}
+
+func (processor *testSpanProcessor) OnStart(parent context.Context, span sdktrace.ReadWriteSpan) {
+	if recorder := processor.current(); recorder != nil {
+		recorder.OnStart(parent, span)
+	}
+}
+
+func (processor *testSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if recorder := processor.current(); recorder != nil {
+		recorder.OnEnd(span)
+	}
+}
+
+func (processor *testSpanProcessor) Shutdown(context.Context) error { return nil }
+
+func (processor *testSpanProcessor) ForceFlush(context.Context) error { return nil }
+
+// recordSpans records the spans ended until the test finishes.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	testTracerSetter.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpans)))
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	testSpans.mutex.Lock()
+	testSpans.recorder = recorder
+	testSpans.mutex.Unlock()
+	t.Cleanup(func() {
+		testSpans.mutex.Lock()
+		testSpans.recorder = nil
+		testSpans.mutex.Unlock()
+	})
+	return recorder
+}
+
+func TestGetOrders_RecordsSpanWithErrorStatus(t *testing.T) {
+	//Given
+	spanRecorder := recordSpans(t)
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, "test").
+		Build()
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	_, _ = service.GetOrders(context.Background(), request.OrderFilter{})
+
+	//Then
+	spans := spanRecorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "OrderService.GetOrders", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "test", spans[0].Status().Description)
+}
+
 func TestGetOrder(t *testing.T) {
 	//Given
 	orderNumber := "1"
 	mockOrderRepository := &mocks.MockOrderRepository{}
 	order := response.Order{}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	resp, err := service.GetOrder(orderNumber)
+	resp, err := service.GetOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.NotNil(t, resp)
 	assert.Nil(t, err)
 	assert.Equal(t, &order, resp)
 	mockOrderRepository.AssertNumberOfCalls(t, "FetchOrderByOrderNumber", 1)
-	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", orderNumber)
+	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", mock.Anything, orderNumber)
 }
 
 func TestGetOrder_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
@@ -89,11 +201,11 @@ func TestGetOrder_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, &serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	resp, err := service.GetOrder(orderNumber)
+	resp, err := service.GetOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.NotNil(t, err)
@@ -108,19 +220,19 @@ func TestCreateOrder(t *testing.T) {
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, nil)
-	mockOrderRepository.On("CreateOrder", mock.Anything).Return(nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.CreateOrder(*serviceReq)
+	err := service.CreateOrder(context.Background(), *serviceReq)
 
 	//Then
 	assert.Nil(t, err)
 	mockOrderRepository.AssertNumberOfCalls(t, "FetchOrderByOrderNumber", 1)
-	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", orderNumber)
+	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", mock.Anything, orderNumber)
 	mockOrderRepository.AssertNumberOfCalls(t, "CreateOrder", 1)
-	mockOrderRepository.AssertCalled(t, "CreateOrder", *serviceReq)
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, *serviceReq, mock.Anything)
 }
 
 func TestCreateOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *testing.T) {
@@ -132,11 +244,11 @@ func TestCreateOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *te
 		SetError(http.StatusInternalServerError, "test").
 		Build()
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, &serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.CreateOrder(*serviceReq)
+	err := service.CreateOrder(context.Background(), *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -160,11 +272,11 @@ func TestCreateOrder_WhenOrderAlreadyExistWithSameOrderNumber_ReturnsStatusConfl
 		CurrencyCode: "TR",
 	}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.CreateOrder(*serviceReq)
+	err := service.CreateOrder(context.Background(), *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -178,15 +290,15 @@ func TestCreateOrder_WhenOrderRepositoryCreateMethodReturnsError_ReturnsError(t
 	serviceReq := &request.CreateOrderRequest{}
 	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderRepository.On("CreateOrder", mock.Anything).Return(&serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.CreateOrder(*serviceReq)
+	err := service.CreateOrder(context.Background(), *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -211,19 +323,19 @@ func TestUpdateOrder(t *testing.T) {
 		CurrencyCode: "TR",
 	}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-	mockOrderRepository.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.UpdateOrder(orderNumber, *serviceReq)
+	err := service.UpdateOrder(context.Background(), orderNumber, *serviceReq)
 
 	//Then
 	assert.Nil(t, err)
 	mockOrderRepository.AssertNumberOfCalls(t, "FetchOrderByOrderNumber", 1)
-	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", orderNumber)
+	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", mock.Anything, orderNumber)
 	mockOrderRepository.AssertNumberOfCalls(t, "UpdateOrder", 1)
-	mockOrderRepository.AssertCalled(t, "UpdateOrder", orderNumber, *serviceReq)
+	mockOrderRepository.AssertCalled(t, "UpdateOrder", mock.Anything, orderNumber, enum.Created, *serviceReq, mock.Anything)
 }
 
 func TestUpdateOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *testing.T) {
@@ -236,11 +348,11 @@ func TestUpdateOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *te
 		SetError(http.StatusInternalServerError, "test").
 		Build()
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, &serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.UpdateOrder(orderNumber, *serviceReq)
+	err := service.UpdateOrder(context.Background(), orderNumber, *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -254,11 +366,11 @@ func TestUpdateOrder_WhenOrderNotFoundInRepository_ReturnsNotFound(t *testing.T)
 	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
 	mockOrderRepository := &mocks.MockOrderRepository{}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.UpdateOrder(orderNumber, *serviceReq)
+	err := service.UpdateOrder(context.Background(), orderNumber, *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -285,11 +397,11 @@ func TestUpdateOrder_WhenStatusIsNotValidForDeletion_ReturnsError(t *testing.T)
 				CurrencyCode: "TR",
 			}
 
-			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-			service := NewOrderService(mockOrderRepository)
+			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+			service := newTestOrderService(mockOrderRepository)
 
 			//When
-			err := service.UpdateOrder(orderNumber, *serviceReq)
+			err := service.UpdateOrder(context.Background(), orderNumber, *serviceReq)
 
 			//Then
 			assert.NotNil(t, err)
@@ -317,15 +429,15 @@ func TestUpdateOrder_WhenOrderRepositoryUpdateMethodReturnsError_ReturnsError(t
 		StatusId:     int(enum.Created),
 		CurrencyCode: "TR",
 	}
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderRepository.On("UpdateOrder", mock.Anything, mock.Anything).Return(&serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.UpdateOrder(orderNumber, *serviceReq)
+	err := service.UpdateOrder(context.Background(), orderNumber, *serviceReq)
 
 	//Then
 	assert.NotNil(t, err)
@@ -348,19 +460,19 @@ func TestDeleteOrder(t *testing.T) {
 		CurrencyCode: "TR",
 	}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-	mockOrderRepository.On("DeleteOrder", mock.Anything).Return(nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.DeleteOrder(orderNumber)
+	err := service.DeleteOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.Nil(t, err)
 	mockOrderRepository.AssertNumberOfCalls(t, "FetchOrderByOrderNumber", 1)
-	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", orderNumber)
+	mockOrderRepository.AssertCalled(t, "FetchOrderByOrderNumber", mock.Anything, orderNumber)
 	mockOrderRepository.AssertNumberOfCalls(t, "DeleteOrder", 1)
-	mockOrderRepository.AssertCalled(t, "DeleteOrder", orderNumber)
+	mockOrderRepository.AssertCalled(t, "DeleteOrder", mock.Anything, orderNumber, mock.Anything)
 }
 
 func TestDeleteOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *testing.T) {
@@ -371,11 +483,11 @@ func TestDeleteOrder_WhenOrderRepositoryGetMethodReturnsError_ReturnsError(t *te
 		SetError(http.StatusInternalServerError, "test").
 		Build()
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, &serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, &serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.DeleteOrder(orderNumber)
+	err := service.DeleteOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.NotNil(t, err)
@@ -387,11 +499,11 @@ func TestDeleteOrder_WhenOrderNotFoundInRepository_ReturnsError(t *testing.T) {
 	orderNumber := "1"
 	mockOrderRepository := &mocks.MockOrderRepository{}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(nil, nil)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.DeleteOrder(orderNumber)
+	err := service.DeleteOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.NotNil(t, err)
@@ -416,11 +528,11 @@ func TestDeleteOrder_WhenStatusIsNotValidForDeletion_ReturnsError(t *testing.T)
 				CurrencyCode: "TR",
 			}
 
-			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
-			service := NewOrderService(mockOrderRepository)
+			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+			service := newTestOrderService(mockOrderRepository)
 
 			//When
-			err := service.DeleteOrder(orderNumber)
+			err := service.DeleteOrder(context.Background(), orderNumber)
 
 			//Then
 			assert.NotNil(t, err)
@@ -446,54 +558,1379 @@ func TestDeleteOrder_WhenOrderRepositoryDeleteMethodReturnsError_ReturnsError(t
 		CurrencyCode: "TR",
 	}
 
-	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
 	serviceErr := response.NewErrorBuilder().
 		SetError(http.StatusInternalServerError, "test").
 		Build()
-	mockOrderRepository.On("DeleteOrder", mock.Anything).Return(&serviceErr)
-	service := NewOrderService(mockOrderRepository)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository)
 
 	//When
-	err := service.DeleteOrder(orderNumber)
+	err := service.DeleteOrder(context.Background(), orderNumber)
 
 	//Then
 	assert.NotNil(t, err)
 	assert.Equal(t, &serviceErr, err)
 }
 
-func getCreateOrderRequest() string {
-	return `{
-  "orderNumber": "1",
-  "firstName": "Test",
-  "lastName": "Sample",
-  "totalAmount": 10.2,
-  "address": "address",
-  "city": "İstanbul",
-  "district": "Bakırköy",
-  "currencyCode": "TRY"
-}`
+func TestGetOrders_WhenPrincipalIsCustomer_ReturnsOnlyOwnOrders(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	orders := []response.Order{
+		{OrderNumber: "1", CustomerId: "customer-1"},
+		{OrderNumber: "2", CustomerId: "customer-2"},
+	}
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(orders, nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "customer-1",
+		Roles:   []string{policies.CustomerRole},
+	})
+
+	//When
+	resp, err := service.GetOrders(ctx, request.OrderFilter{})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.Order{orders[0]}, resp)
 }
 
-func getUpdateOrderRequest() string {
-	return `{
-  "firstName": "Test",
-  "lastName": "Sample",
-  "totalAmount": 10.2,
-  "address": "address",
-  "city": "İstanbul",
-  "district": "Bakırköy",
-  "currencyCode": "TRY"
-}`
+func TestGetOrders_AppliesFilterWithinTheCustomersOwnOrders(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	orders := []response.Order{
+		{OrderNumber: "1", CustomerId: "customer-1", City: "Ankara"},
+		{OrderNumber: "2", CustomerId: "customer-1", City: "İzmir"},
+		{OrderNumber: "3", CustomerId: "customer-2", City: "Ankara"},
+	}
+	mockOrderRepository.On("FetchOrders", mock.Anything).Return(orders, nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "customer-1",
+		Roles:   []string{policies.CustomerRole},
+	})
+
+	//When
+	resp, err := service.GetOrders(ctx, request.OrderFilter{City: "Ankara", CustomerId: "customer-2"})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.Order{orders[0]}, resp)
 }
 
-var statusIdsThatNotBeValidForDeletion = []int{
-	int(enum.Transferred),
-	int(enum.Shipped),
-	int(enum.Delivered),
+func TestStreamOrders_VisitsOnlyTheCustomersMatchingOrders(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	orders := []response.Order{
+		{OrderNumber: "1", CustomerId: "customer-1", City: "Ankara"},
+		{OrderNumber: "2", CustomerId: "customer-1", City: "İzmir"},
+		{OrderNumber: "3", CustomerId: "customer-2", City: "Ankara"},
+	}
+	mockOrderRepository.On("IterateOrders", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(1).(func(order response.Order) bool)
+			for _, order := range orders {
+				if !visit(order) {
+					return
+				}
+			}
+		}).
+		Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "customer-1",
+		Roles:   []string{policies.CustomerRole},
+	})
+	visited := make([]response.Order, 0)
+
+	//When
+	err := service.StreamOrders(ctx, request.OrderFilter{City: "Ankara"}, func(order response.Order) bool {
+		visited = append(visited, order)
+		return true
+	})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, []response.Order{orders[0]}, visited)
 }
 
-var statusIdsThatNotBeValidForChangable = []int{
-	int(enum.Transferred),
-	int(enum.Shipped),
-	int(enum.Delivered),
+func TestStreamOrders_StopsWhenVisitReturnsFalse(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	orders := []response.Order{{OrderNumber: "1"}, {OrderNumber: "2"}, {OrderNumber: "3"}}
+	mockOrderRepository.On("IterateOrders", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			visit := args.Get(1).(func(order response.Order) bool)
+			for _, order := range orders {
+				if !visit(order) {
+					return
+				}
+			}
+		}).
+		Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	visited := 0
+
+	//When
+	err := service.StreamOrders(context.Background(), request.OrderFilter{}, func(order response.Order) bool {
+		visited++
+		return visited < 2
+	})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, visited)
+}
+
+func TestStreamOrders_WhenOrderRepositoryReturnsError_ReturnsError(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusServiceUnavailable, constants.RequestCancelled).
+		Build()
+	mockOrderRepository.On("IterateOrders", mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.StreamOrders(context.Background(), request.OrderFilter{}, func(order response.Order) bool {
+		return true
+	})
+
+	//Then
+	assert.Equal(t, &serviceErr, err)
+}
+
+func TestGetOrder_WhenOrderBelongsToAnotherCustomer_ReturnsForbidden(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	order := response.Order{OrderNumber: "2", CustomerId: "customer-2"}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "customer-1",
+		Roles:   []string{policies.CustomerRole},
+	})
+
+	//When
+	resp, err := service.GetOrder(ctx, "2")
+
+	//Then
+	assert.Nil(t, resp)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	assert.Equal(t, constants.OrderAccessDenied, err.Message)
+}
+
+func TestCreateOrder_WhenPrincipalIsCustomer_AssignsOrderToCustomer(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 1})
+	serviceReq.CustomerId = "customer-2"
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "customer-1",
+		Type:    models.UserPrincipal,
+		Roles:   []string{policies.CustomerRole},
+	})
+
+	//When
+	err := service.CreateOrder(ctx, serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, mock.MatchedBy(func(createOrderRequest request.CreateOrderRequest) bool {
+		return createOrderRequest.CustomerId == "customer-1"
+	}), mock.Anything)
+}
+
+func TestCreateOrder_WhenCustomerGivesNoItems_ReturnsBadRequest(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.CreateOrder(withCustomerPrincipal("customer-1"), *serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.OrderItemsAreRequired, err.Message)
+	mockOrderRepository.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_WhenOperatorGivesNoItems_StoresTheEnteredTotal(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Subject: "operator-1",
+		Type:    models.UserPrincipal,
+		Roles:   []string{policies.OperatorRole},
+	})
+
+	//When
+	err := service.CreateOrder(ctx, *serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, mock.MatchedBy(func(createOrderRequest request.CreateOrderRequest) bool {
+		return createOrderRequest.TotalAmount == 10.2
+	}), mock.Anything)
+}
+
+func TestCreateOrder_WhenPrincipalIsApiKeyWithWriteScope_DoesNotAssignOrderToKey(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := &request.CreateOrderRequest{}
+	_ = json.Unmarshal([]byte(getCreateOrderRequest()), serviceReq)
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithPrincipal(context.Background(), &models.Principal{
+		Type:    models.ApiKeyPrincipal,
+		Subject: "key-1",
+		Scopes:  []string{policies.WriteOrdersScope},
+	})
+
+	//When
+	err := service.CreateOrder(ctx, *serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, *serviceReq, mock.Anything)
+}
+
+func TestCreateOrder_WhenCustomerIsInline_CreatesCustomerAndReferencesIt(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	customerRepository := repositories.NewCustomerRepository()
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", Address: "Lorem ipsum", City: "Ankara", District: "Çankaya", CurrencyCode: "TRY",
+		Items:    []response.OrderItem{{Sku: "MUG-WHT", Quantity: 1}},
+		Customer: &request.CustomerRequest{FirstName: "Ayşe", LastName: "Yılmaz", Email: "ayse@example.com"},
+	}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository, customerRepository)
+
+	//When
+	err := service.CreateOrder(withCustomerPrincipal("customer-9"), serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	customer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-9")
+	assert.Equal(t, "ayse@example.com", customer.Email)
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, mock.MatchedBy(func(createOrderRequest request.CreateOrderRequest) bool {
+		return createOrderRequest.CustomerId == "customer-9" && createOrderRequest.FirstName == "Ayşe" && createOrderRequest.LastName == "Yılmaz"
+	}), mock.Anything)
+}
+
+func TestCreateOrder_WhenCustomerIsReferenced_TakesMissingNamesFromIt(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", LastName: "Ata-Yılmaz", TotalAmount: 10, Address: "Lorem ipsum", City: "Ankara", District: "Çankaya",
+		CurrencyCode: "TRY", CustomerId: "customer-1",
+	}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	expectedReq := serviceReq
+	expectedReq.FirstName = "Ahmet"
+	mockOrderRepository.AssertCalled(t, "CreateOrder", mock.Anything, expectedReq, mock.Anything)
+}
+
+func TestCreateOrder_WhenReferencedCustomerDoesNotExist_ReturnsBadRequest(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := request.CreateOrderRequest{OrderNumber: "100", FirstName: "Ayşe", LastName: "Yılmaz", CustomerId: "customer-9"}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.CustomerNotFound, err.Message)
+	mockOrderRepository.AssertNumberOfCalls(t, "CreateOrder", 0)
+}
+
+func TestCreateOrder_WhenCustomerPrincipalHasNoRecordAndNoNames_ReturnsBadRequest(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 1})
+	serviceReq.FirstName, serviceReq.LastName, serviceReq.CustomerId = "", "", "customer-9"
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.CreateOrder(withCustomerPrincipal("customer-9"), serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.FirstNameIsNotValid, err.Message)
+}
+
+func TestCreateOrder_WhenOrderCanNotBeStored_DiscardsInlineCustomer(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	customerRepository := repositories.NewCustomerRepository()
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", CurrencyCode: "TRY",
+		Items:    []response.OrderItem{{Sku: "MUG-WHT", Quantity: 1}},
+		Customer: &request.CustomerRequest{FirstName: "Ayşe", LastName: "Yılmaz", Email: "ayse@example.com"},
+	}
+	repositoryErr := response.NewErrorBuilder().
+		SetError(http.StatusConflict, constants.SameOrderFoundByUniqueId).
+		Build()
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).Return(&repositoryErr)
+	service := newTestOrderService(mockOrderRepository, customerRepository)
+
+	//When
+	err := service.CreateOrder(withCustomerPrincipal("customer-9"), serviceReq)
+
+	//Then
+	assert.Equal(t, &repositoryErr, err)
+	customer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-9")
+	assert.Nil(t, customer)
+}
+
+func TestCreateOrder_StoresNormalizedShippingAddressAsFlatFieldsAndBilling(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", TotalAmount: 10, CurrencyCode: "GBP",
+		ShippingAddress: &response.Address{Lines: []string{"Flat 2", " 10 Baker Street "}, City: "London", PostalCode: "nw1 6xe", CountryCode: "gb"},
+	}
+
+	//When
+	err := service.CreateOrder(context.Background(), serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	expectedAddress := &response.Address{Lines: []string{"Flat 2", "10 Baker Street"}, City: "London", PostalCode: "NW1 6XE", CountryCode: "GB"}
+	assert.Equal(t, expectedAddress, order.ShippingAddress)
+	assert.Equal(t, expectedAddress, order.BillingAddress)
+	assert.Equal(t, "Flat 2, 10 Baker Street", order.Address)
+	assert.Equal(t, "London", order.City)
+}
+
+func TestUpdateOrder_WhenOnlyFlatFieldsAreGiven_KeepsBillingAddress(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	existingOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "1")
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Ahmet", LastName: "Ata", TotalAmount: 10, Address: "Moda Caddesi 5", City: "İstanbul", District: "Kadıköy", CurrencyCode: "TRY",
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "1", serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "1")
+	assert.Equal(t, &response.Address{
+		Lines: []string{"Moda Caddesi 5"}, City: "İstanbul", District: "Kadıköy",
+		PostalCode: existingOrder.ShippingAddress.PostalCode, CountryCode: existingOrder.ShippingAddress.CountryCode,
+	}, order.ShippingAddress)
+	assert.Equal(t, existingOrder.BillingAddress, order.BillingAddress)
+}
+
+func TestUpdateOrder_WhenOnlyFlatFieldsAreGiven_KeepsPostalAndCountryCode(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	createReq := request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Ahmet", LastName: "Ata", TotalAmount: 10, CurrencyCode: "TRY",
+		ShippingAddress: &response.Address{Lines: []string{"Moda Caddesi 5", "Daire 3"}, City: "İstanbul", District: "Kadıköy", PostalCode: "34710", CountryCode: "TR"},
+	}
+	_ = service.CreateOrder(context.Background(), createReq)
+	sameStreetReq := request.UpdateOrderRequest{
+		FirstName: "Ahmet", LastName: "Ata", TotalAmount: 10, Address: "Moda Caddesi 5, Daire 3", City: "İstanbul", District: "Kadıköy", CurrencyCode: "TRY",
+	}
+	newStreetReq := sameStreetReq
+	newStreetReq.Address = "Bahariye Caddesi 10"
+
+	//When
+	sameStreetErr := service.UpdateOrder(context.Background(), "100", sameStreetReq)
+	sameStreetOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	newStreetErr := service.UpdateOrder(context.Background(), "100", newStreetReq)
+	newStreetOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+
+	//Then
+	assert.Nil(t, sameStreetErr)
+	assert.Equal(t, createReq.ShippingAddress, sameStreetOrder.ShippingAddress)
+	assert.Nil(t, newStreetErr)
+	assert.Equal(t, &response.Address{Lines: []string{"Bahariye Caddesi 10"}, City: "İstanbul", District: "Kadıköy", PostalCode: "34710", CountryCode: "TR"}, newStreetOrder.ShippingAddress)
+	assert.Equal(t, "Bahariye Caddesi 10", newStreetOrder.Address)
+}
+
+func TestCreateOrder_WhenDistrictDoesNotBelongToCity_ReturnsBadRequest(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "100").Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", TotalAmount: 10, CurrencyCode: "GBP",
+		Address: "10 Baker Street", City: "London", District: "Birmingham",
+	}
+
+	//When
+	err := service.CreateOrder(context.Background(), serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.DistrictDoesNotBelongToCity, err.Message)
+	mockOrderRepository.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrder_WhenBillingDistrictDoesNotBelongToCity_ReturnsBadRequest(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	existingOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "1")
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Ahmet", LastName: "Ata", TotalAmount: 10, Address: "Moda Caddesi 5", City: "istanbul", District: "KADIKÖY", CurrencyCode: "TRY",
+		BillingAddress: &response.Address{Lines: []string{"Kızılay Meydanı 1"}, City: "Ankara", District: "Kadıköy", PostalCode: "06420", CountryCode: "TR"},
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "1", serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.DistrictDoesNotBelongToCity, err.Message)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "1")
+	assert.Equal(t, existingOrder, order)
+}
+
+func TestCreateOrder_WhenItemsAreGiven_PricesThemFromTheCatalog(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	serviceReq := request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", TotalAmount: 1, CurrencyCode: "try",
+		Address: "Moda Caddesi 5", City: "İstanbul", District: "Kadıköy",
+		Items: []response.OrderItem{{Sku: "tshirt-blk-m", Quantity: 3, UnitPrice: 0.01}, {Sku: "MUG-WHT", Quantity: 1}},
+	}
+
+	//When
+	err := service.CreateOrder(context.Background(), serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, []response.OrderItem{
+		{Sku: "TSHIRT-BLK-M", Name: "Black T-Shirt, M", Quantity: 3, UnitPrice: 249.90, Amount: 749.70},
+		{Sku: "MUG-WHT", Name: "White Mug", Quantity: 1, UnitPrice: 119.50, Amount: 119.50},
+	}, order.Items)
+	assert.Equal(t, float32(869.20), order.TotalAmount)
+}
+
+func TestCreateOrder_WhenItemCanNotBePriced_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		item            response.OrderItem
+		expectedMessage string
+	}{
+		{"unknown product", response.OrderItem{Sku: "NOTHING", Quantity: 1}, constants.ProductNotFound},
+		{"inactive product", response.OrderItem{Sku: "POSTER-A2", Quantity: 1}, constants.ProductIsNotActive},
+		{"no price in currency", response.OrderItem{Sku: "MUG-WHT", Quantity: 1}, constants.ProductPriceIsNotAvailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			mockOrderRepository := &mocks.MockOrderRepository{}
+			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "100").Return(nil, nil)
+			service := newTestOrderService(mockOrderRepository)
+			serviceReq := request.CreateOrderRequest{
+				OrderNumber: "100", FirstName: "Jane", LastName: "Doe", CurrencyCode: "GBP",
+				Address: "10 Baker Street", City: "London", District: "Westminster",
+				Items: []response.OrderItem{test.item},
+			}
+
+			//When
+			err := service.CreateOrder(context.Background(), serviceReq)
+
+			//Then
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, test.expectedMessage, err.Message)
+			mockOrderRepository.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateOrder_WhenItemsAreMissing_KeepsItemsAndTheirTotal(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	_ = service.CreateOrder(context.Background(), request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}},
+	})
+	existingOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", TotalAmount: 1, CurrencyCode: "EUR",
+		Address: "Unter den Linden 2", City: "Berlin", District: "Mitte",
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "100", serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, existingOrder.Items, order.Items)
+	assert.Equal(t, float32(15), order.TotalAmount)
+	assert.Equal(t, "Unter den Linden 2", order.Address)
+}
+
+func TestUpdateOrder_WhenOrderHasNoItemsAndCallerCannotEnterTotals_RejectsNewTotal(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	_ = service.CreateOrder(context.Background(), request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", TotalAmount: 10, CurrencyCode: "TRY",
+		Address: "Lorem ipsum", City: "Ankara", District: "Çankaya", CustomerId: "customer-1",
+	})
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", TotalAmount: 1, CurrencyCode: "TRY",
+		Address: "Lorem ipsum", City: "Ankara", District: "Çankaya",
+	}
+
+	//When
+	err := service.UpdateOrder(withCustomerPrincipal("customer-1"), "100", serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.OrderItemsAreRequired, err.Message)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, float32(10), order.TotalAmount)
+}
+
+func TestUpdateOrder_WhenCurrencyChanges_PricesKeptItemsAgain(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	service := newTestOrderService(orderRepository)
+	_ = service.CreateOrder(context.Background(), request.CreateOrderRequest{
+		OrderNumber: "100", FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: []response.OrderItem{{Sku: "MUG-WHT", Quantity: 2}},
+	})
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", TotalAmount: 1, CurrencyCode: "TRY",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "100", serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, []response.OrderItem{{Sku: "MUG-WHT", Name: "White Mug", Quantity: 2, UnitPrice: 119.50, Amount: 239}}, order.Items)
+	assert.Equal(t, float32(239), order.TotalAmount)
+}
+
+func getItemOrderRequest(orderNumber string, items ...response.OrderItem) request.CreateOrderRequest {
+	return request.CreateOrderRequest{
+		OrderNumber: orderNumber, FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: items,
+	}
+}
+
+func TestCreateOrder_ReservesStockOverWarehouses(t *testing.T) {
+	//Given
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), inventoryRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getItemOrderRequest("100", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 60}))
+
+	//Then
+	assert.Nil(t, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Equal(t, []response.ReservationLine{
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "BER-1", Quantity: 20},
+		{Sku: "TSHIRT-BLK-M", WarehouseId: "IST-1", Quantity: 40},
+	}, reservation)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "TSHIRT-BLK-M")
+	assert.Equal(t, 0, stockLevels[0].Available)
+	assert.Equal(t, 10, stockLevels[1].Available)
+}
+
+func TestCreateOrder_WhenStockIsInsufficient_ReturnsConflictAndReservesNothing(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(orderRepository, inventoryRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getItemOrderRequest("100",
+		response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 5}, response.OrderItem{Sku: "MUG-WHT", Quantity: 11}))
+
+	//Then
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.StockIsInsufficient, err.Message)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Nil(t, order)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "")
+	for _, stockLevel := range stockLevels {
+		assert.Equal(t, 0, stockLevel.Reserved)
+	}
+}
+
+func TestCheckOrder_RunsTheChecksOfCreateOrderWithoutStoringAnything(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	customerRepository := repositories.NewCustomerRepository()
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(orderRepository, customerRepository, inventoryRepository)
+	checkedReq := getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 2})
+	checkedReq.Customer = &request.CustomerRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+	_ = service.CreateOrder(context.Background(), getItemOrderRequest("200", response.OrderItem{Sku: "MUG-WHT", Quantity: 1}))
+
+	//When
+	err := service.CheckOrder(withCustomerPrincipal("customer-9"), checkedReq)
+	existingErr := service.CheckOrder(context.Background(), getItemOrderRequest("200", response.OrderItem{Sku: "MUG-WHT", Quantity: 1}))
+	stockErr := service.CheckOrder(context.Background(), getItemOrderRequest("300", response.OrderItem{Sku: "MUG-WHT", Quantity: 10}))
+	districtReq := getItemOrderRequest("400", response.OrderItem{Sku: "MUG-WHT", Quantity: 1})
+	districtReq.District = "Çankaya"
+	districtErr := service.CheckOrder(context.Background(), districtReq)
+	couponReq := getItemOrderRequest("500", response.OrderItem{Sku: "MUG-WHT", Quantity: 1})
+	couponReq.CouponCode = "UNKNOWN"
+	couponErr := service.CheckOrder(context.Background(), couponReq)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, constants.SameOrderFoundByUniqueId, existingErr.Message)
+	assert.Equal(t, constants.StockIsInsufficient, stockErr.Message)
+	assert.Equal(t, http.StatusBadRequest, districtErr.StatusCode)
+	assert.Equal(t, constants.CouponNotFound, couponErr.Message)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Nil(t, order)
+	customer, _ := customerRepository.FetchCustomerById(context.Background(), "customer-9")
+	assert.Nil(t, customer)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+}
+
+func TestCreateOrder_WhenOrdersAreConcurrent_NeverReservesMoreThanAvailable(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(orderRepository, inventoryRepository)
+	results := make(chan *response.ErrorResponse)
+
+	//When
+	for i := 0; i < 25; i++ {
+		go func(orderNumber string) {
+			results <- service.CreateOrder(context.Background(), getItemOrderRequest(orderNumber, response.OrderItem{Sku: "MUG-WHT", Quantity: 1}))
+		}(fmt.Sprintf("concurrent-%d", i))
+	}
+	created := 0
+	for i := 0; i < 25; i++ {
+		if err := <-results; err == nil {
+			created++
+		} else {
+			assert.Equal(t, constants.StockIsInsufficient, err.Message)
+		}
+	}
+
+	//Then
+	assert.Equal(t, 10, created)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "MUG-WHT")
+	assert.Equal(t, response.StockLevel{Sku: "MUG-WHT", WarehouseId: "IST-1", OnHand: 10, Reserved: 10, Available: 0}, stockLevels[0])
+}
+
+func TestDeleteOrder_ReleasesReservedStock(t *testing.T) {
+	//Given
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), inventoryRepository)
+	_ = service.CreateOrder(context.Background(), getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 4}))
+
+	//When
+	err := service.DeleteOrder(context.Background(), "100")
+
+	//Then
+	assert.Nil(t, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "MUG-WHT")
+	assert.Equal(t, 10, stockLevels[0].Available)
+}
+
+func TestTransitionOrder_WhenOrderIsTransferred_CommitsReservedStock(t *testing.T) {
+	//Given
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), inventoryRepository)
+	_ = service.CreateOrder(context.Background(), getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 4}))
+	_ = service.TransitionOrder(context.Background(), "100", request.TransitionOrderRequest{StatusId: int(enum.Approved)})
+
+	//When
+	err := service.TransitionOrder(context.Background(), "100", request.TransitionOrderRequest{StatusId: int(enum.Transferred)})
+
+	//Then
+	assert.Nil(t, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "MUG-WHT")
+	assert.Equal(t, response.StockLevel{Sku: "MUG-WHT", WarehouseId: "IST-1", OnHand: 6, Reserved: 0, Available: 6}, stockLevels[0])
+}
+
+func TestTransitionOrder_WhenStockCanNotBeCommitted_KeepsStatusSoTransferCanBeRetried(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockInventoryRepository := &mocks.MockInventoryRepository{}
+	repositoryErr := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, "test").
+		Build()
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "100").Return(&response.Order{OrderNumber: "100", StatusId: int(enum.Approved)}, nil)
+	mockInventoryRepository.On("CommitReservation", mock.Anything, "100").Return(&repositoryErr)
+	service := newTestOrderService(mockOrderRepository, mockInventoryRepository)
+
+	//When
+	err := service.TransitionOrder(context.Background(), "100", request.TransitionOrderRequest{StatusId: int(enum.Transferred)})
+
+	//Then
+	assert.Equal(t, &repositoryErr, err)
+	mockOrderRepository.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_WhenRequestIsCancelledWhileStoring_GivesStockAndCouponUseBack(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	inventoryRepository := repositories.NewInventoryRepository()
+	promotionRepository := repositories.NewPromotionRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cancelledErr := response.NewErrorBuilder().
+		SetError(http.StatusServiceUnavailable, constants.RequestCancelled).
+		Build()
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	mockOrderRepository.On("CreateOrder", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(&cancelledErr)
+	service := newTestOrderService(mockOrderRepository, inventoryRepository, promotionRepository)
+
+	//When
+	err := service.CreateOrder(ctx, getCouponOrderRequest("100", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "MUG-WHT", Quantity: 4}))
+
+	//Then
+	assert.Equal(t, &cancelledErr, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+	promotion, _ := promotionRepository.FetchPromotionByCode(context.Background(), "WELCOME10")
+	assert.Equal(t, 0, promotion.TimesUsed)
+}
+
+func TestDeleteOrder_WhenRequestIsCancelledWhileDeleting_ReleasesReservedStock(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	inventoryRepository := repositories.NewInventoryRepository()
+	_ = inventoryRepository.ReserveStock(context.Background(), "100", []response.OrderItem{{Sku: "MUG-WHT", Quantity: 4}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "100").Return(&response.Order{OrderNumber: "100", StatusId: int(enum.Created)}, nil)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, "100", mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil)
+	service := newTestOrderService(mockOrderRepository, inventoryRepository)
+
+	//When
+	err := service.DeleteOrder(ctx, "100")
+
+	//Then
+	assert.Nil(t, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+}
+
+func TestUpdateOrder_WhenItemsChange_ReplacesReservation(t *testing.T) {
+	//Given
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), inventoryRepository)
+	_ = service.CreateOrder(context.Background(), getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 4}))
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: []response.OrderItem{{Sku: "MUG-WHT", Quantity: 10}},
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "100", serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Equal(t, []response.ReservationLine{{Sku: "MUG-WHT", WarehouseId: "IST-1", Quantity: 10}}, reservation)
+}
+
+// racingOrderRepository runs race right after an order was first read, as a concurrent
+// request between checking and changing the order would.
+type racingOrderRepository struct {
+	repositories.OrderRepository
+	race func()
+}
+
+func (repository *racingOrderRepository) FetchOrderByOrderNumber(ctx context.Context, orderNumber string) (*response.Order, *response.ErrorResponse) {
+	order, errorResp := repository.OrderRepository.FetchOrderByOrderNumber(ctx, orderNumber)
+	if race := repository.race; race != nil {
+		repository.race = nil
+		race()
+	}
+	return order, errorResp
+}
+
+func TestUpdateOrder_WhenOrderIsTransferredConcurrently_ReturnsConflictAndReservesNothing(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	inventoryRepository := repositories.NewInventoryRepository()
+	service := newTestOrderService(orderRepository, inventoryRepository)
+	_ = service.CreateOrder(context.Background(), getItemOrderRequest("100", response.OrderItem{Sku: "MUG-WHT", Quantity: 4}))
+	_ = service.TransitionOrder(context.Background(), "100", request.TransitionOrderRequest{StatusId: int(enum.Approved)})
+	racingService := newTestOrderService(&racingOrderRepository{
+		OrderRepository: orderRepository,
+		race: func() {
+			_ = service.TransitionOrder(context.Background(), "100", request.TransitionOrderRequest{StatusId: int(enum.Transferred)})
+		},
+	}, inventoryRepository)
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: []response.OrderItem{{Sku: "MUG-WHT", Quantity: 5}},
+	}
+
+	//When
+	err := racingService.UpdateOrder(context.Background(), "100", serviceReq)
+
+	//Then
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.OrderStatusHasChanged, err.Message)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, int(enum.Transferred), order.StatusId)
+	assert.Equal(t, 4, order.Items[0].Quantity)
+	reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+	assert.Nil(t, reservation)
+	stockLevels, _ := inventoryRepository.FetchStockLevels(context.Background(), "MUG-WHT")
+	assert.Equal(t, response.StockLevel{Sku: "MUG-WHT", WarehouseId: "IST-1", OnHand: 6, Reserved: 0, Available: 6}, stockLevels[0])
+}
+
+func TestTransitionOrder(t *testing.T) {
+	//Given
+	orderNumber := "1"
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	order := response.Order{OrderNumber: orderNumber, StatusId: int(enum.Created)}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.TransitionOrder(context.Background(), orderNumber, request.TransitionOrderRequest{StatusId: int(enum.Approved)})
+
+	//Then
+	assert.Nil(t, err)
+	mockOrderRepository.AssertCalled(t, "UpdateOrderStatus", mock.Anything, orderNumber, enum.Approved, mock.Anything)
+}
+
+func TestTransitionOrder_WhenOrderNotFoundInRepository_ReturnsNotFound(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(nil, nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.TransitionOrder(context.Background(), "1", request.TransitionOrderRequest{StatusId: int(enum.Approved)})
+
+	//Then
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	mockOrderRepository.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+}
+
+func TestTransitionOrder_WhenTransitionIsNotValid_ReturnsConflict(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    enum.OrderStatus
+		nextStatus enum.OrderStatus
+	}{
+		{"skipping a status", enum.Created, enum.Transferred},
+		{"moving backwards", enum.Shipped, enum.Approved},
+		{"keeping the same status", enum.Approved, enum.Approved},
+		{"moving past delivered", enum.Delivered, enum.Delivered + 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			mockOrderRepository := &mocks.MockOrderRepository{}
+			order := response.Order{OrderNumber: "1", StatusId: int(test.current)}
+			mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+			service := newTestOrderService(mockOrderRepository)
+
+			//When
+			err := service.TransitionOrder(context.Background(), "1", request.TransitionOrderRequest{StatusId: int(test.nextStatus)})
+
+			//Then
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusConflict, err.StatusCode)
+			assert.Equal(t, constants.OrderStatusTransitionIsNotValid, err.Message)
+			mockOrderRepository.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+		})
+	}
+}
+
+func TestUpdateOrder_RecordsAuditEntryWithFieldChanges(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	serviceReq := &request.UpdateOrderRequest{}
+	_ = json.Unmarshal([]byte(getUpdateOrderRequest()), serviceReq)
+	order := response.Order{
+		OrderNumber:  "1",
+		FirstName:    serviceReq.FirstName,
+		LastName:     serviceReq.LastName,
+		TotalAmount:  serviceReq.TotalAmount,
+		Address:      "Kızılay, Ankara",
+		City:         "Ankara",
+		District:     serviceReq.District,
+		StatusId:     int(enum.Created),
+		CurrencyCode: serviceReq.CurrencyCode,
+		ShippingAddress: &response.Address{
+			Lines:    []string{"Kızılay, Ankara"},
+			City:     "Ankara",
+			District: serviceReq.District,
+		},
+	}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("UpdateOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auditRepository := repositories.NewAuditRepository()
+	service := newTestOrderService(mockOrderRepository, auditRepository)
+	principal := &models.Principal{Type: models.UserPrincipal, Subject: "operator-1", Roles: []string{policies.OperatorRole}}
+	ctx := helpers.WithRequestId(helpers.WithPrincipal(context.Background(), principal), "request-1")
+
+	//When
+	err := service.UpdateOrder(ctx, "1", *serviceReq)
+	auditEntries, auditErr := service.GetOrderAudit(ctx, "1")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Nil(t, auditErr)
+	assert.Len(t, auditEntries, 1)
+	assert.Equal(t, string(enum.UpdateAction), auditEntries[0].Action)
+	assert.Equal(t, "user:operator-1", auditEntries[0].Actor)
+	assert.Equal(t, "request-1", auditEntries[0].RequestId)
+	assert.Equal(t, []response.FieldChange{
+		{Field: "address", Before: "Kızılay, Ankara", After: serviceReq.Address},
+		{Field: "city", Before: "Ankara", After: serviceReq.City},
+		{Field: "shippingAddress", Before: order.ShippingAddress, After: &response.Address{
+			Lines:    []string{serviceReq.Address},
+			City:     serviceReq.City,
+			District: serviceReq.District,
+		}},
+	}, auditEntries[0].Changes)
+}
+
+func TestGetOrderAudit_WhenOrderBelongsToAnotherCustomer_ReturnsAccessDenied(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	order := response.Order{OrderNumber: "1", CustomerId: "customer-2"}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "1").Return(&order, nil)
+	auditRepository := repositories.NewAuditRepository()
+	_ = auditRepository.SaveAuditEntry(context.Background(), response.AuditEntry{OrderNumber: "1", Action: string(enum.CreateAction)})
+	service := newTestOrderService(mockOrderRepository, auditRepository)
+
+	//When
+	ownAuditEntries, ownErr := service.GetOrderAudit(withCustomerPrincipal("customer-2"), "1")
+	auditEntries, err := service.GetOrderAudit(withCustomerPrincipal("customer-1"), "1")
+
+	//Then
+	assert.Nil(t, ownErr)
+	assert.Len(t, ownAuditEntries, 1)
+	assert.Nil(t, auditEntries)
+	assert.Equal(t, http.StatusForbidden, err.StatusCode)
+	assert.Equal(t, constants.OrderAccessDenied, err.Message)
+}
+
+func TestGetOrderAudit_WhenOrderIsDeleted_ReturnsEntriesOnlyToThoseReadingEveryOrder(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, "1").Return(nil, nil)
+	auditRepository := repositories.NewAuditRepository()
+	_ = auditRepository.SaveAuditEntry(context.Background(), response.AuditEntry{OrderNumber: "1", Action: string(enum.DeleteAction)})
+	service := newTestOrderService(mockOrderRepository, auditRepository)
+	operator := &models.Principal{Type: models.UserPrincipal, Subject: "operator-1", Roles: []string{policies.OperatorRole}}
+
+	//When
+	auditEntries, err := service.GetOrderAudit(helpers.WithPrincipal(context.Background(), operator), "1")
+	customerAuditEntries, customerErr := service.GetOrderAudit(withCustomerPrincipal("customer-1"), "1")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 1)
+	assert.Nil(t, customerAuditEntries)
+	assert.Equal(t, http.StatusNotFound, customerErr.StatusCode)
+	assert.Equal(t, constants.OrderNotFoundByOrderNumber, customerErr.Message)
+}
+
+func TestDeleteOrder_WhenOrderRepositoryDeleteMethodReturnsError_DoesNotRecordAuditEntry(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockAuditRepository := &mocks.MockAuditRepository{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, "test").
+		Build()
+	order := response.Order{OrderNumber: "1", StatusId: int(enum.Created)}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository, mockAuditRepository)
+
+	//When
+	err := service.DeleteOrder(context.Background(), "1")
+
+	//Then
+	assert.Equal(t, &serviceErr, err)
+	mockAuditRepository.AssertNumberOfCalls(t, "SaveAuditEntry", 0)
+}
+
+func TestDeleteOrder_WhenAuditEntryCanNotBeStored_StillSucceeds(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	mockAuditRepository := &mocks.MockAuditRepository{}
+	serviceErr := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, "test").
+		Build()
+	order := response.Order{OrderNumber: "1", StatusId: int(enum.Created)}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockAuditRepository.On("SaveAuditEntry", mock.Anything, mock.Anything).Return(&serviceErr)
+	service := newTestOrderService(mockOrderRepository, mockAuditRepository)
+
+	//When
+	err := service.DeleteOrder(context.Background(), "1")
+
+	//Then
+	assert.Nil(t, err)
+	mockAuditRepository.AssertNumberOfCalls(t, "SaveAuditEntry", 1)
+}
+
+func TestTransitionOrder_StoresOrderStatusChangedEventWithTheChange(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	order := response.Order{OrderNumber: "1", StatusId: int(enum.Created)}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+	ctx := helpers.WithRequestId(context.Background(), "request-1")
+
+	//When
+	err := service.TransitionOrder(ctx, "1", request.TransitionOrderRequest{StatusId: int(enum.Approved)})
+
+	//Then
+	assert.Nil(t, err)
+	event := mockOrderRepository.Calls[1].Arguments.Get(3).(events.OrderEvent)
+	assert.Equal(t, enum.OrderStatusChanged, event.Type)
+	assert.Equal(t, "1", event.OrderNumber)
+	assert.Equal(t, int(enum.Approved), event.Order.StatusId)
+	assert.Equal(t, int(enum.Created), event.PreviousStatusId)
+	assert.Equal(t, "anonymous", event.Actor)
+	assert.Equal(t, "request-1", event.RequestId)
+}
+
+func TestDeleteOrder_StoresOrderDeletedEventWithTheChange(t *testing.T) {
+	//Given
+	mockOrderRepository := &mocks.MockOrderRepository{}
+	order := response.Order{OrderNumber: "1", StatusId: int(enum.Created), CustomerId: "customer-1"}
+	mockOrderRepository.On("FetchOrderByOrderNumber", mock.Anything, mock.Anything).Return(&order, nil)
+	mockOrderRepository.On("DeleteOrder", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := newTestOrderService(mockOrderRepository)
+
+	//When
+	err := service.DeleteOrder(context.Background(), "1")
+
+	//Then
+	assert.Nil(t, err)
+	event := mockOrderRepository.Calls[1].Arguments.Get(2).(events.OrderEvent)
+	assert.Equal(t, enum.OrderDeleted, event.Type)
+	assert.Equal(t, order, event.Order)
+}
+
+func getCreateOrderRequest() string {
+	return `{
+  "orderNumber": "1",
+  "firstName": "Test",
+  "lastName": "Sample",
+  "totalAmount": 10.2,
+  "address": "address",
+  "city": "İstanbul",
+  "district": "Bakırköy",
+  "currencyCode": "TRY"
+}`
+}
+
+func getUpdateOrderRequest() string {
+	return `{
+  "firstName": "Test",
+  "lastName": "Sample",
+  "totalAmount": 10.2,
+  "address": "address",
+  "city": "İstanbul",
+  "district": "Bakırköy",
+  "currencyCode": "TRY"
+}`
+}
+
+var statusIdsThatNotBeValidForDeletion = []int{
+	int(enum.Transferred),
+	int(enum.Shipped),
+	int(enum.Delivered),
+}
+
+var statusIdsThatNotBeValidForChangable = []int{
+	int(enum.Transferred),
+	int(enum.Shipped),
+	int(enum.Delivered),
+}
+
+func getCouponOrderRequest(orderNumber string, currencyCode string, customerId string, couponCode string, items ...response.OrderItem) request.CreateOrderRequest {
+	createOrderRequest := getItemOrderRequest(orderNumber, items...)
+	createOrderRequest.CurrencyCode = currencyCode
+	createOrderRequest.CustomerId = customerId
+	createOrderRequest.CouponCode = couponCode
+	return createOrderRequest
+}
+
+func TestCreateOrder_AppliesAutomaticPromotionsAndCoupon(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	promotionRepository := repositories.NewPromotionRepository()
+	service := newTestOrderService(orderRepository, promotionRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("100", "TRY", "customer-1", "welcome10", response.OrderItem{Sku: "MUG-WHT", Quantity: 10}))
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, float32(1195), order.SubtotalAmount)
+	assert.Equal(t, "WELCOME10", order.CouponCode)
+	assert.Equal(t, []response.OrderDiscount{
+		{PromotionCode: "TRY1000", Name: "5% off baskets from 1000 TRY", Amount: 59.75},
+		{PromotionCode: "WELCOME10", Name: "10% off the first order", Amount: 119.50},
+	}, order.Discounts)
+	assert.Equal(t, float32(1015.75), order.TotalAmount)
+	promotion, _ := promotionRepository.FetchPromotionByCode(context.Background(), "WELCOME10")
+	assert.Equal(t, 1, promotion.TimesUsed)
+}
+
+func TestCreateOrder_WhenCouponCanNotBeApplied_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		currencyCode    string
+		customerId      string
+		couponCode      string
+		expectedMessage string
+	}{
+		{"unknown coupon", "EUR", "customer-1", "NOPE10", constants.CouponNotFound},
+		{"automatic promotion", "TRY", "customer-1", "TRY1000", constants.CouponNotFound},
+		{"basket below minimum", "EUR", "customer-1", "SAVE5EUR", constants.CouponMinimumBasketIsNotReached},
+		{"other currency", "TRY", "customer-1", "SAVE5EUR", constants.CouponIsNotApplicableToCurrency},
+		{"limit per customer without customer", "EUR", "", "WELCOME10", constants.CouponRequiresCustomer},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+			inventoryRepository := repositories.NewInventoryRepository()
+			service := newTestOrderService(orderRepository, inventoryRepository)
+
+			//When
+			err := service.CreateOrder(context.Background(), getCouponOrderRequest("100", test.currencyCode, test.customerId, test.couponCode, response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+
+			//Then
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, test.expectedMessage, err.Message)
+			order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+			assert.Nil(t, order)
+			reservation, _ := inventoryRepository.FetchReservation(context.Background(), "100")
+			assert.Nil(t, reservation)
+		})
+	}
+}
+
+func TestCreateOrder_WhenCouponIsExpired_ReturnsBadRequest(t *testing.T) {
+	//Given
+	promotionRepository := repositories.NewPromotionRepository()
+	validUntil := time.Now().Add(-time.Hour)
+	_ = promotionRepository.CreatePromotion(context.Background(), response.Promotion{Code: "SUMMER", Name: "Summer", Type: "percentage", Value: 20, ValidUntil: &validUntil, Active: true})
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), promotionRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "", "SUMMER", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+
+	//Then
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, constants.CouponIsNotActive, err.Message)
+}
+
+func TestCreateOrder_WhenCustomerUsedCouponUpToItsLimit_ReturnsConflict(t *testing.T) {
+	//Given
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()))
+	_ = service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("101", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+
+	//Then
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.PromotionUsageLimitIsReached, err.Message)
+}
+
+func TestCreateOrder_WhenOrdersAreConcurrent_NeverExceedsCouponUsageLimit(t *testing.T) {
+	//Given
+	promotionRepository := repositories.NewPromotionRepository()
+	_ = promotionRepository.CreatePromotion(context.Background(), response.Promotion{Code: "FIRST3", Name: "First three", Type: "percentage", Value: 10, UsageLimit: 3, Active: true})
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), promotionRepository)
+	results := make(chan *response.ErrorResponse)
+
+	//When
+	for i := 0; i < 10; i++ {
+		go func(orderNumber string) {
+			results <- service.CreateOrder(context.Background(), getCouponOrderRequest(orderNumber, "EUR", "", "FIRST3", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+		}(fmt.Sprintf("concurrent-%d", i))
+	}
+	created := 0
+	for i := 0; i < 10; i++ {
+		if err := <-results; err == nil {
+			created++
+		} else {
+			assert.Equal(t, constants.PromotionUsageLimitIsReached, err.Message)
+		}
+	}
+
+	//Then
+	assert.Equal(t, 3, created)
+	promotion, _ := promotionRepository.FetchPromotionByCode(context.Background(), "FIRST3")
+	assert.Equal(t, 3, promotion.TimesUsed)
+}
+
+// racingPromotionRepository lets another order redeem promotions right before the first
+// redemption, as a concurrent order between pricing and redeeming would.
+type racingPromotionRepository struct {
+	repositories.PromotionRepository
+	race func()
+}
+
+func (repository *racingPromotionRepository) RedeemPromotions(ctx context.Context, orderNumber string, customerId string, codes []string) *response.ErrorResponse {
+	if race := repository.race; race != nil {
+		repository.race = nil
+		race()
+	}
+	return repository.PromotionRepository.RedeemPromotions(ctx, orderNumber, customerId, codes)
+}
+
+func TestCreateOrder_WhenAutomaticPromotionIsUsedUpConcurrently_CreatesOrderWithoutIt(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	promotionRepository := repositories.NewPromotionRepository()
+	_ = promotionRepository.CreatePromotion(context.Background(), response.Promotion{Code: "ONCE", Name: "First order only", Type: "percentage", Value: 20, UsageLimit: 1, Automatic: true, Active: true})
+	racingRepository := &racingPromotionRepository{
+		PromotionRepository: promotionRepository,
+		race: func() {
+			_ = promotionRepository.RedeemPromotions(context.Background(), "99", "", []string{"ONCE"})
+		},
+	}
+	service := newTestOrderService(orderRepository, racingRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "MUG-WHT", Quantity: 2}))
+
+	//Then
+	assert.Nil(t, err)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, []response.OrderDiscount{{PromotionCode: "WELCOME10", Name: "10% off the first order", Amount: 1.5}}, order.Discounts)
+	assert.Equal(t, float32(13.5), order.TotalAmount)
+	welcome, _ := promotionRepository.FetchPromotionByCode(context.Background(), "WELCOME10")
+	assert.Equal(t, 1, welcome.TimesUsed)
+}
+
+func TestCreateOrder_WhenCouponIsUsedUpConcurrently_ReturnsConflict(t *testing.T) {
+	//Given
+	promotionRepository := repositories.NewPromotionRepository()
+	racingRepository := &racingPromotionRepository{
+		PromotionRepository: promotionRepository,
+		race: func() {
+			_ = promotionRepository.RedeemPromotions(context.Background(), "99", "customer-1", []string{"WELCOME10"})
+		},
+	}
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()), racingRepository)
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "MUG-WHT", Quantity: 2}))
+
+	//Then
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.PromotionUsageLimitIsReached, err.Message)
+}
+
+func TestDeleteOrder_GivesCouponUseBack(t *testing.T) {
+	//Given
+	service := newTestOrderService(repositories.NewOrderRepository(repositories.NewInMemoryDatabase()))
+	_ = service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+	_ = service.DeleteOrder(context.Background(), "100")
+
+	//When
+	err := service.CreateOrder(context.Background(), getCouponOrderRequest("101", "EUR", "customer-1", "WELCOME10", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 1}))
+
+	//Then
+	assert.Nil(t, err)
+}
+
+func TestUpdateOrder_WhenBasketFallsBelowCouponMinimum_DropsDiscountAndGivesUseBack(t *testing.T) {
+	//Given
+	orderRepository := repositories.NewOrderRepository(repositories.NewInMemoryDatabase())
+	promotionRepository := repositories.NewPromotionRepository()
+	service := newTestOrderService(orderRepository, promotionRepository)
+	_ = service.CreateOrder(context.Background(), getCouponOrderRequest("100", "EUR", "", "SAVE5EUR", response.OrderItem{Sku: "TSHIRT-BLK-M", Quantity: 2}))
+	createdOrder, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	serviceReq := request.UpdateOrderRequest{
+		FirstName: "Jane", LastName: "Doe", CurrencyCode: "EUR",
+		Address: "Unter den Linden 1", City: "Berlin", District: "Mitte",
+		Items: []response.OrderItem{{Sku: "TSHIRT-BLK-M", Quantity: 1}},
+	}
+
+	//When
+	err := service.UpdateOrder(context.Background(), "100", serviceReq)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, float32(24.98), createdOrder.TotalAmount)
+	order, _ := orderRepository.FetchOrderByOrderNumber(context.Background(), "100")
+	assert.Equal(t, float32(14.99), order.TotalAmount)
+	assert.Empty(t, order.CouponCode)
+	assert.Empty(t, order.Discounts)
+	promotion, _ := promotionRepository.FetchPromotionByCode(context.Background(), "SAVE5EUR")
+	assert.Equal(t, 0, promotion.TimesUsed)
 }
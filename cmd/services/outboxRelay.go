@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"sync"
+	"time"
+)
+
+// OutboxRelay publishes the events stored in the outbox on the event bus.
+type OutboxRelay interface {
+	// Start relays pending messages every poll interval until Stop is called.
+	Start()
+	// Stop waits for the running relay round to finish.
+	Stop()
+	// RelayPendingMessages publishes one batch of pending messages. Messages that fail are
+	// retried with an exponential backoff and dead-lettered after MaxAttempts.
+	RelayPendingMessages(ctx context.Context) *response.ErrorResponse
+	// PruneMessages drops messages that were published or dead-lettered longer than the
+	// retention ago.
+	PruneMessages(ctx context.Context) *response.ErrorResponse
+}
+
+type OutboxRelayImp struct {
+	outboxRepository repositories.OutboxRepository
+	eventBus         events.EventBus
+	config           models.OutboxConfig
+	now              func() time.Time
+	stop             chan struct{}
+	stopOnce         sync.Once
+	waitGroup        sync.WaitGroup
+}
+
+func (r *OutboxRelayImp) Start() {
+	r.waitGroup.Add(1)
+	go func() {
+		defer r.waitGroup.Done()
+		ticker := time.NewTicker(r.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				// Failed rounds are recorded on their spans and retried on the next tick.
+				_ = r.RelayPendingMessages(context.Background())
+				_ = r.PruneMessages(context.Background())
+			}
+		}
+	}()
+}
+
+func (r *OutboxRelayImp) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+	r.waitGroup.Wait()
+}
+
+func (r *OutboxRelayImp) RelayPendingMessages(ctx context.Context) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OutboxRelay.RelayPendingMessages")
+	defer func() {
+		helpers.RecordError(ctx, errorResp)
+		helpers.EndSpan(span, errorResp)
+	}()
+
+	outboxMessages, errorResp := r.outboxRepository.FetchPendingMessages(ctx, r.now(), r.config.BatchSize)
+	if errorResp != nil {
+		return errorResp
+	}
+
+	// Once a message is rescheduled, later messages of the same order in this batch
+	// have to wait for it.
+	waitingOrderNumbers := make(map[string]bool)
+	for _, outboxMessage := range outboxMessages {
+		orderNumber := outboxMessage.Event.OrderNumber
+		if waitingOrderNumbers[orderNumber] {
+			continue
+		}
+
+		publishErr := r.eventBus.Publish(ctx, outboxMessage.Event)
+		if publishErr == nil {
+			errorResp = r.outboxRepository.MarkMessagePublished(ctx, outboxMessage.Id)
+		} else if outboxMessage.Attempts+1 >= r.config.MaxAttempts {
+			deadLetteredErr := response.NewErrorBuilder().
+				SetError(http.StatusInternalServerError, constants.OutboxMessageIsDeadLettered).
+				Build()
+			helpers.RecordError(ctx, &deadLetteredErr,
+				attribute.Int64(constants.OutboxMessageId, outboxMessage.Id),
+				attribute.String(constants.EventType, string(outboxMessage.Event.Type)),
+				attribute.String(constants.PublishError, publishErr.Error()))
+			errorResp = r.outboxRepository.DeadLetterMessage(ctx, outboxMessage.Id, publishErr.Error())
+		} else {
+			waitingOrderNumbers[orderNumber] = true
+			nextAttemptAt := r.now().Add(exponentialBackoff(r.config.InitialBackoff, r.config.MaxBackoff, outboxMessage.Attempts+1))
+			errorResp = r.outboxRepository.RescheduleMessage(ctx, outboxMessage.Id, publishErr.Error(), nextAttemptAt)
+		}
+
+		if errorResp != nil {
+			return errorResp
+		}
+	}
+
+	return nil
+}
+
+func (r *OutboxRelayImp) PruneMessages(ctx context.Context) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "OutboxRelay.PruneMessages")
+	defer func() {
+		helpers.RecordError(ctx, errorResp)
+		helpers.EndSpan(span, errorResp)
+	}()
+
+	_, errorResp = r.outboxRepository.PruneMessages(ctx, r.now().Add(-r.config.Retention))
+	return errorResp
+}
+
+// exponentialBackoff doubles the initial backoff with every attempt, up to the max backoff.
+func exponentialBackoff(initialBackoff, maxBackoff time.Duration, attempts int) time.Duration {
+	backoff := initialBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+func NewOutboxRelay(outboxRepository repositories.OutboxRepository, eventBus events.EventBus, config models.OutboxConfig) OutboxRelay {
+	return &OutboxRelayImp{
+		outboxRepository: outboxRepository,
+		eventBus:         eventBus,
+		config:           config,
+		now:              time.Now,
+		stop:             make(chan struct{}),
+	}
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/mocks"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"testing"
+	"time"
+)
+
+var testOutboxConfig = models.OutboxConfig{
+	PollInterval:   time.Second,
+	BatchSize:      10,
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// newTestOutbox stores the given events through the order repository, the way the order
+// service does, and returns a relay publishing them on the event bus.
+func newTestOutbox(t *testing.T, eventBus events.EventBus, orderEvents ...events.OrderEvent) *OutboxRelayImp {
+	database := repositories.NewInMemoryDatabase()
+	orderRepository := repositories.NewOrderRepository(database)
+	for _, event := range orderEvents {
+		errorResp := orderRepository.UpdateOrderStatus(context.Background(), event.OrderNumber, enum.OrderStatus(event.Order.StatusId), event)
+		assert.Nil(t, errorResp)
+	}
+
+	return NewOutboxRelay(repositories.NewOutboxRepository(database), eventBus, testOutboxConfig).(*OutboxRelayImp)
+}
+
+func newTestEvent(orderNumber string, status enum.OrderStatus) events.OrderEvent {
+	return events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: orderNumber, StatusId: int(status)})
+}
+
+func TestRelayPendingMessages_PublishesEachMessageOnce(t *testing.T) {
+	//Given
+	eventBus := events.NewEventBus()
+	var published []events.OrderEvent
+	eventBus.Subscribe(func(ctx context.Context, event events.OrderEvent) error {
+		published = append(published, event)
+		return nil
+	})
+	firstEvent := newTestEvent("1", enum.Transferred)
+	secondEvent := newTestEvent("2", enum.Shipped)
+	relay := newTestOutbox(t, eventBus, firstEvent, secondEvent)
+
+	//When
+	firstErr := relay.RelayPendingMessages(context.Background())
+	secondErr := relay.RelayPendingMessages(context.Background())
+
+	//Then
+	assert.Nil(t, firstErr)
+	assert.Nil(t, secondErr)
+	assert.Equal(t, []events.OrderEvent{firstEvent, secondEvent}, published)
+}
+
+func TestRelayPendingMessages_WhenPublishFails_RetriesWithBackoffKeepingOrderPerOrderNumber(t *testing.T) {
+	//Given
+	eventBus := events.NewEventBus()
+	failing := true
+	var published []string
+	eventBus.Subscribe(func(ctx context.Context, event events.OrderEvent) error {
+		if failing && event.Order.StatusId == int(enum.Transferred) {
+			return errors.New("subscriber is down")
+		}
+		published = append(published, event.Id)
+		return nil
+	})
+	failingEvent := newTestEvent("1", enum.Transferred)
+	followingEvent := newTestEvent("1", enum.Shipped)
+	otherOrderEvent := newTestEvent("2", enum.Shipped)
+	relay := newTestOutbox(t, eventBus, failingEvent, followingEvent, otherOrderEvent)
+	now := time.Now()
+	relay.now = func() time.Time { return now }
+
+	//When
+	_ = relay.RelayPendingMessages(context.Background())
+	publishedAfterFailure := append([]string(nil), published...)
+	failing = false
+	_ = relay.RelayPendingMessages(context.Background())
+	publishedBeforeBackoff := append([]string(nil), published...)
+	now = now.Add(testOutboxConfig.InitialBackoff)
+	_ = relay.RelayPendingMessages(context.Background())
+
+	//Then
+	assert.Equal(t, []string{otherOrderEvent.Id}, publishedAfterFailure)
+	assert.Equal(t, publishedAfterFailure, publishedBeforeBackoff)
+	assert.Equal(t, []string{otherOrderEvent.Id, failingEvent.Id, followingEvent.Id}, published)
+}
+
+func TestRelayPendingMessages_WhenMaxAttemptsAreReached_DeadLettersMessage(t *testing.T) {
+	//Given
+	mockOutboxRepository := &mocks.MockOutboxRepository{}
+	mockEventBus := &mocks.MockEventBus{}
+	outboxMessage := events.NewOutboxMessage(newTestEvent("1", enum.Transferred))
+	outboxMessage.Id = 7
+	outboxMessage.Attempts = testOutboxConfig.MaxAttempts - 1
+	mockOutboxRepository.On("FetchPendingMessages", mock.Anything, mock.Anything, mock.Anything).Return([]events.OutboxMessage{outboxMessage}, nil)
+	mockOutboxRepository.On("DeadLetterMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockEventBus.On("Publish", mock.Anything, mock.Anything).Return(errors.New("poison"))
+	relay := NewOutboxRelay(mockOutboxRepository, mockEventBus, testOutboxConfig)
+	spanRecorder := recordSpans(t)
+
+	//When
+	err := relay.RelayPendingMessages(context.Background())
+
+	//Then
+	assert.Nil(t, err)
+	mockOutboxRepository.AssertCalled(t, "DeadLetterMessage", mock.Anything, int64(7), "poison")
+	mockOutboxRepository.AssertNumberOfCalls(t, "RescheduleMessage", 0)
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Contains(t, spans[0].Events()[0].Attributes, attribute.String("exception.message", constants.OutboxMessageIsDeadLettered))
+	assert.Contains(t, spans[0].Events()[0].Attributes, attribute.Int64(constants.OutboxMessageId, 7))
+	assert.Contains(t, spans[0].Events()[0].Attributes, attribute.String(constants.PublishError, "poison"))
+}
+
+func TestRelayPendingMessages_WhenMessagesCannotBeFetched_RecordsErrorOnRelaySpan(t *testing.T) {
+	//Given
+	mockOutboxRepository := &mocks.MockOutboxRepository{}
+	fetchErr := response.NewErrorBuilder().
+		SetError(http.StatusInternalServerError, "test").
+		Build()
+	mockOutboxRepository.On("FetchPendingMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil, &fetchErr)
+	relay := NewOutboxRelay(mockOutboxRepository, &mocks.MockEventBus{}, testOutboxConfig)
+	spanRecorder := recordSpans(t)
+
+	//When
+	err := relay.RelayPendingMessages(context.Background())
+
+	//Then
+	assert.Equal(t, &fetchErr, err)
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "OutboxRelay.RelayPendingMessages", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Contains(t, spans[0].Events()[0].Attributes, attribute.String("exception.message", "test"))
+}
+
+func TestPruneMessages_RemovesFinishedMessagesAfterRetention(t *testing.T) {
+	//Given
+	testOutboxConfig := testOutboxConfig
+	testOutboxConfig.Retention = time.Hour
+	database := repositories.NewInMemoryDatabase()
+	orderRepository := repositories.NewOrderRepository(database)
+	for _, event := range []events.OrderEvent{newTestEvent("1", enum.Transferred), newTestEvent("2", enum.Shipped)} {
+		assert.Nil(t, orderRepository.UpdateOrderStatus(context.Background(), event.OrderNumber, enum.OrderStatus(event.Order.StatusId), event))
+	}
+	outboxRepository := repositories.NewOutboxRepository(database)
+	relay := NewOutboxRelay(outboxRepository, events.NewEventBus(), testOutboxConfig).(*OutboxRelayImp)
+	assert.Nil(t, outboxRepository.MarkMessagePublished(context.Background(), 1))
+	now := time.Now()
+
+	//When
+	relay.now = func() time.Time { return now }
+	keptErr := relay.PruneMessages(context.Background())
+	keptMessages, _ := outboxRepository.FetchPendingMessages(context.Background(), now, 10)
+	relay.now = func() time.Time { return now.Add(testOutboxConfig.Retention + time.Minute) }
+	prunedErr := relay.PruneMessages(context.Background())
+	publishErr := outboxRepository.MarkMessagePublished(context.Background(), 2)
+	pendingMessages, _ := outboxRepository.FetchPendingMessages(context.Background(), now, 10)
+
+	//Then
+	assert.Nil(t, keptErr)
+	assert.Nil(t, prunedErr)
+	assert.Nil(t, publishErr)
+	assert.Len(t, keptMessages, 1)
+	assert.Empty(t, pendingMessages)
+	pruned, _ := outboxRepository.PruneMessages(context.Background(), now.Add(time.Minute))
+	assert.Equal(t, 1, pruned)
+}
+
+func TestExponentialBackoff_DoublesUpToMaxBackoff(t *testing.T) {
+	//Given
+	backoff := func(attempts int) time.Duration {
+		return exponentialBackoff(testOutboxConfig.InitialBackoff, testOutboxConfig.MaxBackoff, attempts)
+	}
+
+	//When
+	backoffs := []time.Duration{backoff(1), backoff(2), backoff(3), backoff(10)}
+
+	//Then
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, time.Minute}, backoffs)
+}
+
+func TestCreateOrder_StoresOrderAndOutboxMessageTogether(t *testing.T) {
+	//Given
+	database := repositories.NewInMemoryDatabase()
+	service := newTestOrderService(repositories.NewOrderRepository(database))
+	createOrderRequest := request.CreateOrderRequest{OrderNumber: "100", FirstName: "Test", CurrencyCode: "TRY"}
+
+	//When
+	err := service.CreateOrder(context.Background(), createOrderRequest)
+	outboxMessages, _ := repositories.NewOutboxRepository(database).FetchPendingMessages(context.Background(), time.Now(), 10)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, outboxMessages, 1)
+	assert.Equal(t, enum.OrderCreated, outboxMessages[0].Event.Type)
+	assert.Equal(t, "100", outboxMessages[0].Event.OrderNumber)
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"strings"
+	"time"
+)
+
+//go:generate mockery --name=ProductService --structname=MockProductService --output=../mocks --filename=fakeProductServiceWithMockery.go
+type ProductService interface {
+	// GetProducts lists the active products, and the inactive ones too for callers who
+	// may change the catalog.
+	GetProducts(ctx context.Context) ([]response.Product, *response.ErrorResponse)
+	GetProduct(ctx context.Context, sku string) (*response.Product, *response.ErrorResponse)
+	CreateProduct(ctx context.Context, createProductRequest request.CreateProductRequest) (*response.Product, *response.ErrorResponse)
+	UpdateProduct(ctx context.Context, sku string, updateProductRequest request.UpdateProductRequest) (*response.Product, *response.ErrorResponse)
+}
+
+type ProductServiceImp struct {
+	productRepository repositories.ProductRepository
+	now               func() time.Time
+}
+
+func (p *ProductServiceImp) GetProducts(ctx context.Context) (products []response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProducts")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	products, errorResp = p.productRepository.FetchProducts(ctx)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if policies.HasPermission(helpers.GetPrincipal(ctx), policies.WriteProducts) {
+		return products, nil
+	}
+
+	activeProducts := make([]response.Product, 0, len(products))
+	for _, product := range products {
+		if product.Active {
+			activeProducts = append(activeProducts, product)
+		}
+	}
+	return activeProducts, nil
+}
+
+func (p *ProductServiceImp) GetProduct(ctx context.Context, sku string) (_ *response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProduct")
+	span.SetAttributes(attribute.String(constants.Sku, sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return p.getProduct(ctx, sku)
+}
+
+func (p *ProductServiceImp) CreateProduct(ctx context.Context, createProductRequest request.CreateProductRequest) (_ *response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductService.CreateProduct")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	product := createProductRequest.Product()
+	span.SetAttributes(attribute.String(constants.Sku, product.Sku))
+	product.CreatedAt = p.now()
+	product.UpdatedAt = product.CreatedAt
+	if errorResp := p.productRepository.CreateProduct(ctx, product); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &product, nil
+}
+
+func (p *ProductServiceImp) UpdateProduct(ctx context.Context, sku string, updateProductRequest request.UpdateProductRequest) (_ *response.Product, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "ProductService.UpdateProduct")
+	span.SetAttributes(attribute.String(constants.Sku, sku))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	existingProduct, errorResp := p.getProduct(ctx, sku)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	product := updateProductRequest.Apply(*existingProduct)
+	product.UpdatedAt = p.now()
+	if errorResp := p.productRepository.UpdateProduct(ctx, product); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &product, nil
+}
+
+// getProduct looks products up by their upper cased SKU, so that "tshirt-blk-m" finds
+// "TSHIRT-BLK-M".
+func (p *ProductServiceImp) getProduct(ctx context.Context, sku string) (*response.Product, *response.ErrorResponse) {
+	product, errorResp := p.productRepository.FetchProductBySku(ctx, strings.ToUpper(sku))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if product == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.ProductNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return product, nil
+}
+
+func NewProductService(productRepository repositories.ProductRepository) ProductService {
+	return &ProductServiceImp{
+		productRepository: productRepository,
+		now:               time.Now,
+	}
+}
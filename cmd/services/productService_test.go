@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"testing"
+)
+
+func TestGetProducts_WhenPrincipalCanNotChangeCatalog_ReturnsOnlyActiveProducts(t *testing.T) {
+	//Given
+	service := NewProductService(repositories.NewProductRepository())
+
+	//When
+	products, err := service.GetProducts(withCustomerPrincipal("customer-1"))
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, products, 2)
+	for _, product := range products {
+		assert.True(t, product.Active)
+	}
+}
+
+func TestGetProducts_WhenAuthenticationIsDisabled_ReturnsInactiveProductsToo(t *testing.T) {
+	//Given
+	service := NewProductService(repositories.NewProductRepository())
+
+	//When
+	products, err := service.GetProducts(context.Background())
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, products, 3)
+}
+
+func TestGetProduct_MatchesSkuRegardlessOfCase(t *testing.T) {
+	//Given
+	service := NewProductService(repositories.NewProductRepository())
+
+	//When
+	product, err := service.GetProduct(context.Background(), "mug-wht")
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "MUG-WHT", product.Sku)
+}
+
+func TestGetProduct_WhenProductDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewProductService(repositories.NewProductRepository())
+
+	//When
+	product, err := service.GetProduct(context.Background(), "NOTHING")
+
+	//Then
+	assert.Nil(t, product)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.ProductNotFound, err.Message)
+}
+
+func TestCreateProduct_StoresNormalizedActiveProduct(t *testing.T) {
+	//Given
+	productRepository := repositories.NewProductRepository()
+	service := NewProductService(productRepository)
+	createProductRequest := request.CreateProductRequest{
+		Sku:    " cap-red ",
+		Name:   " Red Cap ",
+		Prices: []response.Price{{CurrencyCode: "try", Amount: 199.9}},
+	}
+
+	//When
+	product, err := service.CreateProduct(context.Background(), createProductRequest)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "CAP-RED", product.Sku)
+	assert.Equal(t, "Red Cap", product.Name)
+	assert.Equal(t, []response.Price{{CurrencyCode: "TRY", Amount: 199.9}}, product.Prices)
+	assert.True(t, product.Active)
+	storedProduct, _ := productRepository.FetchProductBySku(context.Background(), "CAP-RED")
+	assert.Equal(t, product, storedProduct)
+}
+
+func TestCreateProduct_WhenSkuIsTaken_ReturnsConflict(t *testing.T) {
+	//Given
+	service := NewProductService(repositories.NewProductRepository())
+	createProductRequest := request.CreateProductRequest{
+		Sku:    "mug-wht",
+		Name:   "Another Mug",
+		Prices: []response.Price{{CurrencyCode: "EUR", Amount: 5}},
+	}
+
+	//When
+	product, err := service.CreateProduct(context.Background(), createProductRequest)
+
+	//Then
+	assert.Nil(t, product)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.SameProductFoundBySku, err.Message)
+}
+
+func TestUpdateProduct_ReplacesFieldsAndKeepsActiveFlagWhenMissing(t *testing.T) {
+	//Given
+	productRepository := repositories.NewProductRepository()
+	service := NewProductService(productRepository)
+	existingProduct, _ := productRepository.FetchProductBySku(context.Background(), "POSTER-A2")
+	updateProductRequest := request.UpdateProductRequest{
+		Name:   "Poster, A2",
+		Prices: []response.Price{{CurrencyCode: "EUR", Amount: 4.95}},
+	}
+
+	//When
+	product, err := service.UpdateProduct(context.Background(), "POSTER-A2", updateProductRequest)
+
+	//Then
+	assert.Nil(t, err)
+	assert.False(t, product.Active)
+	assert.Empty(t, product.Description)
+	assert.Equal(t, []response.Price{{CurrencyCode: "EUR", Amount: 4.95}}, product.Prices)
+	assert.Equal(t, existingProduct.CreatedAt, product.CreatedAt)
+	assert.True(t, product.UpdatedAt.After(existingProduct.UpdatedAt))
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"time"
+)
+
+//go:generate mockery --name=PromotionService --structname=MockPromotionService --output=../mocks --filename=fakePromotionServiceWithMockery.go
+type PromotionService interface {
+	GetPromotions(ctx context.Context) ([]response.Promotion, *response.ErrorResponse)
+	GetPromotion(ctx context.Context, code string) (*response.Promotion, *response.ErrorResponse)
+	CreatePromotion(ctx context.Context, createPromotionRequest request.CreatePromotionRequest) (*response.Promotion, *response.ErrorResponse)
+	UpdatePromotion(ctx context.Context, code string, updatePromotionRequest request.UpdatePromotionRequest) (*response.Promotion, *response.ErrorResponse)
+}
+
+type PromotionServiceImp struct {
+	promotionRepository repositories.PromotionRepository
+	now                 func() time.Time
+}
+
+func (p *PromotionServiceImp) GetPromotions(ctx context.Context) (_ []response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionService.GetPromotions")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return p.promotionRepository.FetchPromotions(ctx)
+}
+
+func (p *PromotionServiceImp) GetPromotion(ctx context.Context, code string) (_ *response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionService.GetPromotion")
+	span.SetAttributes(attribute.String(constants.PromotionCode, code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	return p.getPromotion(ctx, code)
+}
+
+func (p *PromotionServiceImp) CreatePromotion(ctx context.Context, createPromotionRequest request.CreatePromotionRequest) (_ *response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionService.CreatePromotion")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	promotion := createPromotionRequest.Promotion()
+	span.SetAttributes(attribute.String(constants.PromotionCode, promotion.Code))
+	promotion.CreatedAt = p.now()
+	promotion.UpdatedAt = promotion.CreatedAt
+	if errorResp := p.promotionRepository.CreatePromotion(ctx, promotion); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &promotion, nil
+}
+
+func (p *PromotionServiceImp) UpdatePromotion(ctx context.Context, code string, updatePromotionRequest request.UpdatePromotionRequest) (_ *response.Promotion, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "PromotionService.UpdatePromotion")
+	span.SetAttributes(attribute.String(constants.PromotionCode, code))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	existingPromotion, errorResp := p.getPromotion(ctx, code)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	promotion := updatePromotionRequest.Apply(*existingPromotion)
+	promotion.UpdatedAt = p.now()
+	if errorResp := p.promotionRepository.UpdatePromotion(ctx, promotion); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &promotion, nil
+}
+
+func (p *PromotionServiceImp) getPromotion(ctx context.Context, code string) (*response.Promotion, *response.ErrorResponse) {
+	promotion, errorResp := p.promotionRepository.FetchPromotionByCode(ctx, request.NormalizePromotionCode(code))
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if promotion == nil {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.PromotionNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return promotion, nil
+}
+
+func NewPromotionService(promotionRepository repositories.PromotionRepository) PromotionService {
+	return &PromotionServiceImp{
+		promotionRepository: promotionRepository,
+		now:                 time.Now,
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/repositories"
+	"testing"
+	"time"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	//Given
+	now := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	service := &PromotionServiceImp{promotionRepository: repositories.NewPromotionRepository(), now: func() time.Time { return now }}
+	createPromotionRequest := request.CreatePromotionRequest{Code: " spring15 ", Name: "Spring", Type: "Percentage", Value: 15, CurrencyCode: "eur"}
+
+	//When
+	promotion, err := service.CreatePromotion(context.Background(), createPromotionRequest)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, &response.Promotion{
+		Code: "SPRING15", Name: "Spring", Type: "percentage", Value: 15, CurrencyCode: "EUR",
+		Active: true, CreatedAt: now, UpdatedAt: now,
+	}, promotion)
+}
+
+func TestCreatePromotion_WhenCodeIsTaken_ReturnsConflict(t *testing.T) {
+	//Given
+	service := NewPromotionService(repositories.NewPromotionRepository())
+
+	//When
+	promotion, err := service.CreatePromotion(context.Background(), request.CreatePromotionRequest{Code: "welcome10", Name: "Welcome", Type: "percentage", Value: 5})
+
+	//Then
+	assert.Nil(t, promotion)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, constants.SamePromotionFoundByCode, err.Message)
+}
+
+func TestUpdatePromotion_KeepsCodeAndKindOfPromotion(t *testing.T) {
+	//Given
+	promotionRepository := repositories.NewPromotionRepository()
+	service := NewPromotionService(promotionRepository)
+	active := false
+
+	//When
+	promotion, err := service.UpdatePromotion(context.Background(), "try1000", request.UpdatePromotionRequest{
+		Name: "7% off baskets from 1000 TRY", Type: "percentage", Value: 7, CurrencyCode: "TRY", MinBasketAmount: 1000, Active: &active,
+	})
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, "TRY1000", promotion.Code)
+	assert.True(t, promotion.Automatic)
+	storedPromotion, _ := promotionRepository.FetchPromotionByCode(context.Background(), "TRY1000")
+	assert.Equal(t, float32(7), storedPromotion.Value)
+	assert.False(t, storedPromotion.Active)
+}
+
+func TestGetPromotion_WhenPromotionDoesNotExist_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewPromotionService(repositories.NewPromotionRepository())
+
+	//When
+	promotion, err := service.GetPromotion(context.Background(), "NOPE10")
+
+	//Then
+	assert.Nil(t, promotion)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.PromotionNotFound, err.Message)
+}
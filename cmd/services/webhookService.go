@@ -0,0 +1,360 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const webhookSecretPrefix = "whsec_"
+
+//go:generate mockery --name=WebhookService --structname=MockWebhookService --output=../mocks --filename=fakeWebhookServiceWithMockery.go
+type WebhookService interface {
+	GetWebhooks(ctx context.Context) ([]response.Webhook, *response.ErrorResponse)
+	CreateWebhook(ctx context.Context, createWebhookRequest request.CreateWebhookRequest) (*response.CreatedWebhook, *response.ErrorResponse)
+	DeleteWebhook(ctx context.Context, id string) *response.ErrorResponse
+	GetDeliveries(ctx context.Context, webhookId string) ([]response.WebhookDelivery, *response.ErrorResponse)
+	// RedeliverDelivery sends the logged payload once more, right away.
+	RedeliverDelivery(ctx context.Context, webhookId string, deliveryId string) (*response.WebhookDelivery, *response.ErrorResponse)
+	// HandleEvent starts delivering the event to every webhook subscribed to its type
+	// whose owner can read the order.
+	HandleEvent(ctx context.Context, event events.OrderEvent) error
+	// Close cancels pending retries and waits for running deliveries.
+	Close()
+}
+
+type WebhookServiceImp struct {
+	webhookRepository repositories.WebhookRepository
+	httpClient        *http.Client
+	resolver          *net.Resolver
+	config            models.WebhookConfig
+	now               func() time.Time
+	stop              chan struct{}
+	stopOnce          sync.Once
+	waitGroup         sync.WaitGroup
+}
+
+func (w *WebhookServiceImp) GetWebhooks(ctx context.Context) (webhooks []response.Webhook, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetWebhooks")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	webhooks, errorResp = w.webhookRepository.FetchWebhooks(ctx)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	principal := helpers.GetPrincipal(ctx)
+	ownedWebhooks := make([]response.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if isOwnedBy(webhook, principal) {
+			ownedWebhooks = append(ownedWebhooks, webhook)
+		}
+	}
+	return ownedWebhooks, nil
+}
+
+func (w *WebhookServiceImp) CreateWebhook(ctx context.Context, createWebhookRequest request.CreateWebhookRequest) (_ *response.CreatedWebhook, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.CreateWebhook")
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if !w.config.AllowInternalHosts {
+		internal, err := resolvesToInternalIp(ctx, w.resolver, createWebhookRequest.Url)
+		if err != nil || internal {
+			errorResp := response.NewErrorBuilder().
+				SetError(http.StatusBadRequest, constants.WebhookUrlIsNotValid).
+				Build()
+			return nil, &errorResp
+		}
+	}
+
+	id, err := randomBytes(8)
+	if err != nil {
+		return nil, internalError()
+	}
+
+	secret := createWebhookRequest.Secret
+	if len(secret) == 0 {
+		secretBytes, err := randomBytes(24)
+		if err != nil {
+			return nil, internalError()
+		}
+		secret = webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(secretBytes)
+	}
+
+	webhook := response.Webhook{
+		Id:         hex.EncodeToString(id),
+		Url:        createWebhookRequest.Url,
+		EventTypes: createWebhookRequest.EventTypes,
+		Secret:     secret,
+		Owner:      helpers.GetPrincipal(ctx),
+		CreatedAt:  w.now(),
+	}
+	if errorResp := w.webhookRepository.SaveWebhook(ctx, webhook); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return &response.CreatedWebhook{Webhook: webhook, Secret: secret}, nil
+}
+
+func (w *WebhookServiceImp) DeleteWebhook(ctx context.Context, id string) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteWebhook")
+	span.SetAttributes(attribute.String(constants.WebhookId, id))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if _, errorResp := w.getWebhook(ctx, id); errorResp != nil {
+		return errorResp
+	}
+
+	return w.webhookRepository.DeleteWebhook(ctx, id)
+}
+
+func (w *WebhookServiceImp) GetDeliveries(ctx context.Context, webhookId string) (deliveries []response.WebhookDelivery, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDeliveries")
+	span.SetAttributes(attribute.String(constants.WebhookId, webhookId))
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	if _, errorResp := w.getWebhook(ctx, webhookId); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return w.webhookRepository.FetchDeliveries(ctx, webhookId)
+}
+
+func (w *WebhookServiceImp) RedeliverDelivery(ctx context.Context, webhookId string, deliveryId string) (_ *response.WebhookDelivery, errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.RedeliverDelivery")
+	span.SetAttributes(
+		attribute.String(constants.WebhookId, webhookId),
+		attribute.String(constants.DeliveryId, deliveryId),
+	)
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	webhook, errorResp := w.getWebhook(ctx, webhookId)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	delivery, errorResp := w.webhookRepository.FetchDeliveryById(ctx, deliveryId)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if delivery == nil || delivery.WebhookId != webhookId {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.WebhookDeliveryNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	if errorResp := w.attempt(ctx, *webhook, delivery); errorResp != nil {
+		return nil, errorResp
+	}
+
+	return delivery, nil
+}
+
+func (w *WebhookServiceImp) HandleEvent(ctx context.Context, event events.OrderEvent) error {
+	webhooks, errorResp := w.webhookRepository.FetchWebhooks(ctx)
+	if errorResp != nil {
+		return fmt.Errorf("webhooks could not be fetched: %s", errorResp.Message)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !isSubscribed(webhook, event.Type) || !policies.CanAccessOrder(webhook.Owner, event.Order.CustomerId) {
+			continue
+		}
+
+		id, err := randomBytes(8)
+		if err != nil {
+			return err
+		}
+
+		delivery := response.WebhookDelivery{
+			Id:        hex.EncodeToString(id),
+			WebhookId: webhook.Id,
+			EventId:   event.Id,
+			EventType: string(event.Type),
+			Payload:   payload,
+			Status:    string(enum.PendingDeliveryStatus),
+			CreatedAt: w.now(),
+		}
+		if errorResp := w.webhookRepository.SaveDelivery(ctx, delivery); errorResp != nil {
+			return fmt.Errorf("webhook delivery could not be saved: %s", errorResp.Message)
+		}
+
+		w.waitGroup.Add(1)
+		go w.deliver(trace.SpanContextFromContext(ctx), webhook, delivery)
+	}
+
+	return nil
+}
+
+func (w *WebhookServiceImp) Close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	w.waitGroup.Wait()
+}
+
+// deliver retries the delivery with an exponential backoff until the receiver accepts
+// it or the attempts run out. It gives up when an attempt cannot be saved.
+func (w *WebhookServiceImp) deliver(spanContext trace.SpanContext, webhook response.Webhook, delivery response.WebhookDelivery) {
+	defer w.waitGroup.Done()
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), spanContext)
+
+	for delivery.Attempts < w.config.MaxAttempts {
+		if errorResp := w.attempt(ctx, webhook, &delivery); errorResp != nil {
+			return
+		}
+
+		if delivery.Status == string(enum.SucceededDeliveryStatus) || delivery.Attempts >= w.config.MaxAttempts {
+			return
+		}
+
+		select {
+		case <-w.stop:
+			return
+		case <-time.After(exponentialBackoff(w.config.InitialBackoff, w.config.MaxBackoff, delivery.Attempts)):
+		}
+	}
+}
+
+// attempt posts the payload once and logs the outcome on the delivery. A failure to
+// save it is recorded on the span of the attempt.
+func (w *WebhookServiceImp) attempt(ctx context.Context, webhook response.Webhook, delivery *response.WebhookDelivery) (errorResp *response.ErrorResponse) {
+	ctx, span := tracer.Start(ctx, "WebhookService.Deliver")
+	span.SetAttributes(
+		attribute.String(constants.WebhookId, webhook.Id),
+		attribute.String(constants.DeliveryId, delivery.Id),
+	)
+	defer func() { helpers.EndSpan(span, errorResp) }()
+
+	attemptedAt := w.now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &attemptedAt
+	delivery.ResponseStatusCode = 0
+	delivery.LastError = ""
+	delivery.Status = string(enum.FailedDeliveryStatus)
+
+	statusCode, err := w.post(ctx, webhook, *delivery, attemptedAt)
+	delivery.ResponseStatusCode = statusCode
+	switch {
+	case err != nil:
+		delivery.LastError = err.Error()
+	case statusCode < 200 || statusCode > 299:
+		delivery.LastError = "receiver responded with " + http.StatusText(statusCode)
+	default:
+		delivery.Status = string(enum.SucceededDeliveryStatus)
+	}
+
+	if errorResp = w.webhookRepository.SaveDelivery(ctx, *delivery); errorResp != nil {
+		helpers.RecordError(ctx, errorResp)
+	}
+	return errorResp
+}
+
+func (w *WebhookServiceImp) post(ctx context.Context, webhook response.Webhook, delivery response.WebhookDelivery, sentAt time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.WebhookEventHeader, delivery.EventType)
+	req.Header.Set(constants.WebhookDeliveryHeader, delivery.Id)
+	req.Header.Set(constants.WebhookTimestampHeader, timestamp)
+	req.Header.Set(constants.WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// getWebhook answers webhooks of other owners as not found, so that their ids are not disclosed.
+func (w *WebhookServiceImp) getWebhook(ctx context.Context, id string) (*response.Webhook, *response.ErrorResponse) {
+	webhook, errorResp := w.webhookRepository.FetchWebhookById(ctx, id)
+	if errorResp != nil {
+		return nil, errorResp
+	}
+
+	if webhook == nil || !isOwnedBy(*webhook, helpers.GetPrincipal(ctx)) {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusNotFound, constants.WebhookNotFound).
+			Build()
+		return nil, &errorResp
+	}
+
+	return webhook, nil
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value receivers use to verify a
+// delivery: the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+func SignWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isOwnedBy reports whether the principal registered the webhook. A nil principal means
+// authentication is disabled, so every webhook is visible.
+func isOwnedBy(webhook response.Webhook, principal *models.Principal) bool {
+	if principal == nil {
+		return true
+	}
+
+	return webhook.Owner != nil && webhook.Owner.Type == principal.Type && webhook.Owner.Subject == principal.Subject
+}
+
+func isSubscribed(webhook response.Webhook, eventType enum.EventType) bool {
+	for _, subscribed := range webhook.EventTypes {
+		if subscribed == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewWebhookService(webhookRepository repositories.WebhookRepository, config models.WebhookConfig) WebhookService {
+	return &WebhookServiceImp{
+		webhookRepository: webhookRepository,
+		httpClient:        newWebhookHttpClient(config.AllowInternalHosts),
+		resolver:          net.DefaultResolver,
+		config:            config,
+		now:               time.Now,
+		stop:              make(chan struct{}),
+	}
+}
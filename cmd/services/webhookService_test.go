@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"simple-order-api/cmd/constants"
+	enum "simple-order-api/cmd/enums"
+	"simple-order-api/cmd/events"
+	"simple-order-api/cmd/helpers"
+	"simple-order-api/cmd/models"
+	"simple-order-api/cmd/models/request"
+	"simple-order-api/cmd/models/response"
+	"simple-order-api/cmd/policies"
+	"simple-order-api/cmd/repositories"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testWebhookConfig = models.WebhookConfig{
+	Timeout:        time.Second,
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	// the receivers of the tests listen on 127.0.0.1
+	AllowInternalHosts: true,
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookReceiver answers deliveries with the given status codes in turn, repeating
+// the last one, and records what it received.
+func newWebhookReceiver(t *testing.T, statusCodes ...int) (*httptest.Server, func() []receivedWebhook) {
+	var mutex sync.Mutex
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mutex.Lock()
+		received = append(received, receivedWebhook{header: r.Header, body: body})
+		statusCode := statusCodes[len(statusCodes)-1]
+		if len(received) <= len(statusCodes) {
+			statusCode = statusCodes[len(received)-1]
+		}
+		mutex.Unlock()
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedWebhook {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]receivedWebhook(nil), received...)
+	}
+}
+
+// waitForDeliveries waits until every delivery succeeded or ran out of attempts, unlike
+// Close which cancels pending retries.
+func waitForDeliveries(service WebhookService) {
+	service.(*WebhookServiceImp).waitGroup.Wait()
+}
+
+func createTestWebhook(t *testing.T, service WebhookService, url string, eventTypes ...enum.EventType) *response.CreatedWebhook {
+	return createOwnedTestWebhook(t, context.Background(), service, url, eventTypes...)
+}
+
+func createOwnedTestWebhook(t *testing.T, ctx context.Context, service WebhookService, url string, eventTypes ...enum.EventType) *response.CreatedWebhook {
+	createWebhookRequest := request.CreateWebhookRequest{Url: url}
+	for _, eventType := range eventTypes {
+		createWebhookRequest.EventTypes = append(createWebhookRequest.EventTypes, string(eventType))
+	}
+
+	webhook, errorResp := service.CreateWebhook(ctx, createWebhookRequest)
+	require.Nil(t, errorResp)
+	return webhook
+}
+
+func withApiKeyPrincipal(id string, scopes ...string) context.Context {
+	return helpers.WithPrincipal(context.Background(), &models.Principal{Type: models.ApiKeyPrincipal, Subject: id, Scopes: scopes})
+}
+
+func TestHandleEvent_DeliversSignedPayloadToSubscribedWebhooks(t *testing.T) {
+	//Given
+	service := NewWebhookService(repositories.NewWebhookRepository(), testWebhookConfig)
+	subscribedServer, subscribedReceived := newWebhookReceiver(t, http.StatusOK)
+	otherServer, otherReceived := newWebhookReceiver(t, http.StatusOK)
+	webhook := createTestWebhook(t, service, subscribedServer.URL, enum.OrderStatusChanged)
+	createTestWebhook(t, service, otherServer.URL, enum.OrderCreated)
+	event := events.NewOrderEvent(enum.OrderStatusChanged, response.Order{OrderNumber: "1", StatusId: int(enum.Shipped)})
+
+	//When
+	err := service.HandleEvent(context.Background(), event)
+	waitForDeliveries(service)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Empty(t, otherReceived())
+	received := subscribedReceived()
+	require.Len(t, received, 1)
+	assert.Contains(t, string(received[0].body), `"orderNumber":"1"`)
+	assert.Equal(t, string(enum.OrderStatusChanged), received[0].header.Get(constants.WebhookEventHeader))
+	timestamp := received[0].header.Get(constants.WebhookTimestampHeader)
+	assert.Equal(t, SignWebhookPayload(webhook.Secret, timestamp, received[0].body), received[0].header.Get(constants.WebhookSignatureHeader))
+	assert.True(t, strings.HasPrefix(webhook.Secret, webhookSecretPrefix))
+}
+
+func TestHandleEvent_WhenReceiverFails_RetriesAndLogsEveryAttempt(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCodes      []int
+		expectedAttempts int
+		expectedStatus   enum.DeliveryStatus
+	}{
+		{"recovering receiver", []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent}, 3, enum.SucceededDeliveryStatus},
+		{"failing receiver", []int{http.StatusServiceUnavailable}, testWebhookConfig.MaxAttempts, enum.FailedDeliveryStatus},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//Given
+			service := NewWebhookService(repositories.NewWebhookRepository(), testWebhookConfig)
+			server, received := newWebhookReceiver(t, test.statusCodes...)
+			webhook := createTestWebhook(t, service, server.URL, enum.OrderCreated)
+
+			//When
+			err := service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+			waitForDeliveries(service)
+			deliveries, deliveriesErr := service.GetDeliveries(context.Background(), webhook.Id)
+
+			//Then
+			assert.Nil(t, err)
+			assert.Nil(t, deliveriesErr)
+			assert.Len(t, received(), test.expectedAttempts)
+			require.Len(t, deliveries, 1)
+			assert.Equal(t, test.expectedAttempts, deliveries[0].Attempts)
+			assert.Equal(t, string(test.expectedStatus), deliveries[0].Status)
+			assert.Equal(t, test.statusCodes[len(test.statusCodes)-1], deliveries[0].ResponseStatusCode)
+		})
+	}
+}
+
+// unsavableDeliveryWebhookRepository saves the first delivery and fails every later save.
+type unsavableDeliveryWebhookRepository struct {
+	repositories.WebhookRepository
+	saves int
+}
+
+func (repository *unsavableDeliveryWebhookRepository) SaveDelivery(ctx context.Context, delivery response.WebhookDelivery) *response.ErrorResponse {
+	repository.saves++
+	if repository.saves > 1 {
+		errorResp := response.NewErrorBuilder().
+			SetError(http.StatusInternalServerError, "test").
+			Build()
+		return &errorResp
+	}
+
+	return repository.WebhookRepository.SaveDelivery(ctx, delivery)
+}
+
+func TestHandleEvent_WhenAttemptCannotBeSaved_RecordsErrorOnDeliverySpanAndStops(t *testing.T) {
+	//Given
+	spanRecorder := recordSpans(t)
+	service := NewWebhookService(&unsavableDeliveryWebhookRepository{WebhookRepository: repositories.NewWebhookRepository()}, testWebhookConfig)
+	server, received := newWebhookReceiver(t, http.StatusInternalServerError)
+	createTestWebhook(t, service, server.URL, enum.OrderCreated)
+
+	//When
+	err := service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+	waitForDeliveries(service)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, received(), 1)
+	var deliverySpans []sdktrace.ReadOnlySpan
+	for _, span := range spanRecorder.Ended() {
+		if span.Name() == "WebhookService.Deliver" {
+			deliverySpans = append(deliverySpans, span)
+		}
+	}
+	require.Len(t, deliverySpans, 1)
+	assert.Equal(t, codes.Error, deliverySpans[0].Status().Code)
+	require.Len(t, deliverySpans[0].Events(), 1)
+	assert.Equal(t, "exception", deliverySpans[0].Events()[0].Name)
+}
+
+func TestRedeliverDelivery_SendsLoggedPayloadAgain(t *testing.T) {
+	//Given
+	config := testWebhookConfig
+	config.MaxAttempts = 1
+	service := NewWebhookService(repositories.NewWebhookRepository(), config)
+	server, received := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusOK)
+	webhook := createTestWebhook(t, service, server.URL, enum.OrderDeleted)
+	_ = service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderDeleted, response.Order{OrderNumber: "1"}))
+	waitForDeliveries(service)
+	deliveries, _ := service.GetDeliveries(context.Background(), webhook.Id)
+
+	//When
+	delivery, err := service.RedeliverDelivery(context.Background(), webhook.Id, deliveries[0].Id)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Equal(t, string(enum.SucceededDeliveryStatus), delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	require.Len(t, received(), 2)
+	assert.Equal(t, received()[0].body, received()[1].body)
+}
+
+func TestRedeliverDelivery_WhenDeliveryBelongsToAnotherWebhook_ReturnsNotFound(t *testing.T) {
+	//Given
+	service := NewWebhookService(repositories.NewWebhookRepository(), testWebhookConfig)
+	server, _ := newWebhookReceiver(t, http.StatusOK)
+	webhook := createTestWebhook(t, service, server.URL, enum.OrderCreated)
+	otherWebhook := createTestWebhook(t, service, server.URL, enum.OrderCreated)
+	_ = service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+	waitForDeliveries(service)
+	deliveries, _ := service.GetDeliveries(context.Background(), otherWebhook.Id)
+
+	//When
+	_, err := service.RedeliverDelivery(context.Background(), webhook.Id, deliveries[0].Id)
+
+	//Then
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	assert.Equal(t, constants.WebhookDeliveryNotFound, err.Message)
+}
+
+func TestHandleEvent_DeliversOnlyEventsOfOrdersTheOwnerCanRead(t *testing.T) {
+	//Given
+	service := NewWebhookService(repositories.NewWebhookRepository(), testWebhookConfig)
+	readerServer, readerReceived := newWebhookReceiver(t, http.StatusOK)
+	managerServer, managerReceived := newWebhookReceiver(t, http.StatusOK)
+	createOwnedTestWebhook(t, withApiKeyPrincipal("reader", policies.WebhooksScope, policies.ReadOrdersScope), service, readerServer.URL, enum.OrderCreated)
+	createOwnedTestWebhook(t, withApiKeyPrincipal("manager", policies.WebhooksScope), service, managerServer.URL, enum.OrderCreated)
+
+	//When
+	err := service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1", CustomerId: "customer-1"}))
+	waitForDeliveries(service)
+
+	//Then
+	assert.Nil(t, err)
+	assert.Len(t, readerReceived(), 1)
+	assert.Empty(t, managerReceived())
+}
+
+func TestWebhooks_AreVisibleOnlyToTheirOwner(t *testing.T) {
+	//Given
+	service := NewWebhookService(repositories.NewWebhookRepository(), testWebhookConfig)
+	server, _ := newWebhookReceiver(t, http.StatusOK)
+	owner := withApiKeyPrincipal("owner", policies.WebhooksScope, policies.ReadOrdersScope)
+	other := withApiKeyPrincipal("other", policies.WebhooksScope)
+	webhook := createOwnedTestWebhook(t, owner, service, server.URL, enum.OrderCreated)
+	_ = service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+	waitForDeliveries(service)
+	deliveries, _ := service.GetDeliveries(owner, webhook.Id)
+
+	//When
+	ownerWebhooks, _ := service.GetWebhooks(owner)
+	otherWebhooks, _ := service.GetWebhooks(other)
+	_, deliveriesErr := service.GetDeliveries(other, webhook.Id)
+	_, redeliverErr := service.RedeliverDelivery(other, webhook.Id, deliveries[0].Id)
+	deleteErr := service.DeleteWebhook(other, webhook.Id)
+
+	//Then
+	assert.Len(t, ownerWebhooks, 1)
+	assert.Empty(t, otherWebhooks)
+	for _, err := range []*response.ErrorResponse{deliveriesErr, redeliverErr, deleteErr} {
+		require.NotNil(t, err)
+		assert.Equal(t, constants.WebhookNotFound, err.Message)
+	}
+}
+
+func TestCreateWebhook_WhenUrlResolvesToInternalAddress_ReturnsBadRequest(t *testing.T) {
+	urls := []string{"http://127.0.0.1:8080/orders", "http://localhost/orders", "http://169.254.169.254/latest/meta-data", "https://10.1.2.3/orders"}
+
+	for _, url := range urls {
+		t.Run(url, func(t *testing.T) {
+			//Given
+			config := testWebhookConfig
+			config.AllowInternalHosts = false
+			service := NewWebhookService(repositories.NewWebhookRepository(), config)
+
+			//When
+			_, err := service.CreateWebhook(context.Background(), request.CreateWebhookRequest{Url: url, EventTypes: []string{string(enum.OrderCreated)}})
+
+			//Then
+			require.NotNil(t, err)
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, constants.WebhookUrlIsNotValid, err.Message)
+		})
+	}
+}
+
+func TestHandleEvent_WhenWebhookHostIsInternal_RefusesToConnect(t *testing.T) {
+	//Given
+	config := testWebhookConfig
+	config.AllowInternalHosts = false
+	config.MaxAttempts = 1
+	webhookRepository := repositories.NewWebhookRepository()
+	service := NewWebhookService(webhookRepository, config)
+	server, received := newWebhookReceiver(t, http.StatusOK)
+	// stored directly, like a host that resolved to a public address when it was registered
+	webhook := response.Webhook{Id: "webhook-1", Url: server.URL, EventTypes: []string{string(enum.OrderCreated)}}
+	require.Nil(t, webhookRepository.SaveWebhook(context.Background(), webhook))
+
+	//When
+	_ = service.HandleEvent(context.Background(), events.NewOrderEvent(enum.OrderCreated, response.Order{OrderNumber: "1"}))
+	waitForDeliveries(service)
+	deliveries, _ := service.GetDeliveries(context.Background(), webhook.Id)
+
+	//Then
+	assert.Empty(t, received())
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, string(enum.FailedDeliveryStatus), deliveries[0].Status)
+	assert.Contains(t, deliveries[0].LastError, "is internal")
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not routable on the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isInternalIp reports whether the address belongs to the host or a private network,
+// like 127.0.0.1, 10.0.0.0/8 or the cloud metadata address 169.254.169.254.
+func isInternalIp(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip)
+}
+
+// resolvesToInternalIp resolves the host of the webhook url and reports whether any of
+// its addresses is internal.
+func resolvesToInternalIp(ctx context.Context, resolver *net.Resolver, rawUrl string) (bool, error) {
+	webhookUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return false, err
+	}
+
+	addresses, err := resolver.LookupIPAddr(ctx, webhookUrl.Hostname())
+	if err != nil {
+		return false, err
+	}
+
+	for _, address := range addresses {
+		if isInternalIp(address.IP) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newWebhookHttpClient checks the address of every connection it opens, including those
+// of redirects, so that a host resolving to an internal address after registration is
+// refused as well.
+func newWebhookHttpClient(allowInternalHosts bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	if !allowInternalHosts {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isInternalIp(ip) {
+				return fmt.Errorf("webhook address %s is internal", host)
+			}
+			return nil
+		}
+	}
+
+	// connections are not sent through a proxy, whose address is all the dialer would see
+	return &http.Client{Transport: &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}}
+}